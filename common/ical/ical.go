@@ -0,0 +1,111 @@
+package ical
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	ContentType = "text/calendar; charset=utf-8"
+
+	dateTimeLayout = "20060102T150405Z"
+	maxLineOctets  = 75
+)
+
+type Event struct {
+	// UID must stay the same for every version of the event so calendar
+	// clients update it instead of adding a copy.
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	Start       time.Time
+	End         time.Time
+	// Stamp is when the event last changed.
+	Stamp time.Time
+	// Sequence grows every time the event changes.
+	Sequence  int
+	Cancelled bool
+}
+
+type Calendar struct {
+	ProductID string
+	Name      string
+	Events    []Event
+}
+
+// Encode renders the calendar as an RFC 5545 iCalendar stream.
+func (c *Calendar) Encode() []byte {
+	buffer := new(bytes.Buffer)
+	writeLine(buffer, "BEGIN:VCALENDAR")
+	writeLine(buffer, "VERSION:2.0")
+	writeLine(buffer, "PRODID:"+c.ProductID)
+	writeLine(buffer, "CALSCALE:GREGORIAN")
+	writeLine(buffer, "METHOD:PUBLISH")
+	if c.Name != "" {
+		writeLine(buffer, "X-WR-CALNAME:"+escape(c.Name))
+	}
+
+	for _, event := range c.Events {
+		status := "CONFIRMED"
+		if event.Cancelled {
+			status = "CANCELLED"
+		}
+
+		writeLine(buffer, "BEGIN:VEVENT")
+		writeLine(buffer, "UID:"+escape(event.UID))
+		writeLine(buffer, "DTSTAMP:"+formatTime(event.Stamp))
+		writeLine(buffer, "LAST-MODIFIED:"+formatTime(event.Stamp))
+		writeLine(buffer, "DTSTART:"+formatTime(event.Start))
+		writeLine(buffer, "DTEND:"+formatTime(event.End))
+		writeLine(buffer, fmt.Sprintf("SEQUENCE:%d", event.Sequence))
+		writeLine(buffer, "STATUS:"+status)
+		writeLine(buffer, "SUMMARY:"+escape(event.Summary))
+		if event.Description != "" {
+			writeLine(buffer, "DESCRIPTION:"+escape(event.Description))
+		}
+		if event.Location != "" {
+			writeLine(buffer, "LOCATION:"+escape(event.Location))
+		}
+		writeLine(buffer, "END:VEVENT")
+	}
+
+	writeLine(buffer, "END:VCALENDAR")
+	return buffer.Bytes()
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(dateTimeLayout)
+}
+
+// escape quotes the characters that are special in TEXT values.
+func escape(value string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(value)
+}
+
+// writeLine folds content lines longer than 75 octets without splitting a
+// UTF-8 sequence, and ends every line with CRLF.
+func writeLine(buffer *bytes.Buffer, line string) {
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		buffer.WriteString(line[:cut])
+		buffer.WriteString("\r\n ")
+		line = line[cut:]
+		// The leading space of a continuation line counts too.
+		limit = maxLineOctets - 1
+	}
+	buffer.WriteString(line)
+	buffer.WriteString("\r\n")
+}
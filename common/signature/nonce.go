@@ -0,0 +1,57 @@
+package signature
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Nonce is a nonce accepted on an internal request, kept until its timestamp
+// falls out of the clock-skew window.
+type Nonce struct {
+	Nonce     string    `gorm:"type:varchar(100);primaryKey"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+}
+
+func (Nonce) TableName() string {
+	return "request_nonces"
+}
+
+type INonceStore interface {
+	// Use records the nonce and reports whether it had not been seen before.
+	Use(ctx context.Context, nonce string, ttl time.Duration) (bool, error)
+}
+
+// PostgresNonceStore keeps nonces in the service database, so a request
+// accepted by one replica cannot be replayed against another.
+type PostgresNonceStore struct {
+	db *gorm.DB
+}
+
+func NewPostgresNonceStore(db *gorm.DB) INonceStore {
+	return &PostgresNonceStore{db: db}
+}
+
+// Use inserts the nonce in one statement, so concurrent requests carrying it
+// cannot both be accepted. An expired row is taken over.
+func (p *PostgresNonceStore) Use(ctx context.Context, nonce string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	err := p.db.WithContext(ctx).Where("expires_at < ?", now).Delete(&Nonce{}).Error
+	if err != nil {
+		return false, err
+	}
+
+	var used []string
+	err = p.db.WithContext(ctx).Raw(`INSERT INTO request_nonces (nonce, expires_at)
+		VALUES (?, ?)
+		ON CONFLICT (nonce) DO UPDATE
+		SET expires_at = excluded.expires_at
+		WHERE request_nonces.expires_at < ?
+		RETURNING nonce`, nonce, now.Add(ttl), now).Scan(&used).Error
+	if err != nil {
+		return false, err
+	}
+
+	return len(used) > 0, nil
+}
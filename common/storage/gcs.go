@@ -1,11 +1,14 @@
-package gcs
+package storage
 
 import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net/http"
+	"strings"
 	"time"
 
 	"cloud.google.com/go/storage"
@@ -27,19 +30,22 @@ type ServiceAccountKeyJSON struct {
 	UniverseDomain          string `json:"universe_domain"`
 }
 
-type GCSClient struct {
+type GCSConfig struct {
 	ServiceAccountKeyJSON ServiceAccountKeyJSON
 	BucketName            string
 }
 
-type IGCSClient interface {
-	UploadFile(context.Context, string, []byte) (string, error)
+type GCSClient struct {
+	ServiceAccountKeyJSON ServiceAccountKeyJSON
+	BucketName            string
+	expiry                time.Duration
 }
 
-func NewGCSClient(serviceAccountKeyJSON ServiceAccountKeyJSON, bucketName string) IGCSClient {
+func NewGCSClient(cfg GCSConfig, expiry time.Duration) IStorageClient {
 	return &GCSClient{
-		ServiceAccountKeyJSON: serviceAccountKeyJSON,
-		BucketName:            bucketName,
+		ServiceAccountKeyJSON: cfg.ServiceAccountKeyJSON,
+		BucketName:            cfg.BucketName,
+		expiry:                expiry,
 	}
 }
 
@@ -63,7 +69,7 @@ func (g *GCSClient) createClient(ctx context.Context) (*storage.Client, error) {
 
 func (g *GCSClient) UploadFile(ctx context.Context, filename string, data []byte) (string, error) {
 	var (
-		contentType      = "application/octet-stream"
+		contentType      = http.DetectContentType(data)
 		timeoutInSeconds = 60
 	)
 
@@ -90,6 +96,7 @@ func (g *GCSClient) UploadFile(ctx context.Context, filename string, data []byte
 
 	writer := object.NewWriter(ctx)
 	writer.ChunkSize = 0
+	writer.ContentType = contentType
 
 	_, err = io.Copy(writer, buffer)
 	if err != nil {
@@ -103,12 +110,60 @@ func (g *GCSClient) UploadFile(ctx context.Context, filename string, data []byte
 		return "", err
 	}
 
-	_, err = object.Update(ctx, storage.ObjectAttrsToUpdate{ContentType: contentType})
+	return filename, nil
+}
+
+// DeleteFile removes an object. An object that is already gone is not an
+// error.
+func (g *GCSClient) DeleteFile(ctx context.Context, filename string) error {
+	timeoutInSeconds := 60
+
+	client, err := g.createClient(ctx)
 	if err != nil {
-		logrus.Errorf("failed to update: %v", err)
+		logrus.Errorf("failed to create client: %v", err)
+		return err
+	}
+
+	defer func(client *storage.Client) {
+		err := client.Close()
+		if err != nil {
+			logrus.Errorf("failed to close client: %v", err)
+			return
+		}
+	}(client)
+
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(timeoutInSeconds)*time.Second)
+	defer cancel()
+
+	err = client.Bucket(g.BucketName).Object(g.objectName(filename)).Delete(ctx)
+	if err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+		logrus.Errorf("failed to delete: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// SignedURL signs a V4 GET URL with the service account key; it does not
+// call GCS.
+func (g *GCSClient) SignedURL(_ context.Context, filename string) (string, error) {
+	url, err := storage.SignedURL(g.BucketName, g.objectName(filename), &storage.SignedURLOptions{
+		GoogleAccessID: g.ServiceAccountKeyJSON.ClientEmail,
+		PrivateKey:     []byte(strings.ReplaceAll(g.ServiceAccountKeyJSON.PrivateKey, `\n`, "\n")),
+		Method:         http.MethodGet,
+		Expires:        time.Now().Add(g.expiry),
+		Scheme:         storage.SigningSchemeV4,
+	})
+	if err != nil {
+		logrus.Errorf("failed to sign url: %v", err)
 		return "", err
 	}
 
-	url := fmt.Sprintf("https://storage.googleapis.com/%s/%s", g.BucketName, filename)
 	return url, nil
 }
+
+// objectName accepts the public URLs stored before uploads returned object
+// names.
+func (g *GCSClient) objectName(filename string) string {
+	return strings.TrimPrefix(filename, fmt.Sprintf("https://storage.googleapis.com/%s/", g.BucketName))
+}
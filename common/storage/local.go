@@ -0,0 +1,133 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type LocalConfig struct {
+	// Dir is where the files are written.
+	Dir string
+	// BaseURL is the public URL the service serves Dir under, for example
+	// http://localhost:8002/storage.
+	BaseURL string
+	// SigningKey signs the download URLs. The driver refuses to start
+	// without one, since anyone could sign URLs with an empty key.
+	SigningKey string
+}
+
+type LocalClient struct {
+	dir     string
+	baseURL string
+	key     []byte
+	expiry  time.Duration
+}
+
+func NewLocalClient(cfg LocalConfig, expiry time.Duration) IStorageClient {
+	return &LocalClient{
+		dir:     cfg.Dir,
+		baseURL: strings.TrimSuffix(cfg.BaseURL, "/"),
+		key:     []byte(cfg.SigningKey),
+		expiry:  expiry,
+	}
+}
+
+func (l *LocalClient) UploadFile(_ context.Context, filename string, data []byte) (string, error) {
+	path, err := l.path(filename)
+	if err != nil {
+		return "", err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		logrus.Errorf("failed to create directory: %v", err)
+		return "", err
+	}
+
+	err = os.WriteFile(path, data, 0o644)
+	if err != nil {
+		logrus.Errorf("failed to write file: %v", err)
+		return "", err
+	}
+
+	return filename, nil
+}
+
+func (l *LocalClient) DeleteFile(_ context.Context, filename string) error {
+	path, err := l.path(filename)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		logrus.Errorf("failed to delete file: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (l *LocalClient) SignedURL(_ context.Context, filename string) (string, error) {
+	expires := time.Now().Add(l.expiry).Unix()
+	path := (&url.URL{Path: filename}).EscapedPath()
+	return fmt.Sprintf("%s/%s?expires=%d&signature=%s", l.baseURL, path, expires, l.sign(filename, expires)), nil
+}
+
+// Handler serves the files of signed URLs. It must be mounted on the path of
+// BaseURL with a *name wildcard.
+func (l *LocalClient) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		filename := strings.TrimPrefix(c.Param("name"), "/")
+		expires, err := strconv.ParseInt(c.Query("expires"), 10, 64)
+		if err != nil || time.Now().Unix() > expires {
+			c.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+
+		signature, err := hex.DecodeString(c.Query("signature"))
+		expected, _ := hex.DecodeString(l.sign(filename, expires))
+		if err != nil || !hmac.Equal(signature, expected) {
+			c.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+
+		path, err := l.path(filename)
+		if err != nil {
+			c.AbortWithStatus(http.StatusNotFound)
+			return
+		}
+
+		c.File(path)
+	}
+}
+
+func (l *LocalClient) sign(filename string, expires int64) string {
+	mac := hmac.New(sha256.New, l.key)
+	mac.Write([]byte(fmt.Sprintf("%s\n%d", filename, expires)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// path maps an object name inside Dir, refusing names that escape it.
+func (l *LocalClient) path(filename string) (string, error) {
+	cleaned := filepath.Clean("/" + filepath.FromSlash(filename))
+	if cleaned == string(filepath.Separator) {
+		return "", ErrInvalidObjectName
+	}
+
+	return filepath.Join(l.dir, cleaned), nil
+}
@@ -0,0 +1,249 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	s3Algorithm        = "AWS4-HMAC-SHA256"
+	s3UnsignedPayload  = "UNSIGNED-PAYLOAD"
+	s3AmzDateLayout    = "20060102T150405Z"
+	s3ShortDateLayout  = "20060102"
+	s3TimeoutInSeconds = 60
+)
+
+type S3Config struct {
+	// Endpoint is the scheme and host of the S3 API, for example
+	// https://s3.ap-southeast-1.amazonaws.com or http://localhost:9000 for
+	// MinIO. Buckets are addressed path-style so both work.
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+}
+
+// S3Client talks to S3-compatible storage with Signature Version 4 signed
+// requests.
+type S3Client struct {
+	cfg    S3Config
+	expiry time.Duration
+	http   *http.Client
+}
+
+func NewS3Client(cfg S3Config, expiry time.Duration) IStorageClient {
+	cfg.Endpoint = strings.TrimSuffix(cfg.Endpoint, "/")
+	return &S3Client{
+		cfg:    cfg,
+		expiry: expiry,
+		http:   &http.Client{Timeout: s3TimeoutInSeconds * time.Second},
+	}
+}
+
+func (s *S3Client) UploadFile(ctx context.Context, filename string, data []byte) (string, error) {
+	err := s.do(ctx, http.MethodPut, filename, data, http.DetectContentType(data))
+	if err != nil {
+		return "", err
+	}
+
+	return filename, nil
+}
+
+func (s *S3Client) DeleteFile(ctx context.Context, filename string) error {
+	return s.do(ctx, http.MethodDelete, filename, nil, "")
+}
+
+// SignedURL presigns a GET request; it does not call S3.
+func (s *S3Client) SignedURL(_ context.Context, filename string) (string, error) {
+	objectURL, err := s.objectURL(filename)
+	if err != nil {
+		return "", err
+	}
+
+	return s.presign(objectURL, time.Now().UTC()), nil
+}
+
+// presign adds the SigV4 query parameters of a GET request made at t to
+// objectURL.
+func (s *S3Client) presign(objectURL *url.URL, t time.Time) string {
+	query := url.Values{}
+	query.Set("X-Amz-Algorithm", s3Algorithm)
+	query.Set("X-Amz-Credential", fmt.Sprintf("%s/%s", s.cfg.AccessKey, s.scope(t)))
+	query.Set("X-Amz-Date", t.Format(s3AmzDateLayout))
+	query.Set("X-Amz-Expires", strconv.Itoa(int(s.expiry.Seconds())))
+	query.Set("X-Amz-SignedHeaders", "host")
+	objectURL.RawQuery = canonicalQuery(query)
+
+	signature := s.signature(http.MethodGet, objectURL, map[string]string{"host": objectURL.Host}, s3UnsignedPayload, t)
+	objectURL.RawQuery += "&X-Amz-Signature=" + signature
+	return objectURL.String()
+}
+
+func (s *S3Client) do(ctx context.Context, method, filename string, body []byte, contentType string) error {
+	objectURL, err := s.objectURL(filename)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	payloadHash := sha256Hex(body)
+	headers := map[string]string{
+		"host":                 objectURL.Host,
+		"x-amz-content-sha256": payloadHash,
+		"x-amz-date":           now.Format(s3AmzDateLayout),
+	}
+	if contentType != "" {
+		headers["content-type"] = contentType
+	}
+
+	signature := s.signature(method, objectURL, headers, payloadHash, now)
+
+	req, err := http.NewRequestWithContext(ctx, method, objectURL.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for name, value := range headers {
+		if name != "host" {
+			req.Header.Set(name, value)
+		}
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, s.cfg.AccessKey, s.scope(now), signedHeaders(headers), signature))
+
+	resp, err := s.http.Do(req)
+	if err != nil {
+		logrus.Errorf("failed to %s object: %v", strings.ToLower(method), err)
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Deleting a missing object answers 204 as well.
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		logrus.Errorf("failed to %s object: %s %s", strings.ToLower(method), resp.Status, message)
+		return fmt.Errorf("s3 %s %s: %s", method, filename, resp.Status)
+	}
+
+	return nil
+}
+
+func (s *S3Client) objectURL(filename string) (*url.URL, error) {
+	if filename == "" || strings.HasPrefix(filename, "/") {
+		return nil, ErrInvalidObjectName
+	}
+
+	objectURL, err := url.Parse(s.cfg.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	objectURL.Path = fmt.Sprintf("/%s/%s", s.cfg.Bucket, filename)
+	objectURL.RawPath = fmt.Sprintf("/%s/%s", uriEncode(s.cfg.Bucket, false), uriEncode(filename, false))
+	return objectURL, nil
+}
+
+func (s *S3Client) scope(t time.Time) string {
+	return fmt.Sprintf("%s/%s/s3/aws4_request", t.Format(s3ShortDateLayout), s.cfg.Region)
+}
+
+// signature computes the SigV4 signature of a request whose query string is
+// already canonical.
+func (s *S3Client) signature(method string, objectURL *url.URL, headers map[string]string, payloadHash string, t time.Time) string {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	canonicalHeaders := new(strings.Builder)
+	for _, name := range names {
+		fmt.Fprintf(canonicalHeaders, "%s:%s\n", name, strings.TrimSpace(headers[name]))
+	}
+
+	canonicalRequest := strings.Join([]string{
+		method,
+		objectURL.EscapedPath(),
+		objectURL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders(headers),
+		payloadHash,
+	}, "\n")
+
+	stringToSign := strings.Join([]string{
+		s3Algorithm,
+		t.Format(s3AmzDateLayout),
+		s.scope(t),
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.cfg.SecretKey), t.Format(s3ShortDateLayout))
+	key = hmacSHA256(key, s.cfg.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func signedHeaders(headers map[string]string) string {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ";")
+}
+
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, uriEncode(key, true)+"="+uriEncode(query.Get(key), true))
+	}
+	return strings.Join(parts, "&")
+}
+
+// uriEncode percent-encodes everything but the unreserved characters, and
+// the slash too when encodeSlash is set, as SigV4 requires.
+func uriEncode(value string, encodeSlash bool) string {
+	encoded := new(strings.Builder)
+	for _, b := range []byte(value) {
+		switch {
+		case 'A' <= b && b <= 'Z', 'a' <= b && b <= 'z', '0' <= b && b <= '9', b == '-', b == '_', b == '.', b == '~':
+			encoded.WriteByte(b)
+		case b == '/' && !encodeSlash:
+			encoded.WriteByte(b)
+		default:
+			fmt.Fprintf(encoded, "%%%02X", b)
+		}
+	}
+	return encoded.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	DriverGCS   = "gcs"
+	DriverLocal = "local"
+	DriverS3    = "s3"
+
+	defaultSignedURLExpiry = 60 * time.Minute
+)
+
+var (
+	ErrInvalidObjectName = errors.New("invalid object name")
+	ErrMissingSigningKey = errors.New("local storage needs a signing key")
+)
+
+// IStorageClient stores objects by name. UploadFile returns the name to keep
+// in the database; SignedURL turns it into a time-limited download link.
+type IStorageClient interface {
+	UploadFile(context.Context, string, []byte) (string, error)
+	DeleteFile(context.Context, string) error
+	SignedURL(context.Context, string) (string, error)
+}
+
+// IServableStorage is implemented by backends whose files are served by the
+// service itself.
+type IServableStorage interface {
+	Handler() gin.HandlerFunc
+}
+
+type Config struct {
+	Driver          string
+	SignedURLExpiry time.Duration
+	GCS             GCSConfig
+	Local           LocalConfig
+	S3              S3Config
+}
+
+// NewStorageClient returns the backend selected by cfg.Driver. An empty
+// driver means GCS, the only backend before the others were added.
+func NewStorageClient(cfg Config) (IStorageClient, error) {
+	if cfg.SignedURLExpiry <= 0 {
+		cfg.SignedURLExpiry = defaultSignedURLExpiry
+	}
+
+	switch cfg.Driver {
+	case "", DriverGCS:
+		return NewGCSClient(cfg.GCS, cfg.SignedURLExpiry), nil
+	case DriverLocal:
+		if cfg.Local.SigningKey == "" {
+			return nil, ErrMissingSigningKey
+		}
+		return NewLocalClient(cfg.Local, cfg.SignedURLExpiry), nil
+	case DriverS3:
+		return NewS3Client(cfg.S3, cfg.SignedURLExpiry), nil
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", cfg.Driver)
+	}
+}
+
+// SignURLs turns stored object names into signed download URLs. Names that
+// cannot be signed are left out rather than failing the whole response.
+func SignURLs(ctx context.Context, client IStorageClient, names []string) []string {
+	urls := make([]string, 0, len(names))
+	for _, name := range names {
+		url, err := client.SignedURL(ctx, name)
+		if err != nil {
+			logrus.Errorf("failed to sign url of %s: %v", name, err)
+			continue
+		}
+		urls = append(urls, url)
+	}
+
+	return urls
+}
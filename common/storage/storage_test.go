@@ -0,0 +1,18 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewStorageClientRefusesLocalWithoutSigningKey(t *testing.T) {
+	_, err := NewStorageClient(Config{Driver: DriverLocal, Local: LocalConfig{Dir: t.TempDir()}})
+	if !errors.Is(err, ErrMissingSigningKey) {
+		t.Fatalf("NewStorageClient error = %v, want %v", err, ErrMissingSigningKey)
+	}
+
+	_, err = NewStorageClient(Config{Driver: DriverLocal, Local: LocalConfig{Dir: t.TempDir(), SigningKey: "secret"}})
+	if err != nil {
+		t.Fatalf("NewStorageClient with a signing key: %v", err)
+	}
+}
@@ -1,6 +1,7 @@
 package clients
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"field-service/clients/config"
@@ -19,6 +20,7 @@ type UserClient struct {
 
 type IUserClient interface {
 	GetUserByToken(context.Context) (*UserData, error)
+	GetUserByCalendarFeedToken(context.Context, string) (*UserData, error)
 }
 
 func NewUserClient(client config.IClientConfig) IUserClient {
@@ -76,3 +78,38 @@ func (u *UserClient) GetUserByToken(ctx context.Context) (*UserData, error) {
 	json.NewDecoder(resp.Body).Decode(&userResp)
 	return &userResp.Data, nil
 }
+
+// GetUserByCalendarFeedToken resolves the feed token of a calendar
+// subscription, which comes without an access token.
+func (u *UserClient) GetUserByCalendarFeedToken(ctx context.Context, token string) (*UserData, error) {
+	unixTime := time.Now().Unix()
+	generateApikey := fmt.Sprintf("%s:%s:%d", "user-services", u.client.SignatureKey(), unixTime)
+	apiKey := util.GenerateSHA256(generateApikey)
+
+	body, err := json.Marshal(map[string]string{"token": token})
+	if err != nil {
+		return nil, err
+	}
+
+	req, _ := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/api/v1/auth/calendar-token/verify", u.client.BaseURL()), bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(constants.XApiKey, apiKey)
+	req.Header.Set(constants.XrequestAt, fmt.Sprintf("%d", unixTime))
+	req.Header.Set(constants.XserviceName, "user-services")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		logrus.Errorf("err: %v", err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var userResp UserResponse
+	json.NewDecoder(resp.Body).Decode(&userResp)
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("user response: %s", userResp.Message)
+	}
+
+	return &userResp.Data, nil
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"context"
+	"field-service/common/broker"
+	"field-service/config"
+	"field-service/controllers/kafka"
+	"field-service/jobs"
+	"field-service/repositories"
+	"field-service/services"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/spf13/cobra"
+)
+
+var daysAhead int
+
+var generateScheduleCommand = &cobra.Command{
+	Use:   "generate-schedule",
+	Short: "generate field schedules ahead from their weekly templates",
+	Run: func(cmd *cobra.Command, args []string) {
+		_ = godotenv.Load()
+		config.Init()
+		db, err := config.InitDatabase()
+		if err != nil {
+			panic(err)
+		}
+
+		loc, err := time.LoadLocation("Asia/Jakarta")
+		if err != nil {
+			panic(err)
+		}
+		time.Local = loc
+
+		if daysAhead <= 0 {
+			daysAhead = config.Cfg.ScheduleGenerator.DaysAhead
+		}
+
+		repository := repositories.NewRepositoryRegistry(db)
+		service := services.NewServiceRegistry(repository, initStorage(), kafka.NewKafkaRegistry(config.Cfg.Kafka.Brokers), broker.NewBroker())
+		err = jobs.NewScheduleGenerator(repository, service).Run(context.Background(), daysAhead)
+		if err != nil {
+			panic(err)
+		}
+	},
+}
+
+func init() {
+	generateScheduleCommand.Flags().IntVar(&daysAhead, "days", 0, "number of days ahead to generate, defaults to scheduleGenerator.daysAhead")
+	command.AddCommand(generateScheduleCommand)
+}
@@ -1,13 +1,18 @@
 package cmd
 
 import (
+	"common/signature"
+	"common/storage"
+	"context"
 	"field-service/clients"
-	"field-service/common/gcs"
+	"field-service/common/broker"
 	"field-service/common/response"
 	"field-service/config"
 	"field-service/constants"
 	"field-service/controllers"
+	"field-service/controllers/kafka"
 	"field-service/domain/models"
+	"field-service/jobs"
 	"field-service/middlewares"
 	"field-service/repositories"
 	"field-service/routes"
@@ -41,19 +46,49 @@ var command = &cobra.Command{
 		time.Local = loc
 
 		err = db.AutoMigrate(
+			&models.Venue{},
+			&models.Amenity{},
 			&models.Field{},
 			&models.FieldSchedule{},
 			&models.Time{},
+			&models.ScheduleTemplate{},
+			&models.FieldBlackout{},
+			&models.FieldBooking{},
+			&models.FieldReview{},
+			&models.AddOn{},
+			&models.AddOnStock{},
+			&signature.Nonce{},
 		)
 		if err != nil {
 			panic(err)
 		}
-		gcs := initGCS()
+
+		// Indexes gorm tags cannot express: full-text search over the field
+		// name and code, and the reverse lookup of the amenity join table.
+		err = db.Exec("CREATE INDEX IF NOT EXISTS idx_fields_search ON fields USING gin (to_tsvector('simple', name || ' ' || code))").Error
+		if err != nil {
+			panic(err)
+		}
+		err = db.Exec("CREATE INDEX IF NOT EXISTS idx_field_amenities_amenity_id ON field_amenities (amenity_id)").Error
+		if err != nil {
+			panic(err)
+		}
+		// Fields that had a template before the flag existed keep using it.
+		err = db.Exec("UPDATE fields SET has_schedule_template = true WHERE NOT has_schedule_template AND EXISTS (SELECT 1 FROM schedule_templates WHERE schedule_templates.field_id = fields.id)").Error
+		if err != nil {
+			panic(err)
+		}
+		storageClient := initStorage()
 		client := clients.NewClientRegistry()
 		repositories := repositories.NewRepositoryRegistry(db)
-		service := services.NewServiceRegistry(repositories, gcs)
+		kafka := kafka.NewKafkaRegistry(config.Cfg.Kafka.Brokers)
+		service := services.NewServiceRegistry(repositories, storageClient, kafka, broker.NewBroker())
 		controller := controllers.NewControllerRegistry(service)
 
+		if config.Cfg.ScheduleGenerator.Enabled {
+			go jobs.NewScheduleGenerator(repositories, service).Start(context.Background())
+		}
+
 		router := gin.Default()
 		router.Use(middlewares.HandlePanic())
 		router.NoRoute(func(ctx *gin.Context) {
@@ -87,8 +122,14 @@ var command = &cobra.Command{
 			})
 		router.Use(middlewares.RateLimiter(lmt))
 
+		// Files of the local storage driver are served by the service itself.
+		if servable, ok := storageClient.(storage.IServableStorage); ok {
+			router.GET("/storage/*name", servable.Handler())
+		}
+
 		group := router.Group("/api/v1")
-		route := routes.NewRouteRegistry(controller, group, client)
+		internalGroup := router.Group("/internal/v1", middlewares.AuthenticateInternal(signature.NewPostgresNonceStore(db)))
+		route := routes.NewRouteRegistry(controller, group, internalGroup, client)
 		route.Serve()
 
 		port := fmt.Sprintf(":%d", config.Cfg.Port)
@@ -103,14 +144,14 @@ func Run() {
 	}
 }
 
-func initGCS() gcs.IGCSClient {
+func initStorage() storage.IStorageClient {
 	// decode, err := base64.StdEncoding.DecodeString(config.Cfg.GcsPrivateKey)
 	// if err != nil {
 	// 	panic(err)
 	// }
 
 	// stringPrivateKey := string(decode)
-	gcsServiceAccount := gcs.ServiceAccountKeyJSON{
+	gcsServiceAccount := storage.ServiceAccountKeyJSON{
 		Type:                    config.Cfg.GcsType,
 		ProjectID:               config.Cfg.GcsProjectID,
 		PrivateKeyID:            config.Cfg.GcsPrivateKeyID,
@@ -123,9 +164,28 @@ func initGCS() gcs.IGCSClient {
 		ClientX509CertURL:       config.Cfg.GcsClientX509CertURL,
 		UniverseDomain:          config.Cfg.GcsUniverseDomain,
 	}
-	gcsClient := gcs.NewGCSClient(
-		gcsServiceAccount,
-		config.Cfg.GcsBucketName,
-	)
-	return gcsClient
+	storageClient, err := storage.NewStorageClient(storage.Config{
+		Driver:          config.Cfg.Storage.Driver,
+		SignedURLExpiry: time.Duration(config.Cfg.Storage.SignedURLExpiryInMinute) * time.Minute,
+		GCS: storage.GCSConfig{
+			ServiceAccountKeyJSON: gcsServiceAccount,
+			BucketName:            config.Cfg.GcsBucketName,
+		},
+		Local: storage.LocalConfig{
+			Dir:        config.Cfg.Storage.Local.Dir,
+			BaseURL:    config.Cfg.Storage.Local.BaseURL,
+			SigningKey: config.Cfg.Storage.Local.SigningKey,
+		},
+		S3: storage.S3Config{
+			Endpoint:  config.Cfg.Storage.S3.Endpoint,
+			Region:    config.Cfg.Storage.S3.Region,
+			Bucket:    config.Cfg.Storage.S3.Bucket,
+			AccessKey: config.Cfg.Storage.S3.AccessKey,
+			SecretKey: config.Cfg.Storage.S3.SecretKey,
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+	return storageClient
 }
@@ -0,0 +1,179 @@
+package broker
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	historySize = 1024
+	bufferSize  = 64
+)
+
+// Event is one published message. ID is unique within the process and grows
+// with every publish, so a client can resume after the last ID it saw.
+type Event struct {
+	ID    string
+	Topic string
+	Name  string
+	Data  []byte
+	seq   uint64
+}
+
+type Subscription struct {
+	// Replay holds the events published after the requested last event ID.
+	Replay []Event
+	// Missed is set when the requested events are no longer kept, for
+	// example after a restart; the client has to reload instead.
+	Missed bool
+
+	events chan Event
+	topic  string
+	broker *Broker
+}
+
+// Events is closed when the subscriber falls too far behind, which ends the
+// stream so the client reconnects and resumes from its last event ID.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+func (s *Subscription) Close() {
+	s.broker.unsubscribe(s)
+}
+
+type IBroker interface {
+	Publish(topic, name string, data []byte)
+	Subscribe(topic, lastEventID string) *Subscription
+}
+
+// Broker fans events out to the subscribers of a topic inside this process
+// and keeps the last events for resuming streams. Instances of the service do
+// not share it.
+type Broker struct {
+	mu          sync.Mutex
+	boot        int64
+	seq         uint64
+	history     []Event
+	subscribers map[string]map[*Subscription]bool
+}
+
+func NewBroker() IBroker {
+	return &Broker{
+		boot:        time.Now().Unix(),
+		history:     make([]Event, 0, historySize),
+		subscribers: make(map[string]map[*Subscription]bool),
+	}
+}
+
+func (b *Broker) Publish(topic, name string, data []byte) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.seq++
+	event := Event{
+		ID:    fmt.Sprintf("%d-%d", b.boot, b.seq),
+		Topic: topic,
+		Name:  name,
+		Data:  data,
+		seq:   b.seq,
+	}
+
+	if len(b.history) == historySize {
+		copy(b.history, b.history[1:])
+		b.history = b.history[:historySize-1]
+	}
+	b.history = append(b.history, event)
+
+	for subscription := range b.subscribers[topic] {
+		select {
+		case subscription.events <- event:
+		default:
+			b.remove(subscription)
+		}
+	}
+}
+
+func (b *Broker) Subscribe(topic, lastEventID string) *Subscription {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	subscription := &Subscription{
+		events: make(chan Event, bufferSize),
+		topic:  topic,
+		broker: b,
+	}
+
+	if lastEventID != "" {
+		subscription.Replay, subscription.Missed = b.since(topic, lastEventID)
+	}
+
+	if b.subscribers[topic] == nil {
+		b.subscribers[topic] = make(map[*Subscription]bool)
+	}
+	b.subscribers[topic][subscription] = true
+	return subscription
+}
+
+// since returns the events of topic after lastEventID, or reports that some
+// of them are gone.
+func (b *Broker) since(topic, lastEventID string) ([]Event, bool) {
+	boot, seq, ok := parseID(lastEventID)
+	if !ok || boot != b.boot || seq > b.seq {
+		return nil, true
+	}
+
+	if seq < b.seq && (len(b.history) == 0 || b.history[0].seq > seq+1) {
+		return nil, true
+	}
+
+	events := make([]Event, 0)
+	for _, event := range b.history {
+		if event.seq > seq && event.Topic == topic {
+			events = append(events, event)
+		}
+	}
+	return events, false
+}
+
+func (b *Broker) unsubscribe(subscription *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.remove(subscription)
+}
+
+func (b *Broker) remove(subscription *Subscription) {
+	subscribers := b.subscribers[subscription.topic]
+	if !subscribers[subscription] {
+		return
+	}
+
+	delete(subscribers, subscription)
+	if len(subscribers) == 0 {
+		delete(b.subscribers, subscription.topic)
+	}
+	close(subscription.events)
+}
+
+func parseID(id string) (int64, uint64, bool) {
+	boot, seq, found := strings.Cut(id, "-")
+	if !found {
+		return 0, 0, false
+	}
+
+	bootValue, err := strconv.ParseInt(boot, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	seqValue, err := strconv.ParseUint(seq, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	return bootValue, seqValue, true
+}
@@ -0,0 +1,230 @@
+package imageproc
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	WebMaxSize       = 1600
+	ThumbnailMaxSize = 320
+	maxPixels        = 40_000_000
+	jpegQuality      = 85
+)
+
+var (
+	ErrUnsupportedImage = errors.New("unsupported image type")
+	ErrImageTooLarge    = errors.New("image dimensions too large")
+)
+
+// allowedTypes are the sniffed content types accepted for upload.
+var allowedTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/webp": true,
+}
+
+type Variant struct {
+	Data        []byte
+	ContentType string
+	Extension   string
+}
+
+type Result struct {
+	// Hash identifies the uploaded content and is stable across uploads of
+	// the same file.
+	Hash      string
+	Web       Variant
+	Thumbnail Variant
+}
+
+// Process checks the magic number of data, decodes it and re-encodes a
+// web-size and a thumbnail variant turned upright by the EXIF orientation. Re-encoding
+// drops every metadata block, EXIF included. PNG stays PNG to keep
+// transparency; everything else becomes JPEG.
+func Process(data []byte) (*Result, error) {
+	contentType := http.DetectContentType(data)
+	if !allowedTypes[contentType] {
+		return nil, ErrUnsupportedImage
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+
+	if config.Width*config.Height > maxPixels {
+		return nil, ErrImageTooLarge
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+
+	orientation := 1
+	if contentType == "image/jpeg" {
+		orientation = exifOrientation(data)
+	}
+
+	sum := sha256.Sum256(data)
+	result := &Result{Hash: hex.EncodeToString(sum[:16])}
+
+	// Both variants are scaled down before they are turned upright, so orient
+	// only walks the pixels of the small images. The thumbnail is scaled from
+	// the web variant rather than the full upload.
+	web := resize(img, WebMaxSize)
+	thumbnail := resize(web, ThumbnailMaxSize)
+
+	result.Web, err = encode(orient(web, orientation), contentType == "image/png")
+	if err != nil {
+		return nil, err
+	}
+
+	result.Thumbnail, err = encode(orient(thumbnail, orientation), contentType == "image/png")
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// resize scales img down so neither side exceeds maxSize. Smaller images are
+// returned as they are.
+func resize(img image.Image, maxSize int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= maxSize && height <= maxSize {
+		return img
+	}
+
+	if width >= height {
+		height = height * maxSize / width
+		width = maxSize
+	} else {
+		width = width * maxSize / height
+		height = maxSize
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, max(width, 1), max(height, 1)))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Src, nil)
+	return dst
+}
+
+func encode(img image.Image, asPNG bool) (Variant, error) {
+	buffer := new(bytes.Buffer)
+	if asPNG {
+		err := png.Encode(buffer, img)
+		if err != nil {
+			return Variant{}, err
+		}
+		return Variant{Data: buffer.Bytes(), ContentType: "image/png", Extension: "png"}, nil
+	}
+
+	err := jpeg.Encode(buffer, img, &jpeg.Options{Quality: jpegQuality})
+	if err != nil {
+		return Variant{}, err
+	}
+	return Variant{Data: buffer.Bytes(), ContentType: "image/jpeg", Extension: "jpg"}, nil
+}
+
+// exifOrientation reads the orientation tag from the EXIF block of a JPEG.
+// It returns 1, the upright orientation, when there is none.
+func exifOrientation(data []byte) int {
+	offset := 2
+	for offset+4 <= len(data) && data[offset] == 0xFF {
+		marker := data[offset+1]
+		length := int(binary.BigEndian.Uint16(data[offset+2:]))
+		if marker == 0xDA || length < 2 || offset+2+length > len(data) {
+			break
+		}
+
+		segment := data[offset+4 : offset+2+length]
+		if marker == 0xE1 && len(segment) > 14 && string(segment[:6]) == "Exif\x00\x00" {
+			return tiffOrientation(segment[6:])
+		}
+
+		offset += 2 + length
+	}
+
+	return 1
+}
+
+func tiffOrientation(tiff []byte) int {
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd+2 > len(tiff) {
+		return 1
+	}
+
+	entries := int(order.Uint16(tiff[ifd:]))
+	for i := 0; i < entries; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			break
+		}
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			return int(order.Uint16(tiff[entry+8:]))
+		}
+	}
+
+	return 1
+}
+
+// orient turns img upright according to an EXIF orientation value.
+func orient(img image.Image, orientation int) image.Image {
+	if orientation < 2 || orientation > 8 {
+		return img
+	}
+
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if orientation >= 5 {
+		width, height = height, width
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < bounds.Dy(); y++ {
+		for x := 0; x < bounds.Dx(); x++ {
+			var dx, dy int
+			switch orientation {
+			case 2:
+				dx, dy = width-1-x, y
+			case 3:
+				dx, dy = width-1-x, height-1-y
+			case 4:
+				dx, dy = x, height-1-y
+			case 5:
+				dx, dy = y, x
+			case 6:
+				dx, dy = width-1-y, x
+			case 7:
+				dx, dy = width-1-y, height-1-x
+			case 8:
+				dx, dy = y, height-1-x
+			}
+			dst.Set(dx, dy, img.At(bounds.Min.X+x, bounds.Min.Y+y))
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,88 @@
+package timeofday
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeOfDay is a wall clock time stored as minutes since midnight. 24:00 is
+// allowed so a slot can end at midnight.
+type TimeOfDay int
+
+const (
+	Midnight TimeOfDay = 0
+	EndOfDay TimeOfDay = 24 * 60
+)
+
+var ErrInvalidTimeOfDay = errors.New("invalid time of day")
+
+// Parse accepts "15:04" or "15:04:05". Seconds are dropped.
+func Parse(value string) (TimeOfDay, error) {
+	parts := strings.Split(strings.TrimSpace(value), ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, ErrInvalidTimeOfDay
+	}
+
+	hour, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, ErrInvalidTimeOfDay
+	}
+
+	minute, err := strconv.Atoi(parts[1])
+	if err != nil || minute < 0 || minute > 59 {
+		return 0, ErrInvalidTimeOfDay
+	}
+
+	t := TimeOfDay(hour*60 + minute)
+	if hour < 0 || t > EndOfDay {
+		return 0, ErrInvalidTimeOfDay
+	}
+
+	return t, nil
+}
+
+func (t TimeOfDay) Minutes() int {
+	return int(t)
+}
+
+func (t TimeOfDay) String() string {
+	return fmt.Sprintf("%02d:%02d", int(t)/60, int(t)%60)
+}
+
+// On returns the moment this time of day happens on date, in time.Local.
+func (t TimeOfDay) On(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, int(t), 0, 0, time.Local)
+}
+
+func (t TimeOfDay) Value() (driver.Value, error) {
+	return t.String() + ":00", nil
+}
+
+func (t *TimeOfDay) Scan(value any) error {
+	var (
+		parsed TimeOfDay
+		err    error
+	)
+
+	switch v := value.(type) {
+	case string:
+		parsed, err = Parse(v)
+	case []byte:
+		parsed, err = Parse(string(v))
+	case time.Time:
+		parsed = TimeOfDay(v.Hour()*60 + v.Minute())
+	default:
+		err = ErrInvalidTimeOfDay
+	}
+
+	if err != nil {
+		return err
+	}
+
+	*t = parsed
+	return nil
+}
@@ -10,26 +10,54 @@ import (
 var Cfg AppConfig
 
 type AppConfig struct {
-	Port                       int             `json:"port"`
-	AppName                    string          `json:"appName"`
-	AppEnv                     string          `json:"appEnv"`
-	SignatureKey               string          `json:"signatureKey"`
-	Database                   Database        `json:"database"`
-	RateLimiterMaxRequest      int             `json:"rateLimiterMaxRequest"`
-	RateLimiterTimeSecond      int             `json:"rateLimiterTimeSecond"`
-	InternalService            InternalService `json:"internalService"`
-	GcsType                    string          `json:"gcsType"`
-	GcsProjectID               string          `json:"gcsProjectID"`
-	GcsPrivateKeyID            string          `json:"gcsPrivateKeyID"`
-	GcsPrivateKey              string          `json:"gcsPrivateKey"`
-	GcsClientEmail             string          `json:"gcsClientEmail"`
-	GcsClientID                string          `json:"gcsClientID"`
-	GcsAuthURI                 string          `json:"gcsAuthURI"`
-	GcsTokenURI                string          `json:"gcsTokenURI"`
-	GcsAuthProviderX509CertURL string          `json:"gcsAuthProviderX509CertURL"`
-	GcsClientX509CertURL       string          `json:"gcsClientX509CertURL"`
-	GcsUniverseDomain          string          `json:"gcsUniverseDomain"`
-	GcsBucketName              string          `json:"gcsBucketName"`
+	Port                       int               `json:"port"`
+	AppName                    string            `json:"appName"`
+	AppEnv                     string            `json:"appEnv"`
+	SignatureKey               string            `json:"signatureKey"`
+	Database                   Database          `json:"database"`
+	RateLimiterMaxRequest      int               `json:"rateLimiterMaxRequest"`
+	RateLimiterTimeSecond      int               `json:"rateLimiterTimeSecond"`
+	InternalService            InternalService   `json:"internalService"`
+	InternalCallers            map[string]string `json:"internalCallers"`
+	SignatureMaxSkewSecond     int               `json:"signatureMaxSkewSecond"`
+	ScheduleGenerator          ScheduleGenerator `json:"scheduleGenerator"`
+	Kafka                      Kafka             `json:"kafka"`
+	GcsType                    string            `json:"gcsType"`
+	GcsProjectID               string            `json:"gcsProjectID"`
+	GcsPrivateKeyID            string            `json:"gcsPrivateKeyID"`
+	GcsPrivateKey              string            `json:"gcsPrivateKey"`
+	GcsClientEmail             string            `json:"gcsClientEmail"`
+	GcsClientID                string            `json:"gcsClientID"`
+	GcsAuthURI                 string            `json:"gcsAuthURI"`
+	GcsTokenURI                string            `json:"gcsTokenURI"`
+	GcsAuthProviderX509CertURL string            `json:"gcsAuthProviderX509CertURL"`
+	GcsClientX509CertURL       string            `json:"gcsClientX509CertURL"`
+	GcsUniverseDomain          string            `json:"gcsUniverseDomain"`
+	GcsBucketName              string            `json:"gcsBucketName"`
+	Storage                    Storage           `json:"storage"`
+}
+
+// Storage selects where uploaded files go. The gcs driver keeps using the
+// gcs* keys above.
+type Storage struct {
+	Driver                  string       `json:"driver"`
+	SignedURLExpiryInMinute int          `json:"signedURLExpiryInMinute"`
+	Local                   LocalStorage `json:"local"`
+	S3                      S3Storage    `json:"s3"`
+}
+
+type LocalStorage struct {
+	Dir        string `json:"dir"`
+	BaseURL    string `json:"baseURL"`
+	SigningKey string `json:"signingKey"`
+}
+
+type S3Storage struct {
+	Endpoint  string `json:"endpoint"`
+	Region    string `json:"region"`
+	Bucket    string `json:"bucket"`
+	AccessKey string `json:"accessKey"`
+	SecretKey string `json:"secretKey"`
 }
 
 type Database struct {
@@ -53,6 +81,19 @@ type User struct {
 	SignatureKey string `json:"signatureKey"`
 }
 
+type ScheduleGenerator struct {
+	Enabled        bool `json:"enabled"`
+	DaysAhead      int  `json:"daysAhead"`
+	IntervalMinute int  `json:"intervalMinute"`
+}
+
+type Kafka struct {
+	Brokers     []string `json:"brokers"`
+	TimeoutInMs int      `json:"timeoutInMs"`
+	MaxRetry    int      `json:"maxRetry"`
+	Topic       string   `json:"topic"`
+}
+
 func Init() {
 	err := util.BindFromJSON(&Cfg, "config.json", ".")
 	if err != nil {
@@ -0,0 +1,13 @@
+package error
+
+import "errors"
+
+var (
+	ErrAddOnNotFound = errors.New("add-on not found")
+	ErrAddOnExists   = errors.New("add-on already exist")
+)
+
+var AddOnErrors = []error{
+	ErrAddOnNotFound,
+	ErrAddOnExists,
+}
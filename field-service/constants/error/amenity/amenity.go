@@ -0,0 +1,13 @@
+package error
+
+import "errors"
+
+var (
+	ErrAmenityNotFound = errors.New("amenity not found")
+	ErrAmenityExists   = errors.New("amenity already exist")
+)
+
+var AmenityErrors = []error{
+	ErrAmenityNotFound,
+	ErrAmenityExists,
+}
@@ -1,9 +1,14 @@
 package error
 
 import (
+	errAddOn "field-service/constants/error/add_on"
+	errAmenity "field-service/constants/error/amenity"
 	errField "field-service/constants/error/field"
+	errFieldBlackout "field-service/constants/error/field_blackout"
+	errFieldReview "field-service/constants/error/field_review"
 	errFieldSch "field-service/constants/error/field_schedule"
 	errTime "field-service/constants/error/time"
+	errVenue "field-service/constants/error/venue"
 )
 
 func ErrMapping(err error) bool {
@@ -12,12 +17,22 @@ func ErrMapping(err error) bool {
 		FieldErrors         = errField.FieldErrors
 		FieldScheduleErrors = errFieldSch.FieldScheduleErrors
 		TimeErrors          = errTime.TimeErrors
+		FieldBlackoutErrors = errFieldBlackout.FieldBlackoutErrors
+		VenueErrors         = errVenue.VenueErrors
+		AmenityErrors       = errAmenity.AmenityErrors
+		FieldReviewErrors   = errFieldReview.FieldReviewErrors
+		AddOnErrors         = errAddOn.AddOnErrors
 	)
 	allErrors := make([]error, 0)
 	allErrors = append(allErrors, GeneralErrors...)
 	allErrors = append(allErrors, FieldErrors...)
 	allErrors = append(allErrors, FieldScheduleErrors...)
 	allErrors = append(allErrors, TimeErrors...)
+	allErrors = append(allErrors, FieldBlackoutErrors...)
+	allErrors = append(allErrors, VenueErrors...)
+	allErrors = append(allErrors, AmenityErrors...)
+	allErrors = append(allErrors, FieldReviewErrors...)
+	allErrors = append(allErrors, AddOnErrors...)
 
 	for _, item := range allErrors {
 		if err.Error() == item.Error() {
@@ -5,9 +5,11 @@ import "errors"
 var (
 	ErrFieldNotFound = errors.New("field not found")
 	ErrFieldExists   = errors.New("field already exist")
+	ErrInvalidParent = errors.New("parent field must be another full pitch")
 )
 
 var FieldErrors = []error{
 	ErrFieldNotFound,
 	ErrFieldExists,
+	ErrInvalidParent,
 }
@@ -0,0 +1,15 @@
+package error
+
+import "errors"
+
+var (
+	ErrFieldBlackoutNotFound = errors.New("field blackout not found")
+	ErrInvalidBlackoutRange  = errors.New("blackout must end after it starts")
+	ErrBlackoutNotAnnounced  = errors.New("blackout could not be announced, please try again")
+)
+
+var FieldBlackoutErrors = []error{
+	ErrFieldBlackoutNotFound,
+	ErrInvalidBlackoutRange,
+	ErrBlackoutNotAnnounced,
+}
@@ -0,0 +1,17 @@
+package error
+
+import "errors"
+
+var (
+	ErrFieldReviewNotFound   = errors.New("field review not found")
+	ErrFieldReviewNotAllowed = errors.New("only the customer who paid for this booking can review it")
+	ErrFieldReviewExists     = errors.New("this booking has already been reviewed")
+	ErrFieldReviewTooEarly   = errors.New("a booking can only be reviewed once it has ended")
+)
+
+var FieldReviewErrors = []error{
+	ErrFieldReviewNotFound,
+	ErrFieldReviewNotAllowed,
+	ErrFieldReviewExists,
+	ErrFieldReviewTooEarly,
+}
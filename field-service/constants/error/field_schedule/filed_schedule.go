@@ -3,11 +3,29 @@ package error
 import "errors"
 
 var (
-	ErrFieldScheduleNotFound = errors.New("field schedule not found")
-	ErrFieldScheduleExists   = errors.New("field schedule already exist")
+	ErrFieldScheduleNotFound     = errors.New("field schedule not found")
+	ErrFieldScheduleExists       = errors.New("field schedule already exist")
+	ErrInvalidDateRange          = errors.New("end date must not be before start date")
+	ErrDateRangeTooLong          = errors.New("date range is too long")
+	ErrDateInThePast             = errors.New("date must not be in the past")
+	ErrFieldScheduleClosed       = errors.New("field schedule is closed for a blackout")
+	ErrFieldScheduleBlocked      = errors.New("field schedule is blocked by a booking of a linked field")
+	ErrInvalidDate               = errors.New("invalid date")
+	ErrFieldScheduleNotBooked    = errors.New("field schedule is not booked by the order")
+	ErrFieldScheduleNotAvailable = errors.New("field schedule is not available")
+	ErrFieldScheduleBooked       = errors.New("field schedule is already booked")
 )
 
 var FieldScheduleErrors = []error{
 	ErrFieldScheduleNotFound,
 	ErrFieldScheduleExists,
+	ErrInvalidDateRange,
+	ErrDateRangeTooLong,
+	ErrDateInThePast,
+	ErrFieldScheduleClosed,
+	ErrFieldScheduleBlocked,
+	ErrInvalidDate,
+	ErrFieldScheduleNotBooked,
+	ErrFieldScheduleNotAvailable,
+	ErrFieldScheduleBooked,
 }
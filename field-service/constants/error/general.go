@@ -11,6 +11,11 @@ var (
 	ErrInvalidUploadFile   = errors.New("invalid upload file")
 	ErrForbidden           = errors.New("forbidden")
 	ErrSizetooBig          = errors.New("upload file size too big")
+	ErrUnsupportedImage    = errors.New("unsupported image type, use jpeg, png or webp")
+	ErrImageTooLarge       = errors.New("image dimensions too large")
+	ErrInvalidSignature    = errors.New("invalid request signature")
+	ErrRequestExpired      = errors.New("request expired")
+	ErrRequestReplayed     = errors.New("request already processed")
 )
 
 var GeneralErrors = []error{
@@ -18,4 +23,6 @@ var GeneralErrors = []error{
 	ErrSQLError,
 	ErrToManyRequests,
 	ErrUnauthorized, ErrInvalidToken, ErrForbidden,
+	ErrInvalidSignature, ErrRequestExpired, ErrRequestReplayed,
+	ErrInvalidUploadFile, ErrSizetooBig, ErrUnsupportedImage, ErrImageTooLarge,
 }
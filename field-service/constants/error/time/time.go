@@ -3,11 +3,21 @@ package error
 import "errors"
 
 var (
-	ErrTimeNotFound = errors.New("Time not found")
-	ErrTimeExists   = errors.New("Time already exist")
+	ErrTimeNotFound        = errors.New("Time not found")
+	ErrTimeExists          = errors.New("Time already exist")
+	ErrInvalidTimeFormat   = errors.New("time must use the HH:MM format")
+	ErrInvalidTimeRange    = errors.New("end time must be after start time")
+	ErrInvalidSlotDuration = errors.New("time slot must last 60, 90 or 120 minutes")
+	ErrTimeOverlap         = errors.New("time overlaps an existing time slot")
+	ErrTimeInUse           = errors.New("time is used by upcoming field schedules")
 )
 
 var TimeErrors = []error{
 	ErrTimeNotFound,
 	ErrTimeExists,
+	ErrInvalidTimeFormat,
+	ErrInvalidTimeRange,
+	ErrInvalidSlotDuration,
+	ErrTimeOverlap,
+	ErrTimeInUse,
 }
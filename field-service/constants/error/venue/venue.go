@@ -0,0 +1,15 @@
+package error
+
+import "errors"
+
+var (
+	ErrVenueNotFound       = errors.New("venue not found")
+	ErrInvalidTimezone     = errors.New("invalid venue timezone")
+	ErrInvalidOpeningHours = errors.New("venue must close after it opens")
+)
+
+var VenueErrors = []error{
+	ErrVenueNotFound,
+	ErrInvalidTimezone,
+	ErrInvalidOpeningHours,
+}
@@ -4,27 +4,55 @@ type FieldScheduleStatusName string
 type FieldScheduleStatus int
 
 const (
-	Available FieldScheduleStatus = 100
-	Booked    FieldScheduleStatus = 200
+	Available   FieldScheduleStatus = 100
+	Booked      FieldScheduleStatus = 200
+	Unavailable FieldScheduleStatus = 300
+	Blocked     FieldScheduleStatus = 400
 
-	AvailableString FieldScheduleStatusName = "Available"
-	BookedString    FieldScheduleStatusName = "Booked"
+	AvailableString   FieldScheduleStatusName = "Available"
+	BookedString      FieldScheduleStatusName = "Booked"
+	UnavailableString FieldScheduleStatusName = "Unavailable"
+	BlockedString     FieldScheduleStatusName = "Blocked"
+)
+
+// ReservedEvent and ReleasedEvent announce an order awaiting payment taking a
+// schedule and giving it back, which leave its status as it is.
+const (
+	ReservedEvent = "reserved"
+	ReleasedEvent = "released"
 )
 
 var mapFieldScheduleStatusIntToString = map[FieldScheduleStatus]FieldScheduleStatusName{
-	Available: AvailableString,
-	Booked:    BookedString,
+	Available:   AvailableString,
+	Booked:      BookedString,
+	Unavailable: UnavailableString,
+	Blocked:     BlockedString,
+}
+
+// mapFieldScheduleStatusToEvent names the change a status announces on the
+// schedule stream.
+var mapFieldScheduleStatusToEvent = map[FieldScheduleStatus]string{
+	Available:   ReleasedEvent,
+	Booked:      "booked",
+	Unavailable: "closed",
+	Blocked:     "blocked",
 }
 
 var mapFieldScheduleStatusStringToInt = map[FieldScheduleStatusName]FieldScheduleStatus{
-	AvailableString: Available,
-	BookedString:    Booked,
+	AvailableString:   Available,
+	BookedString:      Booked,
+	UnavailableString: Unavailable,
+	BlockedString:     Blocked,
 }
 
 func (f FieldScheduleStatus) GetStatusString() FieldScheduleStatusName {
 	return mapFieldScheduleStatusIntToString[f]
 }
 
+func (f FieldScheduleStatus) GetEventName() string {
+	return mapFieldScheduleStatusToEvent[f]
+}
+
 func (f FieldScheduleStatusName) GetStatusInt() FieldScheduleStatus {
 	return mapFieldScheduleStatusStringToInt[f]
 }
@@ -0,0 +1,347 @@
+package controllers
+
+import (
+	errValidation "field-service/common/error"
+	"field-service/common/response"
+	"field-service/domain/dto"
+	"field-service/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type AddOnController struct {
+	service services.IServiceRegistry
+}
+
+type IAddOnController interface {
+	GetAll(*gin.Context)
+	GetAllByVenueUUID(*gin.Context)
+	GetByUUID(*gin.Context)
+	Create(*gin.Context)
+	Update(*gin.Context)
+	Delete(*gin.Context)
+	GetByUUIDs(*gin.Context)
+	TakeStock(*gin.Context)
+	ReturnStock(*gin.Context)
+}
+
+func NewAddOnController(service services.IServiceRegistry) IAddOnController {
+	return &AddOnController{service: service}
+}
+
+func (a *AddOnController) GetAll(c *gin.Context) {
+	var params dto.AddOnRequestParam
+	err := c.ShouldBindQuery(&params)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(params)
+	if err != nil {
+		errMsg := http.StatusText(http.StatusUnprocessableEntity)
+		errorResp := errValidation.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:    http.StatusBadRequest,
+			Err:     err,
+			Message: &errMsg,
+			Data:    errorResp,
+			Gin:     c,
+		})
+		return
+	}
+
+	result, err := a.service.GetAddOn().GetAllByVenueUUID(c, params.VenueID, false)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (a *AddOnController) GetAllByVenueUUID(c *gin.Context) {
+	result, err := a.service.GetAddOn().GetAllByVenueUUID(c, c.Param("uuid"), true)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (a *AddOnController) GetByUUID(c *gin.Context) {
+	result, err := a.service.GetAddOn().GetByUUID(c, c.Param("uuid"))
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (a *AddOnController) Create(c *gin.Context) {
+	var req dto.AddOnRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(req)
+	if err != nil {
+		errMsg := http.StatusText(http.StatusUnprocessableEntity)
+		errorResp := errValidation.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:    http.StatusBadRequest,
+			Err:     err,
+			Message: &errMsg,
+			Data:    errorResp,
+			Gin:     c,
+		})
+		return
+	}
+
+	result, err := a.service.GetAddOn().Create(c, &req)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusCreated,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (a *AddOnController) Update(c *gin.Context) {
+	var req dto.UpdateAddOnRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(req)
+	if err != nil {
+		errMsg := http.StatusText(http.StatusUnprocessableEntity)
+		errorResp := errValidation.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:    http.StatusBadRequest,
+			Err:     err,
+			Message: &errMsg,
+			Data:    errorResp,
+			Gin:     c,
+		})
+		return
+	}
+
+	result, err := a.service.GetAddOn().Update(c, c.Param("uuid"), &req)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (a *AddOnController) Delete(c *gin.Context) {
+	err := a.service.GetAddOn().Delete(c, c.Param("uuid"))
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Gin:  c,
+	})
+}
+
+func (a *AddOnController) GetByUUIDs(c *gin.Context) {
+	var req dto.AddOnLookupRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(req)
+	if err != nil {
+		errMsg := http.StatusText(http.StatusUnprocessableEntity)
+		errorResp := errValidation.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:    http.StatusBadRequest,
+			Err:     err,
+			Message: &errMsg,
+			Data:    errorResp,
+			Gin:     c,
+		})
+		return
+	}
+
+	result, err := a.service.GetAddOn().GetByUUIDs(c, &req)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (a *AddOnController) TakeStock(c *gin.Context) {
+	var req dto.TakeAddOnStockRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(req)
+	if err != nil {
+		errMsg := http.StatusText(http.StatusUnprocessableEntity)
+		errorResp := errValidation.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:    http.StatusBadRequest,
+			Err:     err,
+			Message: &errMsg,
+			Data:    errorResp,
+			Gin:     c,
+		})
+		return
+	}
+
+	err = a.service.GetAddOn().TakeStock(c, &req)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Gin:  c,
+	})
+}
+
+func (a *AddOnController) ReturnStock(c *gin.Context) {
+	var req dto.ReturnAddOnStockRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(req)
+	if err != nil {
+		errMsg := http.StatusText(http.StatusUnprocessableEntity)
+		errorResp := errValidation.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:    http.StatusBadRequest,
+			Err:     err,
+			Message: &errMsg,
+			Data:    errorResp,
+			Gin:     c,
+		})
+		return
+	}
+
+	err = a.service.GetAddOn().ReturnStock(c, &req)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Gin:  c,
+	})
+}
@@ -0,0 +1,169 @@
+package controllers
+
+import (
+	errValidation "field-service/common/error"
+	"field-service/common/response"
+	"field-service/domain/dto"
+	"field-service/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type AmenityController struct {
+	service services.IServiceRegistry
+}
+
+type IAmenityController interface {
+	GetAll(*gin.Context)
+	GetByUUID(*gin.Context)
+	Create(*gin.Context)
+	Update(*gin.Context)
+	Delete(*gin.Context)
+}
+
+func NewAmenityController(service services.IServiceRegistry) IAmenityController {
+	return &AmenityController{service: service}
+}
+
+func (a *AmenityController) GetAll(c *gin.Context) {
+	result, err := a.service.GetAmenity().GetAll(c)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (a *AmenityController) GetByUUID(c *gin.Context) {
+	result, err := a.service.GetAmenity().GetByUUID(c, c.Param("uuid"))
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (a *AmenityController) Create(c *gin.Context) {
+	var req dto.AmenityRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(req)
+	if err != nil {
+		errMsg := http.StatusText(http.StatusUnprocessableEntity)
+		errorResp := errValidation.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:    http.StatusBadRequest,
+			Err:     err,
+			Message: &errMsg,
+			Data:    errorResp,
+			Gin:     c,
+		})
+		return
+	}
+
+	result, err := a.service.GetAmenity().Create(c, &req)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusCreated,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (a *AmenityController) Update(c *gin.Context) {
+	var req dto.AmenityRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(req)
+	if err != nil {
+		errMsg := http.StatusText(http.StatusUnprocessableEntity)
+		errorResp := errValidation.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:    http.StatusBadRequest,
+			Err:     err,
+			Message: &errMsg,
+			Data:    errorResp,
+			Gin:     c,
+		})
+		return
+	}
+
+	result, err := a.service.GetAmenity().Update(c, c.Param("uuid"), &req)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (a *AmenityController) Delete(c *gin.Context) {
+	err := a.service.GetAmenity().Delete(c, c.Param("uuid"))
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Gin:  c,
+	})
+}
@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"common/ical"
 	errValidation "field-service/common/error"
 	"field-service/common/response"
 	"field-service/domain/dto"
@@ -23,6 +24,8 @@ type IFieldController interface {
 	Create(*gin.Context)
 	Update(*gin.Context)
 	Delete(*gin.Context)
+	Search(*gin.Context)
+	GetCalendar(*gin.Context)
 }
 
 func NewFieldController(service services.IServiceRegistry) IFieldController {
@@ -111,6 +114,23 @@ func (f *FieldController) GetByUUID(c *gin.Context) {
 	})
 }
 
+func (f *FieldController) GetCalendar(c *gin.Context) {
+	uuid := c.Param("uuid")
+
+	result, err := f.service.GetField().GetCalendar(c, uuid)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	c.Header("Cache-Control", "private, no-cache")
+	c.Data(http.StatusOK, ical.ContentType, result)
+}
+
 func (f *FieldController) Create(c *gin.Context) {
 	var req dto.FieldRequest
 	err := c.ShouldBindWith(&req, binding.FormMultipart)
@@ -216,3 +236,47 @@ func (f *FieldController) Delete(c *gin.Context) {
 		Gin:  c,
 	})
 }
+
+func (f *FieldController) Search(c *gin.Context) {
+	var params dto.FieldSearchRequestParam
+	err := c.ShouldBindQuery(&params)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(params)
+	if err != nil {
+		errMsg := http.StatusText(http.StatusUnprocessableEntity)
+		errorResp := errValidation.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:    http.StatusBadRequest,
+			Err:     err,
+			Message: &errMsg,
+			Data:    errorResp,
+			Gin:     c,
+		})
+		return
+	}
+
+	result, err := f.service.GetField().Search(c, &params)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
@@ -0,0 +1,195 @@
+package controllers
+
+import (
+	errValidation "field-service/common/error"
+	"field-service/common/response"
+	"field-service/domain/dto"
+	"field-service/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type FieldBlackoutController struct {
+	service services.IServiceRegistry
+}
+
+type IFieldBlackoutController interface {
+	GetAll(*gin.Context)
+	GetByUUID(*gin.Context)
+	Create(*gin.Context)
+	Update(*gin.Context)
+	Delete(*gin.Context)
+}
+
+func NewFieldBlackoutController(service services.IServiceRegistry) IFieldBlackoutController {
+	return &FieldBlackoutController{service: service}
+}
+
+func (f *FieldBlackoutController) GetAll(c *gin.Context) {
+	var params dto.FieldBlackoutRequestParam
+	err := c.ShouldBindQuery(&params)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(params)
+	if err != nil {
+		errMsg := http.StatusText(http.StatusUnprocessableEntity)
+		errorResp := errValidation.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:    http.StatusBadRequest,
+			Err:     err,
+			Message: &errMsg,
+			Data:    errorResp,
+			Gin:     c,
+		})
+		return
+	}
+
+	result, err := f.service.GetFieldBlackout().GetAllByFieldUUID(c, params.FieldID)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (f *FieldBlackoutController) GetByUUID(c *gin.Context) {
+	result, err := f.service.GetFieldBlackout().GetByUUID(c, c.Param("uuid"))
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (f *FieldBlackoutController) Create(c *gin.Context) {
+	var req dto.FieldBlackoutRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(req)
+	if err != nil {
+		errMsg := http.StatusText(http.StatusUnprocessableEntity)
+		errorResp := errValidation.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:    http.StatusBadRequest,
+			Err:     err,
+			Message: &errMsg,
+			Data:    errorResp,
+			Gin:     c,
+		})
+		return
+	}
+
+	result, err := f.service.GetFieldBlackout().Create(c, &req)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusCreated,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (f *FieldBlackoutController) Update(c *gin.Context) {
+	var req dto.UpdateFieldBlackoutRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(req)
+	if err != nil {
+		errMsg := http.StatusText(http.StatusUnprocessableEntity)
+		errorResp := errValidation.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:    http.StatusBadRequest,
+			Err:     err,
+			Message: &errMsg,
+			Data:    errorResp,
+			Gin:     c,
+		})
+		return
+	}
+
+	result, err := f.service.GetFieldBlackout().Update(c, c.Param("uuid"), &req)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (f *FieldBlackoutController) Delete(c *gin.Context) {
+	err := f.service.GetFieldBlackout().Delete(c, c.Param("uuid"))
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Gin:  c,
+	})
+}
@@ -0,0 +1,221 @@
+package controllers
+
+import (
+	errValidation "field-service/common/error"
+	"field-service/common/response"
+	"field-service/domain/dto"
+	"field-service/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type FieldReviewController struct {
+	service services.IServiceRegistry
+}
+
+type IFieldReviewController interface {
+	GetAllByFieldUUID(*gin.Context)
+	GetAllWithPagination(*gin.Context)
+	Create(*gin.Context)
+	Moderate(*gin.Context)
+	Delete(*gin.Context)
+}
+
+func NewFieldReviewController(service services.IServiceRegistry) IFieldReviewController {
+	return &FieldReviewController{service: service}
+}
+
+func (f *FieldReviewController) GetAllByFieldUUID(c *gin.Context) {
+	var params dto.FieldReviewRequestParam
+	err := c.ShouldBindQuery(&params)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(params)
+	if err != nil {
+		errMsg := http.StatusText(http.StatusUnprocessableEntity)
+		errorResp := errValidation.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:    http.StatusBadRequest,
+			Err:     err,
+			Message: &errMsg,
+			Data:    errorResp,
+			Gin:     c,
+		})
+		return
+	}
+
+	result, err := f.service.GetFieldReview().GetAllByFieldUUID(c, c.Param("uuid"), &params)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (f *FieldReviewController) GetAllWithPagination(c *gin.Context) {
+	var params dto.FieldReviewAdminRequestParam
+	err := c.ShouldBindQuery(&params)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(params)
+	if err != nil {
+		errMsg := http.StatusText(http.StatusUnprocessableEntity)
+		errorResp := errValidation.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:    http.StatusBadRequest,
+			Err:     err,
+			Message: &errMsg,
+			Data:    errorResp,
+			Gin:     c,
+		})
+		return
+	}
+
+	result, err := f.service.GetFieldReview().GetAllWithPagination(c, &params)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (f *FieldReviewController) Create(c *gin.Context) {
+	var req dto.FieldReviewRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(req)
+	if err != nil {
+		errMsg := http.StatusText(http.StatusUnprocessableEntity)
+		errorResp := errValidation.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:    http.StatusBadRequest,
+			Err:     err,
+			Message: &errMsg,
+			Data:    errorResp,
+			Gin:     c,
+		})
+		return
+	}
+
+	result, err := f.service.GetFieldReview().Create(c.Request.Context(), &req)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusCreated,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (f *FieldReviewController) Moderate(c *gin.Context) {
+	var req dto.ModerateFieldReviewRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(req)
+	if err != nil {
+		errMsg := http.StatusText(http.StatusUnprocessableEntity)
+		errorResp := errValidation.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:    http.StatusBadRequest,
+			Err:     err,
+			Message: &errMsg,
+			Data:    errorResp,
+			Gin:     c,
+		})
+		return
+	}
+
+	result, err := f.service.GetFieldReview().Moderate(c, c.Param("uuid"), &req)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (f *FieldReviewController) Delete(c *gin.Context) {
+	err := f.service.GetFieldReview().Delete(c, c.Param("uuid"))
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Gin:  c,
+	})
+}
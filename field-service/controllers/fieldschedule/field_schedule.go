@@ -1,16 +1,21 @@
 package controllers
 
 import (
+	"encoding/json"
 	errValidation "field-service/common/error"
 	"field-service/common/response"
+	"field-service/common/util"
 	"field-service/domain/dto"
 	"field-service/services"
+	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 )
 
+const availabilityCacheControl = "public, max-age=60, s-maxage=60, stale-while-revalidate=30"
+
 type FieldScheduleController struct {
 	service services.IServiceRegistry
 }
@@ -19,10 +24,17 @@ type IFieldScheduleController interface {
 	GetAllWithPagination(*gin.Context)
 	GetAllFieldIdAndDate(*gin.Context)
 	GetByUUID(*gin.Context)
+	GetAvailability(*gin.Context)
+	Stream(*gin.Context)
 	GenerateScheduleForOneMonth(*gin.Context)
+	Generate(*gin.Context)
 	Create(*gin.Context)
 	Update(*gin.Context)
 	UpdateStatus(*gin.Context)
+	Reschedule(*gin.Context)
+	Release(*gin.Context)
+	Announce(*gin.Context)
+	GetByUUIDs(*gin.Context)
 	Delete(*gin.Context)
 }
 
@@ -118,6 +130,70 @@ func (f *FieldScheduleController) GetAllFieldIdAndDate(c *gin.Context) {
 	})
 }
 
+func (f *FieldScheduleController) GetAvailability(c *gin.Context) {
+	var params dto.FieldAvailabilityRequestParam
+	err := c.ShouldBindQuery(&params)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(params)
+	if err != nil {
+		errMsg := http.StatusText(http.StatusUnprocessableEntity)
+		errorResp := errValidation.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:    http.StatusBadRequest,
+			Err:     err,
+			Message: &errMsg,
+			Data:    errorResp,
+			Gin:     c,
+		})
+		return
+	}
+
+	result, err := f.service.GetFieldSchedule().GetAvailability(c, &params)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	// The calendar is public and the same for every caller, so a CDN may
+	// keep it briefly; the ETag lets clients revalidate without the body.
+	body, err := json.Marshal(result)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusInternalServerError,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	etag := fmt.Sprintf(`"%s"`, util.GenerateSHA256(string(body)))
+	c.Header("Cache-Control", availabilityCacheControl)
+	c.Header("ETag", etag)
+	if c.GetHeader("If-None-Match") == etag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
 func (f *FieldScheduleController) GetByUUID(c *gin.Context) {
 	uuid := c.Param("uuid")
 
@@ -165,7 +241,51 @@ func (f *FieldScheduleController) GenerateScheduleForOneMonth(c *gin.Context) {
 		return
 	}
 
-	err = f.service.GetFieldSchedule().GenerateScheduleForOneMonth(c, &params)
+	result, err := f.service.GetFieldSchedule().GenerateScheduleForOneMonth(c, &params)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusCreated,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (f *FieldScheduleController) Generate(c *gin.Context) {
+	var params dto.GenerateFieldScheduleRequest
+	err := c.ShouldBindJSON(&params)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(params)
+	if err != nil {
+		errMsg := http.StatusText(http.StatusUnprocessableEntity)
+		errorResp := errValidation.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:    http.StatusBadRequest,
+			Err:     err,
+			Message: &errMsg,
+			Data:    errorResp,
+			Gin:     c,
+		})
+		return
+	}
+
+	result, err := f.service.GetFieldSchedule().Generate(c, &params)
 	if err != nil {
 		response.HttpResponse(response.ParamHTTPResp{
 			Code: http.StatusBadRequest,
@@ -177,6 +297,7 @@ func (f *FieldScheduleController) GenerateScheduleForOneMonth(c *gin.Context) {
 
 	response.HttpResponse(response.ParamHTTPResp{
 		Code: http.StatusCreated,
+		Data: result,
 		Gin:  c,
 	})
 }
@@ -311,6 +432,179 @@ func (f *FieldScheduleController) UpdateStatus(c *gin.Context) {
 	})
 }
 
+func (f *FieldScheduleController) Reschedule(c *gin.Context) {
+	var req dto.RescheduleFieldScheduleRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(req)
+	if err != nil {
+		errMsg := http.StatusText(http.StatusUnprocessableEntity)
+		errorResp := errValidation.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:    http.StatusBadRequest,
+			Err:     err,
+			Message: &errMsg,
+			Data:    errorResp,
+			Gin:     c,
+		})
+		return
+	}
+
+	err = f.service.GetFieldSchedule().Reschedule(c, &req)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Gin:  c,
+	})
+}
+
+func (f *FieldScheduleController) Release(c *gin.Context) {
+	var req dto.ReleaseFieldScheduleRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(req)
+	if err != nil {
+		errMsg := http.StatusText(http.StatusUnprocessableEntity)
+		errorResp := errValidation.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:    http.StatusBadRequest,
+			Err:     err,
+			Message: &errMsg,
+			Data:    errorResp,
+			Gin:     c,
+		})
+		return
+	}
+
+	err = f.service.GetFieldSchedule().Release(c, &req)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Gin:  c,
+	})
+}
+
+func (f *FieldScheduleController) Announce(c *gin.Context) {
+	var req dto.AnnounceFieldScheduleRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(req)
+	if err != nil {
+		errMsg := http.StatusText(http.StatusUnprocessableEntity)
+		errorResp := errValidation.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:    http.StatusBadRequest,
+			Err:     err,
+			Message: &errMsg,
+			Data:    errorResp,
+			Gin:     c,
+		})
+		return
+	}
+
+	err = f.service.GetFieldSchedule().Announce(c, &req)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Gin:  c,
+	})
+}
+
+func (f *FieldScheduleController) GetByUUIDs(c *gin.Context) {
+	var req dto.FieldScheduleLookupRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(req)
+	if err != nil {
+		errMsg := http.StatusText(http.StatusUnprocessableEntity)
+		errorResp := errValidation.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:    http.StatusBadRequest,
+			Err:     err,
+			Message: &errMsg,
+			Data:    errorResp,
+			Gin:     c,
+		})
+		return
+	}
+
+	result, err := f.service.GetFieldSchedule().GetByUUIDs(c, &req)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
 func (f *FieldScheduleController) Delete(c *gin.Context) {
 
 	err := f.service.GetFieldSchedule().Delete(c, c.Param("uuid"))
@@ -1,9 +1,15 @@
 package controllers
 
 import (
+	controllersAO "field-service/controllers/addon"
+	controllersA "field-service/controllers/amenity"
 	controllersF "field-service/controllers/field"
+	controllersFB "field-service/controllers/fieldblackout"
+	controllersFR "field-service/controllers/fieldreview"
 	controllersFS "field-service/controllers/fieldschedule"
+	controllersST "field-service/controllers/scheduletemplate"
 	controllersT "field-service/controllers/time"
+	controllersV "field-service/controllers/venue"
 	"field-service/services"
 )
 
@@ -15,6 +21,12 @@ type IControllerRegistry interface {
 	GetField() controllersF.IFieldController
 	GetFieldSchedule() controllersFS.IFieldScheduleController
 	GetTime() controllersT.ITimeController
+	GetScheduleTemplate() controllersST.IScheduleTemplateController
+	GetFieldBlackout() controllersFB.IFieldBlackoutController
+	GetVenue() controllersV.IVenueController
+	GetAmenity() controllersA.IAmenityController
+	GetFieldReview() controllersFR.IFieldReviewController
+	GetAddOn() controllersAO.IAddOnController
 }
 
 func NewControllerRegistry(service services.IServiceRegistry) IControllerRegistry {
@@ -32,3 +44,27 @@ func (r *Registry) GetFieldSchedule() controllersFS.IFieldScheduleController {
 func (r *Registry) GetTime() controllersT.ITimeController {
 	return controllersT.NewTimeController(r.service)
 }
+
+func (r *Registry) GetScheduleTemplate() controllersST.IScheduleTemplateController {
+	return controllersST.NewScheduleTemplateController(r.service)
+}
+
+func (r *Registry) GetFieldBlackout() controllersFB.IFieldBlackoutController {
+	return controllersFB.NewFieldBlackoutController(r.service)
+}
+
+func (r *Registry) GetVenue() controllersV.IVenueController {
+	return controllersV.NewVenueController(r.service)
+}
+
+func (r *Registry) GetAmenity() controllersA.IAmenityController {
+	return controllersA.NewAmenityController(r.service)
+}
+
+func (r *Registry) GetFieldReview() controllersFR.IFieldReviewController {
+	return controllersFR.NewFieldReviewController(r.service)
+}
+
+func (r *Registry) GetAddOn() controllersAO.IAddOnController {
+	return controllersAO.NewAddOnController(r.service)
+}
@@ -19,6 +19,8 @@ type ITimeController interface {
 	GetAll(*gin.Context)
 	GetByUUID(*gin.Context)
 	Create(*gin.Context)
+	Update(*gin.Context)
+	Delete(*gin.Context)
 }
 
 func NewTimeController(service services.IServiceRegistry) ITimeController {
@@ -26,7 +28,18 @@ func NewTimeController(service services.IServiceRegistry) ITimeController {
 }
 
 func (f *TimeController) GetAll(c *gin.Context) {
-	result, err := f.service.GetTime().GetAll(c)
+	var params dto.TimeRequestParam
+	err := c.ShouldBindQuery(&params)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	result, err := f.service.GetTime().GetAll(c, &params)
 	if err != nil {
 		response.HttpResponse(response.ParamHTTPResp{
 			Code: http.StatusBadRequest,
@@ -104,3 +117,64 @@ func (f *TimeController) Create(c *gin.Context) {
 		Gin:  c,
 	})
 }
+
+func (f *TimeController) Update(c *gin.Context) {
+	var req dto.UpdateTimeRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(req)
+	if err != nil {
+		errMsg := http.StatusText(http.StatusUnprocessableEntity)
+		errorResp := errValidation.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:    http.StatusBadRequest,
+			Err:     err,
+			Message: &errMsg,
+			Data:    errorResp,
+			Gin:     c,
+		})
+		return
+	}
+
+	result, err := f.service.GetTime().Update(c, c.Param("uuid"), &req)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  c,
+	})
+}
+
+func (f *TimeController) Delete(c *gin.Context) {
+	err := f.service.GetTime().Delete(c, c.Param("uuid"))
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  c,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Gin:  c,
+	})
+}
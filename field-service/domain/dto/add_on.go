@@ -0,0 +1,62 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AddOnRequest struct {
+	VenueID  string `json:"venueID" validate:"required"`
+	Code     string `json:"code" validate:"required,max=50"`
+	Name     string `json:"name" validate:"required,max=100"`
+	Category string `json:"category" validate:"required,oneof=equipment drink"`
+	Price    int    `json:"price" validate:"required,min=1"`
+	Stock    int    `json:"stock" validate:"min=0"`
+	IsActive *bool  `json:"isActive"`
+}
+
+type UpdateAddOnRequest struct {
+	Code     string `json:"code" validate:"required,max=50"`
+	Name     string `json:"name" validate:"required,max=100"`
+	Category string `json:"category" validate:"required,oneof=equipment drink"`
+	Price    int    `json:"price" validate:"required,min=1"`
+	Stock    int    `json:"stock" validate:"min=0"`
+	IsActive *bool  `json:"isActive"`
+}
+
+type AddOnRequestParam struct {
+	VenueID string `form:"venueID" validate:"required"`
+}
+
+type AddOnResponse struct {
+	UUID      uuid.UUID  `json:"uuid"`
+	VenueID   uuid.UUID  `json:"venueID"`
+	VenueName string     `json:"venueName"`
+	Code      string     `json:"code"`
+	Name      string     `json:"name"`
+	Category  string     `json:"category"`
+	Price     int        `json:"price"`
+	Stock     int        `json:"stock"`
+	IsActive  bool       `json:"isActive"`
+	CreatedAt *time.Time `json:"createAt,omitempty"`
+	UpdateAt  *time.Time `json:"updateAt,omitempty"`
+}
+
+type AddOnLookupRequest struct {
+	AddOnIDs []string `json:"addOnIDs" validate:"required"`
+}
+
+type AddOnStockItem struct {
+	AddOnID  string `json:"addOnID" validate:"required"`
+	Quantity int    `json:"quantity" validate:"required,min=1"`
+}
+
+type TakeAddOnStockRequest struct {
+	OrderID string           `json:"orderID" validate:"required,uuid"`
+	Items   []AddOnStockItem `json:"items" validate:"required,dive"`
+}
+
+type ReturnAddOnStockRequest struct {
+	OrderID string `json:"orderID" validate:"required,uuid"`
+}
@@ -0,0 +1,20 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AmenityRequest struct {
+	Code string `json:"code" validate:"required,max=50"`
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+type AmenityResponse struct {
+	UUID      uuid.UUID  `json:"uuid"`
+	Code      string     `json:"code"`
+	Name      string     `json:"name"`
+	CreatedAt *time.Time `json:"createAt,omitempty"`
+	UpdateAt  *time.Time `json:"updateAt,omitempty"`
+}
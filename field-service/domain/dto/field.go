@@ -8,27 +8,52 @@ import (
 )
 
 type FieldRequest struct {
+	VenueID      string                 `form:"venueID"`
+	ParentID     string                 `form:"parentID"`
 	Code         string                 `form:"code" validate:"required"`
 	Name         string                 `form:"name" validate:"required"`
 	PricePerHour int                    `form:"pricePerHour" validate:"required"`
 	Images       []multipart.FileHeader `form:"images" validate:"required"`
+	Surface      string                 `form:"surface" validate:"omitempty,oneof=vinyl synthetic_grass natural_grass"`
+	Size         string                 `form:"size" validate:"omitempty,oneof=5v5 7v7 11v11"`
+	IsIndoor     bool                   `form:"isIndoor"`
+	HasLighting  bool                   `form:"hasLighting"`
+	AmenityIDs   []string               `form:"amenityIDs"`
 }
 
 type UpdateFieldRequest struct {
+	VenueID      string                 `form:"venueID"`
+	ParentID     string                 `form:"parentID"`
 	Code         string                 `form:"code" validate:"required"`
 	Name         string                 `form:"name" validate:"required"`
 	PricePerHour int                    `form:"pricePerHour" validate:"required"`
 	Images       []multipart.FileHeader `form:"images"`
+	Surface      string                 `form:"surface" validate:"omitempty,oneof=vinyl synthetic_grass natural_grass"`
+	Size         string                 `form:"size" validate:"omitempty,oneof=5v5 7v7 11v11"`
+	IsIndoor     bool                   `form:"isIndoor"`
+	HasLighting  bool                   `form:"hasLighting"`
+	AmenityIDs   []string               `form:"amenityIDs"`
 }
 
 type FieldResponse struct {
-	UUID         uuid.UUID  `json:"uuid"`
-	Code         string     `json:"code"`
-	Name         string     `json:"name"`
-	PricePerHour int        `json:"pricePerHour"`
-	Images       []string   `json:"images"`
-	CreatedAt    *time.Time `json:"createAt"`
-	UpdateAt     *time.Time `json:"updateAt"`
+	UUID         uuid.UUID         `json:"uuid"`
+	VenueID      *uuid.UUID        `json:"venueID"`
+	VenueName    string            `json:"venueName"`
+	ParentID     *uuid.UUID        `json:"parentID"`
+	Code         string            `json:"code"`
+	Name         string            `json:"name"`
+	PricePerHour int               `json:"pricePerHour"`
+	Images       []string          `json:"images"`
+	Thumbnails   []string          `json:"thumbnails"`
+	Surface      string            `json:"surface"`
+	Size         string            `json:"size"`
+	IsIndoor     bool              `json:"isIndoor"`
+	HasLighting  bool              `json:"hasLighting"`
+	Amenities    []AmenityResponse `json:"amenities"`
+	Rating       float64           `json:"rating"`
+	RatingCount  int               `json:"ratingCount"`
+	CreatedAt    *time.Time        `json:"createAt"`
+	UpdateAt     *time.Time        `json:"updateAt"`
 }
 
 type FieldDetailResponse struct {
@@ -43,6 +68,44 @@ type FieldDetailResponse struct {
 type FieldRequestParam struct {
 	Page       int     `form:"page" validate:"required"`
 	Limit      int     `form:"limit" validate:"required"`
-	SortColumn *string `form:"sortColumn"`
-	SortOrder  *string `form:"sortOrder"`
+	SortColumn *string `form:"sortColumn" validate:"omitempty,oneof=code name price_per_hour created_at"`
+	SortOrder  *string `form:"sortOrder" validate:"omitempty,oneof=asc desc"`
+}
+
+type FieldSearchRequestParam struct {
+	Page        int      `form:"page" validate:"required,min=1"`
+	Limit       int      `form:"limit" validate:"required,min=1,max=100"`
+	Query       string   `form:"q"`
+	Surfaces    []string `form:"surface" validate:"dive,oneof=vinyl synthetic_grass natural_grass"`
+	Sizes       []string `form:"size" validate:"dive,oneof=5v5 7v7 11v11"`
+	IsIndoor    *bool    `form:"isIndoor"`
+	HasLighting *bool    `form:"hasLighting"`
+	Amenities   []string `form:"amenity"`
+	MinPrice    *int     `form:"minPrice" validate:"omitempty,min=0"`
+	MaxPrice    *int     `form:"maxPrice" validate:"omitempty,min=0"`
+}
+
+type FacetCount struct {
+	Value string `json:"value"`
+	Label string `json:"label,omitempty"`
+	Count int64  `json:"count"`
+}
+
+type PriceRange struct {
+	Min int `json:"min"`
+	Max int `json:"max"`
+}
+
+type FieldFacets struct {
+	Surface   []FacetCount `json:"surface"`
+	Size      []FacetCount `json:"size"`
+	Indoor    []FacetCount `json:"indoor"`
+	Lighting  []FacetCount `json:"lighting"`
+	Amenities []FacetCount `json:"amenities"`
+	Price     PriceRange   `json:"price"`
+}
+
+type FieldSearchResponse struct {
+	Fields any         `json:"fields"`
+	Facets FieldFacets `json:"facets"`
 }
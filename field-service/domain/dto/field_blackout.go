@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type FieldBlackoutRequest struct {
+	FieldID string `json:"fieldID" validate:"required"`
+	StartAt string `json:"startAt" validate:"required,datetime=2006-01-02 15:04"`
+	EndAt   string `json:"endAt" validate:"required,datetime=2006-01-02 15:04"`
+	Reason  string `json:"reason" validate:"required,max=255"`
+}
+
+type UpdateFieldBlackoutRequest struct {
+	StartAt string `json:"startAt" validate:"required,datetime=2006-01-02 15:04"`
+	EndAt   string `json:"endAt" validate:"required,datetime=2006-01-02 15:04"`
+	Reason  string `json:"reason" validate:"required,max=255"`
+}
+
+type FieldBlackoutRequestParam struct {
+	FieldID string `form:"fieldID" validate:"required"`
+}
+
+type FieldBlackoutResponse struct {
+	UUID              uuid.UUID  `json:"uuid"`
+	FieldID           uuid.UUID  `json:"fieldID"`
+	FieldName         string     `json:"fieldName"`
+	StartAt           string     `json:"startAt"`
+	EndAt             string     `json:"endAt"`
+	Reason            string     `json:"reason"`
+	ClosedCount       int        `json:"closedCount"`
+	CancelledBookings int        `json:"cancelledBookings"`
+	CreatedAt         *time.Time `json:"createdAt"`
+	UpdatedAt         *time.Time `json:"updatedAt"`
+}
@@ -0,0 +1,43 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type FieldReviewRequest struct {
+	FieldScheduleID string `json:"fieldScheduleID" validate:"required"`
+	Rating          int    `json:"rating" validate:"required,min=1,max=5"`
+	Comment         string `json:"comment" validate:"max=1000"`
+}
+
+type ModerateFieldReviewRequest struct {
+	Hidden *bool  `json:"hidden" validate:"required"`
+	Reason string `json:"reason" validate:"max=255"`
+}
+
+type FieldReviewRequestParam struct {
+	Page  int `form:"page" validate:"required,min=1"`
+	Limit int `form:"limit" validate:"required,min=1,max=100"`
+}
+
+type FieldReviewAdminRequestParam struct {
+	Page    int    `form:"page" validate:"required,min=1"`
+	Limit   int    `form:"limit" validate:"required,min=1,max=100"`
+	FieldID string `form:"fieldID"`
+	Status  string `form:"status" validate:"omitempty,oneof=visible hidden"`
+}
+
+type FieldReviewResponse struct {
+	UUID         uuid.UUID  `json:"uuid"`
+	FieldID      uuid.UUID  `json:"fieldID"`
+	FieldName    string     `json:"fieldName"`
+	UserName     string     `json:"userName"`
+	Rating       int        `json:"rating"`
+	Comment      string     `json:"comment"`
+	Hidden       bool       `json:"hidden,omitempty"`
+	HiddenReason string     `json:"hiddenReason,omitempty"`
+	CreatedAt    *time.Time `json:"createdAt"`
+	UpdatedAt    *time.Time `json:"updatedAt"`
+}
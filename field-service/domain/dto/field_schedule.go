@@ -24,22 +24,79 @@ type UpdateFieldScheduleRequest struct {
 
 type UpdatStatuseFieldScheduleRequest struct {
 	FiledSchedulesIDs []string `json:"fieldScheduleIDs" validate:"required"`
+	OrderCode         string   `json:"orderCode"`
+	UserID            string   `json:"userID"`
+}
+
+// RescheduleFieldScheduleRequest moves the bookings of an order from the
+// From schedules to the To schedules. Without From schedules the To
+// schedules are only taken, which holds them while a reschedule is paid.
+type RescheduleFieldScheduleRequest struct {
+	FromFieldScheduleIDs []string `json:"fromFieldScheduleIDs"`
+	ToFieldScheduleIDs   []string `json:"toFieldScheduleIDs" validate:"required,min=1"`
+	OrderCode            string   `json:"orderCode" validate:"required"`
+	UserID               string   `json:"userID"`
+}
+
+// ReleaseFieldScheduleRequest frees the schedules of a cancelled order.
+type ReleaseFieldScheduleRequest struct {
+	FieldScheduleIDs []string `json:"fieldScheduleIDs" validate:"required,min=1"`
+	OrderCode        string   `json:"orderCode" validate:"required"`
+}
+
+// AnnounceFieldScheduleRequest announces an event on the stream of each
+// schedule without changing its status.
+type AnnounceFieldScheduleRequest struct {
+	FieldScheduleIDs []string `json:"fieldScheduleIDs" validate:"required,min=1"`
+	Event            string   `json:"event" validate:"required,oneof=reserved released"`
+}
+
+type FieldScheduleLookupRequest struct {
+	FieldScheduleIDs []string `json:"fieldScheduleIDs" validate:"required"`
+}
+
+// FieldScheduleDetailResponse describes a schedule for other services, with
+// the exact start and end so they need not know the venue time zone.
+type FieldScheduleDetailResponse struct {
+	UUID      uuid.UUID                         `json:"uuid"`
+	FieldUUID uuid.UUID                         `json:"fieldUUID"`
+	FieldName string                            `json:"fieldName"`
+	Location  string                            `json:"location"`
+	Date      string                            `json:"date"`
+	StartAt   time.Time                         `json:"startAt"`
+	EndAt     time.Time                         `json:"endAt"`
+	Status    constants.FieldScheduleStatusName `json:"status"`
+}
+
+type FieldScheduleStreamRequestParam struct {
+	Date        string `form:"date" validate:"required"`
+	LastEventID string `form:"lastEventId"`
+}
+
+type FieldScheduleStatusEvent struct {
+	UUID   uuid.UUID                         `json:"uuid"`
+	Date   string                            `json:"date"`
+	Status constants.FieldScheduleStatusName `json:"status"`
 }
 
 type FieldScheduleResponse struct {
-	UUID         uuid.UUID                         `json:"uuid"`
-	FieldName    string                            `json:"fieldName"`
-	PricePerHour int                               `json:"pricePerHour"`
-	Date         string                            `json:"date"`
-	Status       constants.FieldScheduleStatusName `json:"status"`
-	Time         string                            `json:"time"`
-	CreatedAt    *time.Time                        `json:"createAt"`
-	UpdateAt     *time.Time                        `json:"updateAt"`
+	UUID              uuid.UUID                         `json:"uuid"`
+	FieldName         string                            `json:"fieldName"`
+	VenueID           *uuid.UUID                        `json:"venueID,omitempty"`
+	DepositPercentage int                               `json:"depositPercentage"`
+	PricePerHour      int                               `json:"pricePerHour"`
+	Price             int                               `json:"price"`
+	Date              string                            `json:"date"`
+	Status            constants.FieldScheduleStatusName `json:"status"`
+	Time              string                            `json:"time"`
+	CreatedAt         *time.Time                        `json:"createAt"`
+	UpdateAt          *time.Time                        `json:"updateAt"`
 }
 
 type FieldScheduleForBookResponse struct {
 	UUID         uuid.UUID                         `json:"uuid"`
 	PricePerHour string                            `json:"pricePerHour"`
+	Price        string                            `json:"price"`
 	Date         string                            `json:"date"`
 	Status       constants.FieldScheduleStatusName `json:"status"`
 	Time         string                            `json:"time"`
@@ -52,6 +109,72 @@ type FieldScheduleByFieldIDAndDateRequestParam struct {
 type FieldScheduleRequestParam struct {
 	Page       int     `form:"page" validate:"required"`
 	Limit      int     `form:"limit" validate:"required"`
-	SortColumn *string `form:"sortColumn"`
-	SortOrder  int     `form:"sortOrder"`
+	SortColumn *string `form:"sortColumn" validate:"omitempty,oneof=date status created_at"`
+	SortOrder  *string `form:"sortOrder" validate:"omitempty,oneof=asc desc"`
+}
+
+type GenerateFieldScheduleRequest struct {
+	FieldID   string `json:"fieldID" validate:"required"`
+	StartDate string `json:"startDate" validate:"required,datetime=2006-01-02"`
+	EndDate   string `json:"endDate" validate:"required,datetime=2006-01-02"`
+}
+
+type GeneratedFieldScheduleSlot struct {
+	Date string `json:"date"`
+	Time string `json:"time"`
+}
+
+type GenerateFieldScheduleResponse struct {
+	FieldID      uuid.UUID                    `json:"fieldID"`
+	StartDate    string                       `json:"startDate"`
+	EndDate      string                       `json:"endDate"`
+	CreatedCount int                          `json:"createdCount"`
+	SkippedCount int                          `json:"skippedCount"`
+	Created      []GeneratedFieldScheduleSlot `json:"created"`
+	Skipped      []GeneratedFieldScheduleSlot `json:"skipped"`
+}
+
+type FieldAvailabilityRequestParam struct {
+	FieldID   string `form:"fieldID" validate:"omitempty,uuid"`
+	StartDate string `form:"startDate" validate:"required,datetime=2006-01-02"`
+	EndDate   string `form:"endDate" validate:"required,datetime=2006-01-02"`
+}
+
+// FieldAvailabilityRow is one field and day of the aggregated availability
+// query, with the slots of the day as a JSON array.
+type FieldAvailabilityRow struct {
+	FieldUUID uuid.UUID
+	FieldName string
+	Date      time.Time
+	Available int
+	Booked    int
+	Blocked   int
+	Slots     string
+}
+
+type SlotAvailability struct {
+	UUID      uuid.UUID                         `json:"uuid"`
+	StartTime string                            `json:"startTime"`
+	EndTime   string                            `json:"endTime"`
+	Status    constants.FieldScheduleStatusName `json:"status"`
+}
+
+type DayAvailability struct {
+	Date      string             `json:"date"`
+	Available int                `json:"available"`
+	Booked    int                `json:"booked"`
+	Blocked   int                `json:"blocked"`
+	Slots     []SlotAvailability `json:"slots"`
+}
+
+type FieldAvailability struct {
+	FieldID   uuid.UUID         `json:"fieldID"`
+	FieldName string            `json:"fieldName"`
+	Days      []DayAvailability `json:"days"`
+}
+
+type FieldAvailabilityResponse struct {
+	StartDate string              `json:"startDate"`
+	EndDate   string              `json:"endDate"`
+	Fields    []FieldAvailability `json:"fields"`
 }
@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type KafkaEvent struct {
+	Name string `json:"name"`
+}
+
+type KafkaMetaData struct {
+	Sender    string `json:"sender"`
+	SendingAt string `json:"sendingAt"`
+}
+
+type KafkaData struct {
+	BlackoutID       uuid.UUID   `json:"blackoutID"`
+	FieldID          uuid.UUID   `json:"fieldID"`
+	Reason           string      `json:"reason"`
+	StartAt          time.Time   `json:"startAt"`
+	EndAt            time.Time   `json:"endAt"`
+	FieldScheduleIDs []uuid.UUID `json:"fieldScheduleIDs"`
+}
+
+type KafkaBody struct {
+	Type string     `json:"type"`
+	Data *KafkaData `json:"data"`
+}
+
+type KafkaMessage struct {
+	Event    KafkaEvent    `json:"event"`
+	Metadata KafkaMetaData `json:"metadata"`
+	Body     KafkaBody     `json:"body"`
+}
@@ -0,0 +1,27 @@
+package dto
+
+import "github.com/google/uuid"
+
+type ScheduleTemplateDayRequest struct {
+	Weekday int      `json:"weekday" validate:"min=0,max=6"`
+	TimeIDs []string `json:"timeIDs" validate:"required"`
+}
+
+// ScheduleTemplateRequest puts the template in force unless Enabled is
+// false, which removes it and opens every slot of the field again.
+type ScheduleTemplateRequest struct {
+	Enabled *bool                        `json:"enabled"`
+	Days    []ScheduleTemplateDayRequest `json:"days" validate:"dive"`
+}
+
+type ScheduleTemplateDayResponse struct {
+	Weekday     int            `json:"weekday"`
+	WeekdayName string         `json:"weekdayName"`
+	Times       []TimeResponse `json:"times"`
+}
+
+type ScheduleTemplateResponse struct {
+	FieldID uuid.UUID                     `json:"fieldID"`
+	Enabled bool                          `json:"enabled"`
+	Days    []ScheduleTemplateDayResponse `json:"days"`
+}
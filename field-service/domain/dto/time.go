@@ -7,14 +7,26 @@ import (
 )
 
 type TimeRequest struct {
+	FieldID   *string `json:"fieldID"`
+	StartTime string  `json:"startTime" validate:"required"`
+	EndTime   string  `json:"endTime" validate:"required"`
+}
+
+type UpdateTimeRequest struct {
 	StartTime string `json:"startTime" validate:"required"`
 	EndTime   string `json:"endTime" validate:"required"`
 }
 
+type TimeRequestParam struct {
+	FieldID *string `form:"fieldID"`
+}
+
 type TimeResponse struct {
-	UUID      uuid.UUID  `json:"uuid"`
-	StartTime string     `json:"startTime"`
-	EndTime   string     `json:"endTime"`
-	CreatedAt *time.Time `json:"createAt"`
-	UpdateAt  *time.Time `json:"updateAt"`
+	UUID           uuid.UUID  `json:"uuid"`
+	FieldID        *uuid.UUID `json:"fieldID"`
+	StartTime      string     `json:"startTime"`
+	EndTime        string     `json:"endTime"`
+	DurationMinute int        `json:"durationMinute"`
+	CreatedAt      *time.Time `json:"createAt"`
+	UpdateAt       *time.Time `json:"updateAt"`
 }
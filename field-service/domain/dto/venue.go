@@ -0,0 +1,59 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type VenueRequest struct {
+	Name              string   `json:"name" validate:"required,max=255"`
+	Address           string   `json:"address" validate:"required"`
+	City              string   `json:"city" validate:"required,max=100"`
+	Latitude          *float64 `json:"latitude" validate:"required,latitude"`
+	Longitude         *float64 `json:"longitude" validate:"required,longitude"`
+	Timezone          string   `json:"timezone" validate:"required"`
+	OpenTime          string   `json:"openTime" validate:"required"`
+	CloseTime         string   `json:"closeTime" validate:"required"`
+	Phone             string   `json:"phone" validate:"omitempty,max=20"`
+	Email             string   `json:"email" validate:"omitempty,email,max=100"`
+	DepositPercentage int      `json:"depositPercentage" validate:"min=0,max=99"`
+}
+
+type VenueNearbyRequestParam struct {
+	Latitude  *float64 `form:"lat" validate:"required,latitude"`
+	Longitude *float64 `form:"lng" validate:"required,longitude"`
+	RadiusKm  float64  `form:"radius" validate:"omitempty,gt=0,lte=100"`
+	Limit     int      `form:"limit" validate:"omitempty,min=1,max=100"`
+	Date      string   `form:"date" validate:"required_with=Time,omitempty,datetime=2006-01-02"`
+	Time      string   `form:"time" validate:"omitempty,datetime=15:04"`
+}
+
+type VenueFieldResponse struct {
+	UUID         uuid.UUID `json:"uuid"`
+	Code         string    `json:"code"`
+	Name         string    `json:"name"`
+	PricePerHour int       `json:"pricePerHour"`
+	Images       []string  `json:"images"`
+	Rating       float64   `json:"rating"`
+	RatingCount  int       `json:"ratingCount"`
+}
+
+type VenueResponse struct {
+	UUID              uuid.UUID            `json:"uuid"`
+	Name              string               `json:"name"`
+	Address           string               `json:"address"`
+	City              string               `json:"city"`
+	Latitude          float64              `json:"latitude"`
+	Longitude         float64              `json:"longitude"`
+	Timezone          string               `json:"timezone"`
+	OpenTime          string               `json:"openTime"`
+	CloseTime         string               `json:"closeTime"`
+	Phone             string               `json:"phone"`
+	Email             string               `json:"email"`
+	DepositPercentage int                  `json:"depositPercentage"`
+	DistanceKm        *float64             `json:"distanceKm,omitempty"`
+	Fields            []VenueFieldResponse `json:"fields"`
+	CreatedAt         *time.Time           `json:"createAt"`
+	UpdateAt          *time.Time           `json:"updateAt"`
+}
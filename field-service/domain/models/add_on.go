@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AddOn is an item a venue rents or sells next to its fields, such as balls,
+// bibs, shoes or drinks.
+type AddOn struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement"`
+	UUID      uuid.UUID `gorm:"type:uuid;not null"`
+	VenueID   uint      `gorm:"type:uint;not null;uniqueIndex:idx_add_ons_venue_code"`
+	Code      string    `gorm:"type:varchar(50);not null;uniqueIndex:idx_add_ons_venue_code"`
+	Name      string    `gorm:"type:varchar(100);not null"`
+	Category  string    `gorm:"type:varchar(20);not null;index"`
+	Price     int       `gorm:"type:int;not null"`
+	Stock     int       `gorm:"type:int;not null;default:0"`
+	IsActive  bool      `gorm:"not null;default:true"`
+	CreatedAt *time.Time
+	UpdatedAt *time.Time
+	Venue     Venue `gorm:"foreignKey:venue_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+// AddOnStock records the add-ons an order took out of stock, so a repeated
+// payment event does not take them twice and only taken items are returned.
+type AddOnStock struct {
+	ID         uint      `gorm:"primaryKey;autoIncrement"`
+	OrderID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_add_on_stocks_order_add_on"`
+	AddOnID    uint      `gorm:"type:uint;not null;uniqueIndex:idx_add_on_stocks_order_add_on"`
+	Quantity   int       `gorm:"type:int;not null"`
+	ReturnedAt *time.Time
+	CreatedAt  *time.Time
+	UpdatedAt  *time.Time
+	AddOn      AddOn `gorm:"foreignKey:add_on_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
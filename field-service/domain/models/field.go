@@ -1,6 +1,7 @@
 package models
 
 import (
+	"math"
 	"time"
 
 	"github.com/google/uuid"
@@ -8,14 +9,38 @@ import (
 )
 
 type Field struct {
-	ID            uint           `gorm:"primaryKey;autoIncrement"`
-	UUID          uuid.UUID      `gorm:"type:uuid;not null"`
-	Code          string         `gorm:"type:varchar(15);not null"`
-	Name          string         `gorm:"type:varchar(255);not null"`
-	PricePerHour  int            `gorm:"type:int;not null"`
-	Images        pq.StringArray `gorm:"type:text[];not null;default:'{}'"`
-	CreatedAt     *time.Time
-	UpdatedAt     *time.Time
-	DeletedAt     *time.Time
-	FieldSchedule []FieldSchedule `gorm:"foreignKey:field_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	ID           uint           `gorm:"primaryKey;autoIncrement"`
+	UUID         uuid.UUID      `gorm:"type:uuid;not null"`
+	VenueID      *uint          `gorm:"index"`
+	ParentID     *uint          `gorm:"index"`
+	Code         string         `gorm:"type:varchar(15);not null"`
+	Name         string         `gorm:"type:varchar(255);not null"`
+	PricePerHour int            `gorm:"type:int;not null;index"`
+	Surface      string         `gorm:"type:varchar(30);index"`
+	Size         string         `gorm:"type:varchar(10);index"`
+	IsIndoor     bool           `gorm:"not null;default:false;index"`
+	HasLighting  bool           `gorm:"not null;default:false;index"`
+	Images       pq.StringArray `gorm:"type:text[];not null;default:'{}'"`
+	Thumbnails   pq.StringArray `gorm:"type:text[];not null;default:'{}'"`
+	RatingCount  int            `gorm:"type:int;not null;default:0"`
+	RatingSum    int            `gorm:"type:int;not null;default:0"`
+	// HasScheduleTemplate is set once a weekly template is saved. From then on
+	// only the template opens slots, even when it has no rows left.
+	HasScheduleTemplate bool `gorm:"not null;default:false"`
+	CreatedAt           *time.Time
+	UpdatedAt           *time.Time
+	DeletedAt           *time.Time
+	Venue               *Venue          `gorm:"foreignKey:venue_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+	Parent              *Field          `gorm:"foreignKey:parent_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+	Amenities           []Amenity       `gorm:"many2many:field_amenities;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	FieldSchedule       []FieldSchedule `gorm:"foreignKey:field_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+// AverageRating is the mean of the visible reviews, rounded to one decimal.
+func (f *Field) AverageRating() float64 {
+	if f.RatingCount == 0 {
+		return 0
+	}
+
+	return math.Round(float64(f.RatingSum)/float64(f.RatingCount)*10) / 10
 }
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type FieldBlackout struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement"`
+	UUID      uuid.UUID `gorm:"type:uuid;not null"`
+	FieldID   uint      `gorm:"type:uint;not null;index"`
+	StartAt   time.Time `gorm:"type:timestamptz;not null"`
+	EndAt     time.Time `gorm:"type:timestamptz;not null"`
+	Reason    string    `gorm:"type:varchar(255);not null"`
+	CreatedAt *time.Time
+	UpdatedAt *time.Time
+	Field     Field `gorm:"foreignKey:field_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+// Overlaps reports whether the blackout shares any moment with [start, end).
+func (b *FieldBlackout) Overlaps(start, end time.Time) bool {
+	return start.Before(b.EndAt) && end.After(b.StartAt)
+}
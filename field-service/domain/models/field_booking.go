@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FieldBooking records the order holding a booked schedule. The row stays
+// after the booking is cancelled so calendar feeds can announce it.
+type FieldBooking struct {
+	ID              uint      `gorm:"primaryKey;autoIncrement"`
+	UUID            uuid.UUID `gorm:"type:uuid;not null"`
+	FieldID         uint      `gorm:"type:uint;not null;index"`
+	FieldScheduleID uint      `gorm:"type:uint;not null;index"`
+	OrderCode       string    `gorm:"type:varchar(30)"`
+	// UserID is the customer who paid for the booking. Bookings made before
+	// it was recorded have none and cannot be reviewed.
+	UserID        *uuid.UUID `gorm:"type:uuid;index"`
+	CancelledAt   *time.Time
+	CreatedAt     *time.Time
+	UpdatedAt     *time.Time
+	FieldSchedule FieldSchedule `gorm:"foreignKey:field_schedule_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FieldReview is a customer's rating of a booking they paid for. A hidden
+// review is left out of the listings and of the field's rating.
+type FieldReview struct {
+	ID             uint      `gorm:"primaryKey;autoIncrement"`
+	UUID           uuid.UUID `gorm:"type:uuid;not null"`
+	FieldID        uint      `gorm:"type:uint;not null;index"`
+	FieldBookingID uint      `gorm:"type:uint;not null;uniqueIndex"`
+	UserID         uuid.UUID `gorm:"type:uuid;not null;index"`
+	UserName       string    `gorm:"type:varchar(100);not null"`
+	Rating         int       `gorm:"type:int;not null"`
+	Comment        string    `gorm:"type:text"`
+	HiddenAt       *time.Time
+	HiddenReason   string `gorm:"type:varchar(255)"`
+	CreatedAt      *time.Time
+	UpdatedAt      *time.Time
+	Field          Field        `gorm:"foreignKey:field_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	FieldBooking   FieldBooking `gorm:"foreignKey:field_booking_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (r *FieldReview) IsHidden() bool {
+	return r.HiddenAt != nil
+}
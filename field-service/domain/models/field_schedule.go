@@ -2,6 +2,7 @@ package models
 
 import (
 	"field-service/constants"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -10,9 +11,9 @@ import (
 type FieldSchedule struct {
 	ID        uint                          `gorm:"primaryKey;autoIncrement"`
 	UUID      uuid.UUID                     `gorm:"type:uuid;not null"`
-	FieldID   uint                          `gorm:"type:uint;not null"`
-	TimeID    uint                          `gorm:"type:uint;not null"`
-	Date      time.Time                     `gorm:"type:date;not null"`
+	FieldID   uint                          `gorm:"type:uint;not null;index:idx_field_schedules_field_date,priority:1;uniqueIndex:idx_field_schedules_slot,priority:1"`
+	TimeID    uint                          `gorm:"type:uint;not null;uniqueIndex:idx_field_schedules_slot,priority:3"`
+	Date      time.Time                     `gorm:"type:date;not null;index;index:idx_field_schedules_field_date,priority:2;uniqueIndex:idx_field_schedules_slot,priority:2"`
 	Status    constants.FieldScheduleStatus `gorm:"type:int;not null"`
 	CreatedAt *time.Time
 	UpdatdeAt *time.Time
@@ -20,3 +21,13 @@ type FieldSchedule struct {
 	Field     Field `gorm:"foreignKey:field_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 	Time      Time  `gorm:"foreignKey:time_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 }
+
+// ScheduleTopic names the broker topic carrying the status changes of a
+// field on one day.
+func ScheduleTopic(fieldID uint, date string) string {
+	return fmt.Sprintf("field-schedule:%d:%s", fieldID, date)
+}
+
+func (f *FieldSchedule) Topic() string {
+	return ScheduleTopic(f.FieldID, f.Date.Format(time.DateOnly))
+}
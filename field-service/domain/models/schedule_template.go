@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ScheduleTemplate is one opening slot of a field's weekly template: the time
+// slot is offered on every date that falls on Weekday (0 is Sunday).
+type ScheduleTemplate struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement"`
+	UUID      uuid.UUID `gorm:"type:uuid;not null"`
+	FieldID   uint      `gorm:"type:uint;not null;uniqueIndex:idx_schedule_template_slot"`
+	Weekday   int       `gorm:"type:int;not null;uniqueIndex:idx_schedule_template_slot"`
+	TimeID    uint      `gorm:"type:uint;not null;uniqueIndex:idx_schedule_template_slot"`
+	CreatedAt *time.Time
+	UpdatedAt *time.Time
+	Field     Field `gorm:"foreignKey:field_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Time      Time  `gorm:"foreignKey:time_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
@@ -1,16 +1,48 @@
 package models
 
 import (
+	"field-service/common/timeofday"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type Time struct {
-	ID        uint      `gorm:"primaryKey;autoIncrement"`
-	UUID      uuid.UUID `gorm:"type:uuid;not null"`
-	StartTime string    `gorm:"type:time without time zone;not null"`
-	EndTime   string    `gorm:"type:time without time zone;not null"`
+	ID        uint                `gorm:"primaryKey;autoIncrement"`
+	UUID      uuid.UUID           `gorm:"type:uuid;not null"`
+	FieldID   *uint               `gorm:"type:uint;index"`
+	StartTime timeofday.TimeOfDay `gorm:"type:time without time zone;not null"`
+	EndTime   timeofday.TimeOfDay `gorm:"type:time without time zone;not null"`
 	CreatedAt *time.Time
 	UpdatedAt *time.Time
+	Field     *Field `gorm:"foreignKey:field_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+// RangeOn returns when this slot starts and ends on the given date. A slot
+// that ends at or before its start time runs past midnight.
+func (t *Time) RangeOn(date time.Time) (time.Time, time.Time) {
+	start := t.StartTime.On(date)
+	end := t.EndTime.On(date)
+	if !end.After(start) {
+		end = end.AddDate(0, 0, 1)
+	}
+	return start, end
+}
+
+func (t *Time) DurationMinute() int {
+	duration := t.EndTime.Minutes() - t.StartTime.Minutes()
+	if duration <= 0 {
+		duration += timeofday.EndOfDay.Minutes()
+	}
+	return duration
+}
+
+// Price prorates the hourly price of a field over the length of the slot.
+func (t *Time) Price(pricePerHour int) int {
+	return pricePerHour * t.DurationMinute() / 60
+}
+
+// Overlaps reports whether both slots share any minute of the day.
+func (t *Time) Overlaps(other *Time) bool {
+	return t.StartTime < other.EndTime && other.StartTime < t.EndTime
 }
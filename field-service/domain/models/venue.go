@@ -0,0 +1,43 @@
+package models
+
+import (
+	"field-service/common/timeofday"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Venue struct {
+	ID        uint                `gorm:"primaryKey;autoIncrement"`
+	UUID      uuid.UUID           `gorm:"type:uuid;not null"`
+	Name      string              `gorm:"type:varchar(255);not null"`
+	Address   string              `gorm:"type:text;not null"`
+	City      string              `gorm:"type:varchar(100);not null"`
+	Latitude  float64             `gorm:"type:double precision;not null"`
+	Longitude float64             `gorm:"type:double precision;not null"`
+	Timezone  string              `gorm:"type:varchar(64);not null"`
+	OpenTime  timeofday.TimeOfDay `gorm:"type:time without time zone;not null"`
+	CloseTime timeofday.TimeOfDay `gorm:"type:time without time zone;not null"`
+	Phone     string              `gorm:"type:varchar(20)"`
+	Email     string              `gorm:"type:varchar(100)"`
+	// DepositPercentage is the share of an order a customer may pay online
+	// as a deposit, the rest being paid at the venue. Zero means orders are
+	// paid in full.
+	DepositPercentage int `gorm:"type:int;not null;default:0"`
+	CreatedAt         *time.Time
+	UpdatedAt         *time.Time
+	Fields            []Field  `gorm:"foreignKey:venue_id;references:id"`
+	Distance          *float64 `gorm:"->;-:migration"`
+}
+
+// Location joins the name and address of the venue into one line.
+func (v *Venue) Location() string {
+	parts := make([]string, 0, 3)
+	for _, part := range []string{v.Name, v.Address, v.City} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,103 @@
+package jobs
+
+import (
+	"context"
+	"field-service/config"
+	"field-service/repositories"
+	"field-service/services"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// scheduleGeneratorLockKey identifies the advisory lock shared by every
+	// replica, so only one of them generates schedules at a time.
+	scheduleGeneratorLockKey int64 = 7001
+
+	defaultDaysAhead      = 30
+	defaultIntervalMinute = 60
+)
+
+type ScheduleGenerator struct {
+	repository repositories.IRepositoryRegistry
+	service    services.IServiceRegistry
+}
+
+type IScheduleGenerator interface {
+	Start(context.Context)
+	Run(context.Context, int) error
+}
+
+func NewScheduleGenerator(repository repositories.IRepositoryRegistry, service services.IServiceRegistry) IScheduleGenerator {
+	return &ScheduleGenerator{repository: repository, service: service}
+}
+
+// Start runs the generator right away and then on every interval until ctx is
+// cancelled.
+func (g *ScheduleGenerator) Start(ctx context.Context) {
+	daysAhead := config.Cfg.ScheduleGenerator.DaysAhead
+	interval := config.Cfg.ScheduleGenerator.IntervalMinute
+	if interval <= 0 {
+		interval = defaultIntervalMinute
+	}
+
+	ticker := time.NewTicker(time.Duration(interval) * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		err := g.Run(ctx, daysAhead)
+		if err != nil {
+			logrus.Errorf("schedule generator: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Run makes sure every active field has schedules from tomorrow up to daysAhead days
+// out. It returns without doing anything when another replica holds the lock.
+func (g *ScheduleGenerator) Run(ctx context.Context, daysAhead int) error {
+	if daysAhead <= 0 {
+		daysAhead = defaultDaysAhead
+	}
+
+	release, locked, err := g.repository.GetLock().TryLock(ctx, scheduleGeneratorLockKey)
+	if err != nil {
+		return err
+	}
+
+	if !locked {
+		logrus.Info("schedule generator: another instance is running, skipping")
+		return nil
+	}
+	defer release()
+
+	fields, err := g.repository.GetField().FindActive(ctx)
+	if err != nil {
+		return err
+	}
+
+	now, _ := time.Parse(time.DateOnly, time.Now().Format(time.DateOnly))
+	startDate := now.AddDate(0, 0, 1)
+	endDate := startDate.AddDate(0, 0, daysAhead-1)
+
+	for i := range fields {
+		result, err := g.service.GetFieldSchedule().GenerateForField(ctx, &fields[i], startDate, endDate)
+		if err != nil {
+			logrus.Errorf("schedule generator: field %s: %v", fields[i].UUID, err)
+			continue
+		}
+
+		if result.CreatedCount > 0 {
+			logrus.Infof("schedule generator: field %s %s..%s created %d, skipped %d",
+				fields[i].UUID, result.StartDate, result.EndDate, result.CreatedCount, result.SkippedCount)
+		}
+	}
+
+	return nil
+}
@@ -1,6 +1,8 @@
 package middlewares
 
 import (
+	"bytes"
+	"common/signature"
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
@@ -10,8 +12,11 @@ import (
 	"field-service/constants"
 	errConstant "field-service/constants/error"
 	"fmt"
+	"io"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/didip/tollbooth"
 	"github.com/didip/tollbooth/limiter"
@@ -114,6 +119,8 @@ func CheckRole(roles []string, client clients.IClientRegistry) gin.HandlerFunc {
 			responseUnauthorized(ctx, errConstant.ErrUnauthorized.Error())
 			return
 		}
+		userLogin := ctx.Request.WithContext(context.WithValue(ctx.Request.Context(), constants.User, user))
+		ctx.Request = userLogin
 		ctx.Next()
 	}
 }
@@ -152,3 +159,88 @@ func AuthenticateWithoutToken() gin.HandlerFunc {
 		ctx.Next()
 	}
 }
+
+// AuthenticateCalendarFeed checks the feed token that calendar apps send in
+// the query string, since they cannot send headers.
+func AuthenticateCalendarFeed(roles []string, client clients.IClientRegistry) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		token := ctx.Query("token")
+		if token == "" {
+			responseUnauthorized(ctx, errConstant.ErrUnauthorized.Error())
+			return
+		}
+
+		user, err := client.GetUser().GetUserByCalendarFeedToken(ctx.Request.Context(), token)
+		if err != nil || !contains(roles, user.Role) {
+			responseUnauthorized(ctx, errConstant.ErrUnauthorized.Error())
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+const defaultSignatureMaxSkew = 5 * time.Minute
+
+// validateSignature checks a request signed with signature.Sign by one of the
+// callers listed in internalCallers. The timestamp must be within the skew
+// window and the nonce may only be used once inside that window.
+func validateSignature(c *gin.Context, nonces signature.INonceStore) error {
+	serviceName := c.GetHeader(constants.XserviceName)
+	requestAt := c.GetHeader(constants.XrequestAt)
+	nonce := c.GetHeader(constants.XNonce)
+	sign := c.GetHeader(constants.XSignature)
+
+	key, ok := config.Cfg.InternalCallers[serviceName]
+	if !ok || key == "" || nonce == "" || sign == "" {
+		return errConstant.ErrUnauthorized
+	}
+
+	unixTime, err := strconv.ParseInt(requestAt, 10, 64)
+	if err != nil {
+		return errConstant.ErrInvalidSignature
+	}
+
+	maxSkew := defaultSignatureMaxSkew
+	if config.Cfg.SignatureMaxSkewSecond > 0 {
+		maxSkew = time.Duration(config.Cfg.SignatureMaxSkewSecond) * time.Second
+	}
+
+	skew := time.Since(time.Unix(unixTime, 0))
+	if skew > maxSkew || skew < -maxSkew {
+		return errConstant.ErrRequestExpired
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return errConstant.ErrInvalidSignature
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	if !signature.Verify(key, serviceName, c.Request.Method, c.Request.URL.RequestURI(), body, requestAt, nonce, sign) {
+		return errConstant.ErrInvalidSignature
+	}
+
+	fresh, err := nonces.Use(c, fmt.Sprintf("%s:%s", serviceName, nonce), 2*maxSkew)
+	if err != nil {
+		return errConstant.ErrSQLError
+	}
+
+	if !fresh {
+		return errConstant.ErrRequestReplayed
+	}
+
+	return nil
+}
+
+func AuthenticateInternal(nonces signature.INonceStore) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		err := validateSignature(ctx, nonces)
+		if err != nil {
+			responseUnauthorized(ctx, err.Error())
+			return
+		}
+
+		ctx.Next()
+	}
+}
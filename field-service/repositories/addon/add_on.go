@@ -0,0 +1,139 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	errWrap "field-service/common/error"
+	errConstant "field-service/constants/error"
+	errAddOn "field-service/constants/error/add_on"
+	"field-service/domain/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type AddOnRepository struct {
+	db *gorm.DB
+}
+
+type IAddOnRepository interface {
+	FindByVenueID(context.Context, uint, bool) ([]models.AddOn, error)
+	FindByUUID(context.Context, string) (*models.AddOn, error)
+	FindByUUIDs(context.Context, []string) ([]models.AddOn, error)
+	FindByVenueIDAndCode(context.Context, uint, string) (*models.AddOn, error)
+	Create(context.Context, *models.AddOn) (*models.AddOn, error)
+	Update(context.Context, string, *models.AddOn) (*models.AddOn, error)
+	Delete(context.Context, string) error
+	AdjustStock(context.Context, *gorm.DB, uint, int) error
+}
+
+func NewAddOnRepository(db *gorm.DB) IAddOnRepository {
+	return &AddOnRepository{db: db}
+}
+
+// FindByVenueID lists the add-ons of a venue, leaving out the inactive ones
+// when activeOnly is set.
+func (a *AddOnRepository) FindByVenueID(ctx context.Context, venueID uint, activeOnly bool) ([]models.AddOn, error) {
+	var addOns []models.AddOn
+	query := a.db.WithContext(ctx).Preload("Venue").Where("venue_id = ?", venueID)
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+
+	err := query.Order("category asc, name asc").Find(&addOns).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return addOns, nil
+}
+
+func (a *AddOnRepository) FindByUUID(ctx context.Context, uuid string) (*models.AddOn, error) {
+	var addOn models.AddOn
+	err := a.db.WithContext(ctx).Preload("Venue").Where("uuid = ?", uuid).First(&addOn).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapError(errAddOn.ErrAddOnNotFound)
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &addOn, nil
+}
+
+func (a *AddOnRepository) FindByUUIDs(ctx context.Context, uuids []string) ([]models.AddOn, error) {
+	var addOns []models.AddOn
+	err := a.db.WithContext(ctx).Preload("Venue").Where("uuid IN ?", uuids).Find(&addOns).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return addOns, nil
+}
+
+func (a *AddOnRepository) FindByVenueIDAndCode(ctx context.Context, venueID uint, code string) (*models.AddOn, error) {
+	var addOn models.AddOn
+	err := a.db.WithContext(ctx).Where("venue_id = ? AND code = ?", venueID, code).First(&addOn).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapError(errAddOn.ErrAddOnNotFound)
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &addOn, nil
+}
+
+func (a *AddOnRepository) Create(ctx context.Context, req *models.AddOn) (*models.AddOn, error) {
+	req.UUID = uuid.New()
+
+	err := a.db.WithContext(ctx).Omit("Venue").Create(req).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return req, nil
+}
+
+func (a *AddOnRepository) Update(ctx context.Context, uuid string, req *models.AddOn) (*models.AddOn, error) {
+	addOn, err := a.FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.db.WithContext(ctx).Model(addOn).Updates(map[string]any{
+		"code":      req.Code,
+		"name":      req.Name,
+		"category":  req.Category,
+		"price":     req.Price,
+		"stock":     req.Stock,
+		"is_active": req.IsActive,
+	}).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return addOn, nil
+}
+
+func (a *AddOnRepository) Delete(ctx context.Context, uuid string) error {
+	err := a.db.WithContext(ctx).Where("uuid = ?", uuid).Delete(&models.AddOn{}).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+// AdjustStock adds delta to the stock of an add-on; a negative delta takes
+// items out.
+func (a *AddOnRepository) AdjustStock(ctx context.Context, tx *gorm.DB, id uint, delta int) error {
+	err := tx.WithContext(ctx).Model(&models.AddOn{}).
+		Where("id = ?", id).
+		UpdateColumn("stock", gorm.Expr("stock + ?", delta)).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package repositories
+
+import (
+	"context"
+	errWrap "field-service/common/error"
+	errConstant "field-service/constants/error"
+	"field-service/domain/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AddOnStockRepository struct {
+	db *gorm.DB
+}
+
+type IAddOnStockRepository interface {
+	FindByOrderIDForUpdate(context.Context, *gorm.DB, uuid.UUID) ([]models.AddOnStock, error)
+	Create(context.Context, *gorm.DB, []models.AddOnStock) error
+	MarkReturned(context.Context, *gorm.DB, []uint) error
+}
+
+func NewAddOnStockRepository(db *gorm.DB) IAddOnStockRepository {
+	return &AddOnStockRepository{db: db}
+}
+
+// FindByOrderIDForUpdate locks what the order took, so returning it runs
+// once even when the events that trigger it arrive together.
+func (a *AddOnStockRepository) FindByOrderIDForUpdate(ctx context.Context, tx *gorm.DB, orderID uuid.UUID) ([]models.AddOnStock, error) {
+	var stocks []models.AddOnStock
+	err := tx.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("order_id = ?", orderID).
+		Order("id asc").
+		Find(&stocks).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return stocks, nil
+}
+
+func (a *AddOnStockRepository) Create(ctx context.Context, tx *gorm.DB, stocks []models.AddOnStock) error {
+	if len(stocks) == 0 {
+		return nil
+	}
+
+	err := tx.WithContext(ctx).Omit("AddOn").Create(&stocks).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+func (a *AddOnStockRepository) MarkReturned(ctx context.Context, tx *gorm.DB, ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	err := tx.WithContext(ctx).Model(&models.AddOnStock{}).
+		Where("id IN ?", ids).
+		Update("returned_at", time.Now()).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
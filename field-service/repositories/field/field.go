@@ -9,6 +9,8 @@ import (
 	"field-service/domain/dto"
 	"field-service/domain/models"
 	"fmt"
+	"strings"
+	"unicode"
 
 	"github.com/google/uuid"
 	"github.com/lib/pq"
@@ -22,10 +24,16 @@ type FieldRepository struct {
 type IFieldRepository interface {
 	FindAllWithPagination(context.Context, *dto.FieldRequestParam) ([]models.Field, int64, error)
 	FindAllWithoutPagination(context.Context) ([]models.Field, error)
+	FindActive(context.Context) ([]models.Field, error)
 	FindByUUID(context.Context, string) (*models.Field, error)
+	FindChildren(context.Context, uint) ([]models.Field, error)
 	Create(context.Context, *models.Field) (*models.Field, error)
 	Update(context.Context, string, *models.Field) (*models.Field, error)
 	Delete(context.Context, string) error
+	ReplaceAmenities(context.Context, *models.Field, []models.Amenity) error
+	Search(context.Context, *dto.FieldSearchRequestParam) ([]models.Field, int64, error)
+	Facets(context.Context, *dto.FieldSearchRequestParam) (*dto.FieldFacets, error)
+	AdjustRating(context.Context, *gorm.DB, uint, int, int) error
 }
 
 func NewFieldRepository(db *gorm.DB) IFieldRepository {
@@ -40,7 +48,11 @@ func (f *FieldRepository) FindAllWithPagination(ctx context.Context, param *dto.
 	)
 
 	if param.SortColumn != nil {
-		sort = fmt.Sprintf("%s %s", *param.SortColumn, *param.SortOrder)
+		order := "asc"
+		if param.SortOrder != nil {
+			order = *param.SortOrder
+		}
+		sort = fmt.Sprintf("%s %s", *param.SortColumn, order)
 	} else {
 		sort = "created_at desc"
 	}
@@ -48,7 +60,7 @@ func (f *FieldRepository) FindAllWithPagination(ctx context.Context, param *dto.
 	limit := param.Limit
 	offset := (param.Page - 1) * limit
 
-	err := f.db.WithContext(ctx).Limit(limit).Offset(offset).Order(sort).Find(&fields).Error
+	err := f.db.WithContext(ctx).Preload("Venue").Preload("Parent").Preload("Amenities").Limit(limit).Offset(offset).Order(sort).Find(&fields).Error
 	if err != nil {
 		return nil, 0, errWrap.WrapError(errConstant.ErrSQLError)
 	}
@@ -67,7 +79,7 @@ func (f *FieldRepository) FindAllWithoutPagination(ctx context.Context) ([]model
 		fields []models.Field
 	)
 
-	err := f.db.WithContext(ctx).Find(&fields).Error
+	err := f.db.WithContext(ctx).Preload("Venue").Preload("Parent").Preload("Amenities").Find(&fields).Error
 	if err != nil {
 		return nil, errWrap.WrapError(errConstant.ErrSQLError)
 	}
@@ -76,12 +88,25 @@ func (f *FieldRepository) FindAllWithoutPagination(ctx context.Context) ([]model
 
 }
 
+// FindActive returns the fields that have not been retired.
+func (f *FieldRepository) FindActive(ctx context.Context) ([]models.Field, error) {
+	var fields []models.Field
+	err := f.db.WithContext(ctx).Preload("Venue").Preload("Parent").
+		Where("deleted_at IS NULL").
+		Find(&fields).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return fields, nil
+}
+
 func (f *FieldRepository) FindByUUID(ctx context.Context, uuid string) (*models.Field, error) {
 	var (
 		field *models.Field
 	)
 
-	err := f.db.WithContext(ctx).Where("uuid = ?", uuid).First(&field).Error
+	err := f.db.WithContext(ctx).Preload("Venue").Preload("Parent").Preload("Amenities").Where("uuid = ?", uuid).First(&field).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errWrap.WrapError(errField.ErrFieldNotFound)
@@ -92,19 +117,45 @@ func (f *FieldRepository) FindByUUID(ctx context.Context, uuid string) (*models.
 	return field, nil
 }
 
+func (f *FieldRepository) FindChildren(ctx context.Context, parentID uint) ([]models.Field, error) {
+	var fields []models.Field
+	err := f.db.WithContext(ctx).Where("parent_id = ?", parentID).Order("id asc").Find(&fields).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return fields, nil
+}
+
 func (f *FieldRepository) Create(ctx context.Context, req *models.Field) (*models.Field, error) {
 	field := models.Field{
-		UUID:         uuid.New(),
+		UUID:         req.UUID,
+		VenueID:      req.VenueID,
+		ParentID:     req.ParentID,
 		Code:         req.Code,
 		Name:         req.Name,
 		Images:       req.Images,
+		Thumbnails:   req.Thumbnails,
 		PricePerHour: req.PricePerHour,
+		Surface:      req.Surface,
+		Size:         req.Size,
+		IsIndoor:     req.IsIndoor,
+		HasLighting:  req.HasLighting,
+		Amenities:    req.Amenities,
+	}
+
+	if field.UUID == uuid.Nil {
+		field.UUID = uuid.New()
 	}
 
 	if field.Images == nil {
 		field.Images = pq.StringArray{}
 	}
 
+	if field.Thumbnails == nil {
+		field.Thumbnails = pq.StringArray{}
+	}
+
 	err := f.db.WithContext(ctx).Create(&field).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -119,13 +170,23 @@ func (f *FieldRepository) Create(ctx context.Context, req *models.Field) (*model
 
 func (f *FieldRepository) Update(ctx context.Context, uuid string, req *models.Field) (*models.Field, error) {
 	field := models.Field{
+		VenueID:      req.VenueID,
+		ParentID:     req.ParentID,
 		Code:         req.Code,
 		Name:         req.Name,
 		Images:       req.Images,
+		Thumbnails:   req.Thumbnails,
 		PricePerHour: req.PricePerHour,
+		Surface:      req.Surface,
+		Size:         req.Size,
+		IsIndoor:     req.IsIndoor,
+		HasLighting:  req.HasLighting,
 	}
 
-	err := f.db.WithContext(ctx).Where("uuid = ?", uuid).Updates(&field).Error
+	// The columns are listed so that unchecked attributes are saved as false.
+	err := f.db.WithContext(ctx).Model(&models.Field{}).Where("uuid = ?", uuid).
+		Select("venue_id", "parent_id", "code", "name", "images", "thumbnails", "price_per_hour", "surface", "size", "is_indoor", "has_lighting").
+		Updates(&field).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errWrap.WrapError(errField.ErrFieldNotFound)
@@ -153,3 +214,178 @@ func (f *FieldRepository) Delete(ctx context.Context, uuid string) error {
 	return nil
 
 }
+
+// AdjustRating adds count reviews totalling sum stars to the field's rating.
+// Both may be negative to take reviews away.
+func (f *FieldRepository) AdjustRating(ctx context.Context, tx *gorm.DB, fieldID uint, count, sum int) error {
+	err := tx.WithContext(ctx).Model(&models.Field{}).
+		Where("id = ?", fieldID).
+		UpdateColumns(map[string]any{
+			"rating_count": gorm.Expr("rating_count + ?", count),
+			"rating_sum":   gorm.Expr("rating_sum + ?", sum),
+		}).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+func (f *FieldRepository) ReplaceAmenities(ctx context.Context, field *models.Field, amenities []models.Amenity) error {
+	err := f.db.WithContext(ctx).Model(field).Association("Amenities").Replace(amenities)
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+// Search returns one page of the fields matching every filter of the param.
+func (f *FieldRepository) Search(ctx context.Context, param *dto.FieldSearchRequestParam) ([]models.Field, int64, error) {
+	var (
+		fields []models.Field
+		total  int64
+	)
+
+	err := f.filter(ctx, param, "").Count(&total).Error
+	if err != nil {
+		return nil, 0, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	query := f.filter(ctx, param, "").Preload("Venue").Preload("Parent").Preload("Amenities")
+	if tsQuery := prefixTSQuery(param.Query); tsQuery != "" {
+		query = query.Order(gorm.Expr("ts_rank(to_tsvector('simple', fields.name || ' ' || fields.code), to_tsquery('simple', ?)) desc", tsQuery))
+	}
+
+	limit := param.Limit
+	offset := (param.Page - 1) * limit
+	err = query.Order("fields.name asc").Limit(limit).Offset(offset).Find(&fields).Error
+	if err != nil {
+		return nil, 0, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return fields, total, nil
+}
+
+// Facets counts the matching fields per attribute value. Surface, size,
+// indoor, lighting and price ignore their own filter so the other options stay
+// visible; amenities are combined with AND, so their counts keep it and show
+// how many fields remain when one more amenity is picked.
+func (f *FieldRepository) Facets(ctx context.Context, param *dto.FieldSearchRequestParam) (*dto.FieldFacets, error) {
+	var (
+		facets dto.FieldFacets
+		err    error
+	)
+
+	facets.Surface, err = f.countBy(ctx, param, "surface", "fields.surface")
+	if err != nil {
+		return nil, err
+	}
+
+	facets.Size, err = f.countBy(ctx, param, "size", "fields.size")
+	if err != nil {
+		return nil, err
+	}
+
+	facets.Indoor, err = f.countBy(ctx, param, "indoor", "fields.is_indoor::text")
+	if err != nil {
+		return nil, err
+	}
+
+	facets.Lighting, err = f.countBy(ctx, param, "lighting", "fields.has_lighting::text")
+	if err != nil {
+		return nil, err
+	}
+
+	err = f.filter(ctx, param, "").
+		Joins("JOIN field_amenities ON field_amenities.field_id = fields.id").
+		Joins("JOIN amenities ON amenities.id = field_amenities.amenity_id").
+		Select("amenities.code AS value, amenities.name AS label, count(*) AS count").
+		Group("amenities.code, amenities.name").
+		Order("amenities.name asc").
+		Scan(&facets.Amenities).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	err = f.filter(ctx, param, "price").
+		Select("coalesce(min(fields.price_per_hour), 0) AS min, coalesce(max(fields.price_per_hour), 0) AS max").
+		Scan(&facets.Price).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &facets, nil
+}
+
+func (f *FieldRepository) countBy(ctx context.Context, param *dto.FieldSearchRequestParam, facet, column string) ([]dto.FacetCount, error) {
+	counts := make([]dto.FacetCount, 0)
+	err := f.filter(ctx, param, facet).
+		Select(column + " AS value, count(*) AS count").
+		Where(column + " <> ''").
+		Group(column).
+		Order("value asc").
+		Scan(&counts).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return counts, nil
+}
+
+// filter applies the search filters of the param, except the one named by
+// skip.
+func (f *FieldRepository) filter(ctx context.Context, param *dto.FieldSearchRequestParam, skip string) *gorm.DB {
+	query := f.db.WithContext(ctx).Model(&models.Field{})
+
+	if tsQuery := prefixTSQuery(param.Query); tsQuery != "" {
+		query = query.Where("to_tsvector('simple', fields.name || ' ' || fields.code) @@ to_tsquery('simple', ?)", tsQuery)
+	}
+	if skip != "surface" && len(param.Surfaces) > 0 {
+		query = query.Where("fields.surface IN ?", param.Surfaces)
+	}
+	if skip != "size" && len(param.Sizes) > 0 {
+		query = query.Where("fields.size IN ?", param.Sizes)
+	}
+	if skip != "indoor" && param.IsIndoor != nil {
+		query = query.Where("fields.is_indoor = ?", *param.IsIndoor)
+	}
+	if skip != "lighting" && param.HasLighting != nil {
+		query = query.Where("fields.has_lighting = ?", *param.HasLighting)
+	}
+	if skip != "amenities" && len(param.Amenities) > 0 {
+		query = query.Where(`fields.id IN (
+			SELECT field_amenities.field_id FROM field_amenities
+			JOIN amenities ON amenities.id = field_amenities.amenity_id
+			WHERE amenities.code IN ?
+			GROUP BY field_amenities.field_id
+			HAVING count(DISTINCT amenities.id) = ?)`, param.Amenities, len(param.Amenities))
+	}
+	if skip != "price" && param.MinPrice != nil {
+		query = query.Where("fields.price_per_hour >= ?", *param.MinPrice)
+	}
+	if skip != "price" && param.MaxPrice != nil {
+		query = query.Where("fields.price_per_hour <= ?", *param.MaxPrice)
+	}
+
+	return query
+}
+
+// prefixTSQuery turns free text into a tsquery matching every word as a
+// prefix, e.g. "fut ind" becomes "fut:* & ind:*".
+func prefixTSQuery(text string) string {
+	terms := make([]string, 0)
+	for _, word := range strings.Fields(text) {
+		word = strings.Map(func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				return unicode.ToLower(r)
+			}
+			return -1
+		}, word)
+		if word != "" {
+			terms = append(terms, word+":*")
+		}
+	}
+
+	return strings.Join(terms, " & ")
+}
@@ -0,0 +1,121 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	errWrap "field-service/common/error"
+	errConstant "field-service/constants/error"
+	errFieldBlackout "field-service/constants/error/field_blackout"
+	"field-service/domain/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type FieldBlackoutRepository struct {
+	db *gorm.DB
+}
+
+type IFieldBlackoutRepository interface {
+	FindByFieldID(context.Context, uint) ([]models.FieldBlackout, error)
+	FindOverlapping(context.Context, uint, time.Time, time.Time) ([]models.FieldBlackout, error)
+	FindByUUID(context.Context, string) (*models.FieldBlackout, error)
+	Create(context.Context, *gorm.DB, *models.FieldBlackout) (*models.FieldBlackout, error)
+	Update(context.Context, *gorm.DB, string, *models.FieldBlackout) (*models.FieldBlackout, error)
+	Delete(context.Context, *gorm.DB, string) error
+}
+
+func NewFieldBlackoutRepository(db *gorm.DB) IFieldBlackoutRepository {
+	return &FieldBlackoutRepository{db: db}
+}
+
+func (f *FieldBlackoutRepository) FindByFieldID(ctx context.Context, fieldID uint) ([]models.FieldBlackout, error) {
+	var blackouts []models.FieldBlackout
+	err := f.db.WithContext(ctx).Preload("Field").
+		Where("field_id = ?", fieldID).
+		Order("start_at desc").
+		Find(&blackouts).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return blackouts, nil
+}
+
+func (f *FieldBlackoutRepository) FindOverlapping(ctx context.Context, fieldID uint, start, end time.Time) ([]models.FieldBlackout, error) {
+	var blackouts []models.FieldBlackout
+	err := f.db.WithContext(ctx).
+		Where("field_id = ? AND start_at < ? AND end_at > ?", fieldID, end, start).
+		Find(&blackouts).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return blackouts, nil
+}
+
+func (f *FieldBlackoutRepository) FindByUUID(ctx context.Context, uuid string) (*models.FieldBlackout, error) {
+	var blackout models.FieldBlackout
+	err := f.db.WithContext(ctx).Preload("Field").Where("uuid = ?", uuid).First(&blackout).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapError(errFieldBlackout.ErrFieldBlackoutNotFound)
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &blackout, nil
+}
+
+func (f *FieldBlackoutRepository) Create(ctx context.Context, tx *gorm.DB, req *models.FieldBlackout) (*models.FieldBlackout, error) {
+	blackout := models.FieldBlackout{
+		UUID:    req.UUID,
+		FieldID: req.FieldID,
+		StartAt: req.StartAt,
+		EndAt:   req.EndAt,
+		Reason:  req.Reason,
+	}
+
+	if blackout.UUID == uuid.Nil {
+		blackout.UUID = uuid.New()
+	}
+
+	err := tx.WithContext(ctx).Create(&blackout).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &blackout, nil
+}
+
+func (f *FieldBlackoutRepository) Update(ctx context.Context, tx *gorm.DB, uuid string, req *models.FieldBlackout) (*models.FieldBlackout, error) {
+	var blackout models.FieldBlackout
+	err := tx.WithContext(ctx).Preload("Field").Where("uuid = ?", uuid).First(&blackout).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapError(errFieldBlackout.ErrFieldBlackoutNotFound)
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	blackout.StartAt = req.StartAt
+	blackout.EndAt = req.EndAt
+	blackout.Reason = req.Reason
+
+	err = tx.WithContext(ctx).Save(&blackout).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &blackout, nil
+}
+
+func (f *FieldBlackoutRepository) Delete(ctx context.Context, tx *gorm.DB, uuid string) error {
+	err := tx.WithContext(ctx).Where("uuid = ?", uuid).Delete(&models.FieldBlackout{}).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
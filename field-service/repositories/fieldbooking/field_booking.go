@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"context"
+	errWrap "field-service/common/error"
+	errConstant "field-service/constants/error"
+	"field-service/domain/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type FieldBookingRepository struct {
+	db *gorm.DB
+}
+
+type IFieldBookingRepository interface {
+	FindByFieldIDFromDate(context.Context, uint, string) ([]models.FieldBooking, error)
+	FindActiveByFieldScheduleID(context.Context, uint) (*models.FieldBooking, error)
+	Create(context.Context, *gorm.DB, *models.FieldBooking) error
+	CancelByFieldScheduleIDs(context.Context, *gorm.DB, []uint) error
+}
+
+func NewFieldBookingRepository(db *gorm.DB) IFieldBookingRepository {
+	return &FieldBookingRepository{db: db}
+}
+
+// FindByFieldIDFromDate returns the bookings of a field, cancelled ones
+// included, whose schedule is on or after date.
+func (f *FieldBookingRepository) FindByFieldIDFromDate(ctx context.Context, fieldID uint, date string) ([]models.FieldBooking, error) {
+	var bookings []models.FieldBooking
+	err := f.db.WithContext(ctx).
+		Preload("FieldSchedule.Time").
+		Preload("FieldSchedule.Field.Venue").
+		Joins("JOIN field_schedules ON field_schedules.id = field_bookings.field_schedule_id").
+		Where("field_bookings.field_id = ? AND field_schedules.date >= ?", fieldID, date).
+		Order("field_schedules.date, field_bookings.id").
+		Find(&bookings).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return bookings, nil
+}
+
+// FindActiveByFieldScheduleID returns the booking holding the schedule, or nil
+// when it is not booked.
+func (f *FieldBookingRepository) FindActiveByFieldScheduleID(ctx context.Context, fieldScheduleID uint) (*models.FieldBooking, error) {
+	var bookings []models.FieldBooking
+	err := f.db.WithContext(ctx).
+		Where("field_schedule_id = ? AND cancelled_at IS NULL", fieldScheduleID).
+		Order("id desc").
+		Limit(1).
+		Find(&bookings).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	if len(bookings) == 0 {
+		return nil, nil
+	}
+
+	return &bookings[0], nil
+}
+
+func (f *FieldBookingRepository) Create(ctx context.Context, tx *gorm.DB, booking *models.FieldBooking) error {
+	booking.UUID = uuid.New()
+	err := tx.WithContext(ctx).Create(booking).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+// CancelByFieldScheduleIDs marks the active bookings of the schedules as
+// cancelled.
+func (f *FieldBookingRepository) CancelByFieldScheduleIDs(ctx context.Context, tx *gorm.DB, ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	err := tx.WithContext(ctx).Model(&models.FieldBooking{}).
+		Where("field_schedule_id IN ? AND cancelled_at IS NULL", ids).
+		Update("cancelled_at", time.Now()).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
@@ -0,0 +1,170 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	errWrap "field-service/common/error"
+	errConstant "field-service/constants/error"
+	errFieldReview "field-service/constants/error/field_review"
+	"field-service/domain/dto"
+	"field-service/domain/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type FieldReviewRepository struct {
+	db *gorm.DB
+}
+
+type IFieldReviewRepository interface {
+	FindVisibleByFieldID(context.Context, uint, *dto.FieldReviewRequestParam) ([]models.FieldReview, int64, error)
+	FindAllWithPagination(context.Context, uint, *dto.FieldReviewAdminRequestParam) ([]models.FieldReview, int64, error)
+	FindByUUID(context.Context, string) (*models.FieldReview, error)
+	FindByUUIDForUpdate(context.Context, *gorm.DB, string) (*models.FieldReview, error)
+	ExistsByFieldBookingID(context.Context, uint) (bool, error)
+	Create(context.Context, *gorm.DB, *models.FieldReview) error
+	Update(context.Context, *gorm.DB, *models.FieldReview) error
+	Delete(context.Context, *gorm.DB, uint) error
+}
+
+func NewFieldReviewRepository(db *gorm.DB) IFieldReviewRepository {
+	return &FieldReviewRepository{db: db}
+}
+
+func (f *FieldReviewRepository) FindVisibleByFieldID(ctx context.Context, fieldID uint, param *dto.FieldReviewRequestParam) ([]models.FieldReview, int64, error) {
+	var (
+		reviews []models.FieldReview
+		total   int64
+	)
+
+	query := f.db.WithContext(ctx).Model(&models.FieldReview{}).
+		Where("field_id = ? AND hidden_at IS NULL", fieldID)
+
+	err := query.Count(&total).Error
+	if err != nil {
+		return nil, 0, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	err = query.Preload("Field").
+		Order("created_at desc").
+		Limit(param.Limit).
+		Offset((param.Page - 1) * param.Limit).
+		Find(&reviews).Error
+	if err != nil {
+		return nil, 0, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return reviews, total, nil
+}
+
+// FindAllWithPagination lists the reviews for moderation, of every field when
+// fieldID is 0.
+func (f *FieldReviewRepository) FindAllWithPagination(ctx context.Context, fieldID uint, param *dto.FieldReviewAdminRequestParam) ([]models.FieldReview, int64, error) {
+	var (
+		reviews []models.FieldReview
+		total   int64
+	)
+
+	query := f.db.WithContext(ctx).Model(&models.FieldReview{})
+	if fieldID != 0 {
+		query = query.Where("field_id = ?", fieldID)
+	}
+	switch param.Status {
+	case "visible":
+		query = query.Where("hidden_at IS NULL")
+	case "hidden":
+		query = query.Where("hidden_at IS NOT NULL")
+	}
+
+	err := query.Count(&total).Error
+	if err != nil {
+		return nil, 0, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	err = query.Preload("Field").
+		Order("created_at desc").
+		Limit(param.Limit).
+		Offset((param.Page - 1) * param.Limit).
+		Find(&reviews).Error
+	if err != nil {
+		return nil, 0, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return reviews, total, nil
+}
+
+func (f *FieldReviewRepository) FindByUUID(ctx context.Context, uuid string) (*models.FieldReview, error) {
+	var review models.FieldReview
+	err := f.db.WithContext(ctx).Preload("Field").Where("uuid = ?", uuid).First(&review).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapError(errFieldReview.ErrFieldReviewNotFound)
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &review, nil
+}
+
+// FindByUUIDForUpdate locks the review so concurrent moderation cannot apply
+// the same change to the field's rating twice.
+func (f *FieldReviewRepository) FindByUUIDForUpdate(ctx context.Context, tx *gorm.DB, uuid string) (*models.FieldReview, error) {
+	var review models.FieldReview
+	err := tx.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("uuid = ?", uuid).
+		First(&review).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapError(errFieldReview.ErrFieldReviewNotFound)
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &review, nil
+}
+
+func (f *FieldReviewRepository) ExistsByFieldBookingID(ctx context.Context, fieldBookingID uint) (bool, error) {
+	var total int64
+	err := f.db.WithContext(ctx).Model(&models.FieldReview{}).
+		Where("field_booking_id = ?", fieldBookingID).
+		Count(&total).Error
+	if err != nil {
+		return false, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return total > 0, nil
+}
+
+func (f *FieldReviewRepository) Create(ctx context.Context, tx *gorm.DB, review *models.FieldReview) error {
+	if review.UUID == uuid.Nil {
+		review.UUID = uuid.New()
+	}
+
+	err := tx.WithContext(ctx).Omit(clause.Associations).Create(review).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+func (f *FieldReviewRepository) Update(ctx context.Context, tx *gorm.DB, review *models.FieldReview) error {
+	err := tx.WithContext(ctx).Omit(clause.Associations).Save(review).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+func (f *FieldReviewRepository) Delete(ctx context.Context, tx *gorm.DB, id uint) error {
+	err := tx.WithContext(ctx).Where("id = ?", id).Delete(&models.FieldReview{}).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
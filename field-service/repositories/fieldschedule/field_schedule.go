@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	errWrap "field-service/common/error"
+	"field-service/common/timeofday"
 	"field-service/constants"
 	errConstant "field-service/constants/error"
 	errFieldSchedule "field-service/constants/error/field_schedule"
@@ -12,6 +13,7 @@ import (
 	"fmt"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type FieldScheduleRepository struct {
@@ -22,10 +24,15 @@ type IFieldScheduleRepository interface {
 	FindAllWithPagination(context.Context, *dto.FieldScheduleRequestParam) ([]models.FieldSchedule, int64, error)
 	FindAllWithFieldIdAndDate(context.Context, int, string) ([]models.FieldSchedule, error)
 	FindByUUID(context.Context, string) (*models.FieldSchedule, error)
+	FindByUUIDs(context.Context, []string) ([]models.FieldSchedule, error)
 	FindByDateAndTimeId(context.Context, string, int, int) (*models.FieldSchedule, error)
+	FindByFieldIDAndDateRange(context.Context, uint, string, string) ([]models.FieldSchedule, error)
+	FindAvailability(context.Context, *uint, string, string) ([]dto.FieldAvailabilityRow, error)
 	Create(context.Context, []models.FieldSchedule) error
 	Update(context.Context, string, *models.FieldSchedule) (*models.FieldSchedule, error)
-	UpdateStatus(context.Context, constants.FieldScheduleStatus, string) error
+	FindOverlappingForUpdate(context.Context, *gorm.DB, []uint, string, timeofday.TimeOfDay, timeofday.TimeOfDay) ([]models.FieldSchedule, error)
+	FindByFieldIDAndDateRangeForUpdate(context.Context, *gorm.DB, uint, string, string) ([]models.FieldSchedule, error)
+	UpdateStatusByIDs(context.Context, *gorm.DB, constants.FieldScheduleStatus, []uint) error
 	Delete(context.Context, string) error
 }
 
@@ -41,7 +48,11 @@ func (f *FieldScheduleRepository) FindAllWithPagination(ctx context.Context, par
 	)
 
 	if param.SortColumn != nil {
-		sort = fmt.Sprintf("%s %s", *param.SortColumn, *&param.SortOrder)
+		order := "asc"
+		if param.SortOrder != nil {
+			order = *param.SortOrder
+		}
+		sort = fmt.Sprintf("%s %s", *param.SortColumn, order)
 	} else {
 		sort = "created_at desc"
 	}
@@ -84,7 +95,7 @@ func (f *FieldScheduleRepository) FindByUUID(ctx context.Context, uuid string) (
 		field *models.FieldSchedule
 	)
 
-	err := f.db.WithContext(ctx).Preload("Field").Preload("Time").Where("uuid = ?", uuid).First(&field).Error
+	err := f.db.WithContext(ctx).Preload("Field.Venue").Preload("Time").Where("uuid = ?", uuid).First(&field).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errWrap.WrapError(errFieldSchedule.ErrFieldScheduleNotFound)
@@ -95,6 +106,18 @@ func (f *FieldScheduleRepository) FindByUUID(ctx context.Context, uuid string) (
 	return field, nil
 }
 
+func (f *FieldScheduleRepository) FindByUUIDs(ctx context.Context, uuids []string) ([]models.FieldSchedule, error) {
+	var fieldSchedules []models.FieldSchedule
+	err := f.db.WithContext(ctx).Preload("Field.Venue").Preload("Time").
+		Where("uuid IN ?", uuids).
+		Find(&fieldSchedules).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return fieldSchedules, nil
+}
+
 func (f *FieldScheduleRepository) FindByDateAndTimeId(ctx context.Context, date string, timeId int, fieldId int) (*models.FieldSchedule, error) {
 	var (
 		field *models.FieldSchedule
@@ -111,8 +134,92 @@ func (f *FieldScheduleRepository) FindByDateAndTimeId(ctx context.Context, date
 	return field, nil
 }
 
+func (f *FieldScheduleRepository) FindByFieldIDAndDateRange(ctx context.Context, fieldID uint, startDate, endDate string) ([]models.FieldSchedule, error) {
+	var fieldSchedules []models.FieldSchedule
+	err := f.db.WithContext(ctx).Preload("Time").
+		Where("field_id = ? AND date BETWEEN ? AND ?", fieldID, startDate, endDate).
+		Find(&fieldSchedules).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return fieldSchedules, nil
+}
+
+// FindAvailability aggregates the schedules between startDate and endDate per
+// field and day in a single query: the status counts and the ordered slots of
+// the day. Closed and blocked slots are both counted as blocked.
+func (f *FieldScheduleRepository) FindAvailability(ctx context.Context, fieldID *uint, startDate, endDate string) ([]dto.FieldAvailabilityRow, error) {
+	var rows []dto.FieldAvailabilityRow
+	query := f.db.WithContext(ctx).Model(&models.FieldSchedule{}).
+		Joins("JOIN fields ON fields.id = field_schedules.field_id").
+		Joins("JOIN times ON times.id = field_schedules.time_id").
+		Select(`fields.uuid AS field_uuid, fields.name AS field_name, field_schedules.date AS date,
+			count(*) FILTER (WHERE field_schedules.status = ?) AS available,
+			count(*) FILTER (WHERE field_schedules.status = ?) AS booked,
+			count(*) FILTER (WHERE field_schedules.status IN ?) AS blocked,
+			json_agg(json_build_object(
+				'uuid', field_schedules.uuid,
+				'startTime', to_char(times.start_time, 'HH24:MI'),
+				'endTime', to_char(times.end_time, 'HH24:MI'),
+				'status', field_schedules.status
+			) ORDER BY times.start_time) AS slots`,
+			constants.Available, constants.Booked, []constants.FieldScheduleStatus{constants.Unavailable, constants.Blocked}).
+		Where("field_schedules.date BETWEEN ? AND ?", startDate, endDate)
+	if fieldID != nil {
+		query = query.Where("field_schedules.field_id = ?", *fieldID)
+	}
+
+	err := query.Group("fields.uuid, fields.name, field_schedules.date").
+		Order("fields.name asc, fields.uuid asc, field_schedules.date asc").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return rows, nil
+}
+
+// FindOverlappingForUpdate locks, in id order, the schedules of the fields on
+// the date whose slot overlaps start-end, so that concurrent bookings of
+// linked fields wait for each other.
+func (f *FieldScheduleRepository) FindOverlappingForUpdate(ctx context.Context, tx *gorm.DB, fieldIDs []uint, date string, start, end timeofday.TimeOfDay) ([]models.FieldSchedule, error) {
+	var fieldSchedules []models.FieldSchedule
+	err := tx.WithContext(ctx).
+		Joins("JOIN times ON times.id = field_schedules.time_id").
+		Where("field_schedules.field_id IN ? AND field_schedules.date = ?", fieldIDs, date).
+		Where("times.start_time < ? AND times.end_time > ?", end, start).
+		Order("field_schedules.id asc").
+		Clauses(clause.Locking{Strength: "UPDATE", Table: clause.Table{Name: "field_schedules"}}).
+		Find(&fieldSchedules).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return fieldSchedules, nil
+}
+
+// FindByFieldIDAndDateRangeForUpdate locks, in id order, the schedules of the
+// field between startDate and endDate, for changes that span whole days such
+// as a blackout.
+func (f *FieldScheduleRepository) FindByFieldIDAndDateRangeForUpdate(ctx context.Context, tx *gorm.DB, fieldID uint, startDate, endDate string) ([]models.FieldSchedule, error) {
+	var fieldSchedules []models.FieldSchedule
+	err := tx.WithContext(ctx).Preload("Time").
+		Where("field_id = ? AND date BETWEEN ? AND ?", fieldID, startDate, endDate).
+		Order("id asc").
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Find(&fieldSchedules).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return fieldSchedules, nil
+}
+
+// Create skips schedules whose field, date and time already exist, so callers
+// racing over the same range cannot insert a slot twice.
 func (f *FieldScheduleRepository) Create(ctx context.Context, req []models.FieldSchedule) error {
-	err := f.db.WithContext(ctx).Create(&req).Error
+	err := f.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&req).Error
 	if err != nil {
 		return errWrap.WrapError(errConstant.ErrSQLError)
 	}
@@ -140,24 +247,17 @@ func (f *FieldScheduleRepository) Update(ctx context.Context, uuid string, req *
 
 }
 
-func (f *FieldScheduleRepository) UpdateStatus(ctx context.Context, status constants.FieldScheduleStatus, uuid string) error {
-	fieldSchedule, err := f.FindByUUID(ctx, uuid)
-	if err != nil {
-		return err
+func (f *FieldScheduleRepository) UpdateStatusByIDs(ctx context.Context, tx *gorm.DB, status constants.FieldScheduleStatus, ids []uint) error {
+	if len(ids) == 0 {
+		return nil
 	}
 
-	fieldSchedule.Status = status
-
-	err = f.db.WithContext(ctx).Save(&fieldSchedule).Error
+	err := tx.WithContext(ctx).Model(&models.FieldSchedule{}).Where("id IN ?", ids).Update("status", status).Error
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errWrap.WrapError(errFieldSchedule.ErrFieldScheduleNotFound)
-		}
 		return errWrap.WrapError(errConstant.ErrSQLError)
 	}
 
 	return nil
-
 }
 
 func (f *FieldScheduleRepository) Delete(ctx context.Context, uuid string) error {
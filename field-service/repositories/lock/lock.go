@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	"context"
+	errWrap "field-service/common/error"
+	errConstant "field-service/constants/error"
+
+	"gorm.io/gorm"
+)
+
+type LockRepository struct {
+	db *gorm.DB
+}
+
+type ILockRepository interface {
+	TryLock(context.Context, int64) (func(), bool, error)
+}
+
+func NewLockRepository(db *gorm.DB) ILockRepository {
+	return &LockRepository{db: db}
+}
+
+// TryLock takes a postgres session advisory lock without waiting. The lock
+// belongs to a single pooled connection, so that connection is held until the
+// returned release function is called.
+func (l *LockRepository) TryLock(ctx context.Context, key int64) (func(), bool, error) {
+	sqlDB, err := l.db.DB()
+	if err != nil {
+		return nil, false, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return nil, false, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	var locked bool
+	err = conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", key).Scan(&locked)
+	if err != nil {
+		conn.Close()
+		return nil, false, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	if !locked {
+		conn.Close()
+		return nil, false, nil
+	}
+
+	release := func() {
+		_, _ = conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", key)
+		conn.Close()
+	}
+	return release, true, nil
+}
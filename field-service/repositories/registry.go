@@ -1,9 +1,18 @@
 package repositories
 
 import (
+	repoAddOn "field-service/repositories/addon"
+	repoAddOnStock "field-service/repositories/addonstock"
+	repoAmenity "field-service/repositories/amenity"
 	repoField "field-service/repositories/field"
+	repoFieldBlackout "field-service/repositories/fieldblackout"
+	repoFieldBooking "field-service/repositories/fieldbooking"
+	repoFieldReview "field-service/repositories/fieldreview"
 	repoFieldSchedule "field-service/repositories/fieldschedule"
+	repoLock "field-service/repositories/lock"
+	repoScheduleTemplate "field-service/repositories/scheduletemplate"
 	repoTime "field-service/repositories/time"
+	repoVenue "field-service/repositories/venue"
 
 	"gorm.io/gorm"
 )
@@ -16,6 +25,16 @@ type IRepositoryRegistry interface {
 	GetField() repoField.IFieldRepository
 	GetFieldSchedule() repoFieldSchedule.IFieldScheduleRepository
 	GetTime() repoTime.ITimeRepository
+	GetScheduleTemplate() repoScheduleTemplate.IScheduleTemplateRepository
+	GetLock() repoLock.ILockRepository
+	GetFieldBlackout() repoFieldBlackout.IFieldBlackoutRepository
+	GetVenue() repoVenue.IVenueRepository
+	GetAmenity() repoAmenity.IAmenityRepository
+	GetFieldBooking() repoFieldBooking.IFieldBookingRepository
+	GetFieldReview() repoFieldReview.IFieldReviewRepository
+	GetAddOn() repoAddOn.IAddOnRepository
+	GetAddOnStock() repoAddOnStock.IAddOnStockRepository
+	GetTx() *gorm.DB
 }
 
 func NewRepositoryRegistry(db *gorm.DB) IRepositoryRegistry {
@@ -31,3 +50,33 @@ func (r *Registry) GetFieldSchedule() repoFieldSchedule.IFieldScheduleRepository
 func (r *Registry) GetTime() repoTime.ITimeRepository {
 	return repoTime.NewTimeRepository(r.db)
 }
+func (r *Registry) GetScheduleTemplate() repoScheduleTemplate.IScheduleTemplateRepository {
+	return repoScheduleTemplate.NewScheduleTemplateRepository(r.db)
+}
+func (r *Registry) GetLock() repoLock.ILockRepository {
+	return repoLock.NewLockRepository(r.db)
+}
+func (r *Registry) GetFieldBlackout() repoFieldBlackout.IFieldBlackoutRepository {
+	return repoFieldBlackout.NewFieldBlackoutRepository(r.db)
+}
+func (r *Registry) GetVenue() repoVenue.IVenueRepository {
+	return repoVenue.NewVenueRepository(r.db)
+}
+func (r *Registry) GetAmenity() repoAmenity.IAmenityRepository {
+	return repoAmenity.NewAmenityRepository(r.db)
+}
+func (r *Registry) GetFieldBooking() repoFieldBooking.IFieldBookingRepository {
+	return repoFieldBooking.NewFieldBookingRepository(r.db)
+}
+func (r *Registry) GetFieldReview() repoFieldReview.IFieldReviewRepository {
+	return repoFieldReview.NewFieldReviewRepository(r.db)
+}
+func (r *Registry) GetAddOn() repoAddOn.IAddOnRepository {
+	return repoAddOn.NewAddOnRepository(r.db)
+}
+func (r *Registry) GetAddOnStock() repoAddOnStock.IAddOnStockRepository {
+	return repoAddOnStock.NewAddOnStockRepository(r.db)
+}
+func (r *Registry) GetTx() *gorm.DB {
+	return r.db
+}
@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"context"
+	errWrap "field-service/common/error"
+	errConstant "field-service/constants/error"
+	"field-service/domain/models"
+
+	"gorm.io/gorm"
+)
+
+type ScheduleTemplateRepository struct {
+	db *gorm.DB
+}
+
+type IScheduleTemplateRepository interface {
+	FindByFieldID(context.Context, uint) ([]models.ScheduleTemplate, error)
+	ReplaceByFieldID(context.Context, uint, bool, []models.ScheduleTemplate) error
+}
+
+func NewScheduleTemplateRepository(db *gorm.DB) IScheduleTemplateRepository {
+	return &ScheduleTemplateRepository{db: db}
+}
+
+func (s *ScheduleTemplateRepository) FindByFieldID(ctx context.Context, fieldID uint) ([]models.ScheduleTemplate, error) {
+	var templates []models.ScheduleTemplate
+	err := s.db.WithContext(ctx).Preload("Time").
+		Joins("JOIN times ON times.id = schedule_templates.time_id").
+		Where("schedule_templates.field_id = ?", fieldID).
+		Order("schedule_templates.weekday asc").Order("times.start_time asc").
+		Find(&templates).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return templates, nil
+}
+
+// ReplaceByFieldID stores the template of a field together with whether the
+// template is in force.
+func (s *ScheduleTemplateRepository) ReplaceByFieldID(ctx context.Context, fieldID uint, enabled bool, templates []models.ScheduleTemplate) error {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("field_id = ?", fieldID).Delete(&models.ScheduleTemplate{}).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Model(&models.Field{}).Where("id = ?", fieldID).Update("has_schedule_template", enabled).Error
+		if err != nil {
+			return err
+		}
+
+		if len(templates) == 0 {
+			return nil
+		}
+
+		return tx.Create(&templates).Error
+	})
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
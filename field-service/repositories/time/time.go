@@ -18,9 +18,13 @@ type TimeRepository struct {
 
 type ITimeRepository interface {
 	FindAll(context.Context) ([]models.Time, error)
+	FindByFieldID(context.Context, uint) ([]models.Time, error)
 	FindByUUID(context.Context, string) (*models.Time, error)
 	FindID(context.Context, int) ([]models.Time, error)
 	Create(context.Context, *models.Time) (*models.Time, error)
+	Update(context.Context, *models.Time) (*models.Time, error)
+	Delete(context.Context, *models.Time) error
+	IsUsed(context.Context, uint) (bool, error)
 }
 
 func NewTimeRepository(db *gorm.DB) ITimeRepository {
@@ -29,7 +33,7 @@ func NewTimeRepository(db *gorm.DB) ITimeRepository {
 
 func (t *TimeRepository) FindAll(ctx context.Context) ([]models.Time, error) {
 	var times []models.Time
-	err := t.db.WithContext(ctx).Find(&times).Error
+	err := t.db.WithContext(ctx).Where("field_id IS NULL").Order("start_time asc").Find(&times).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errWrap.WrapError(errTime.ErrTimeNotFound)
@@ -40,9 +44,19 @@ func (t *TimeRepository) FindAll(ctx context.Context) ([]models.Time, error) {
 	return times, err
 }
 
+func (t *TimeRepository) FindByFieldID(ctx context.Context, fieldID uint) ([]models.Time, error) {
+	var times []models.Time
+	err := t.db.WithContext(ctx).Preload("Field").Where("field_id = ?", fieldID).Order("start_time asc").Find(&times).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return times, nil
+}
+
 func (t *TimeRepository) FindByUUID(ctx context.Context, uuid string) (*models.Time, error) {
 	var times models.Time
-	err := t.db.WithContext(ctx).Where("uuid = ?", uuid).First(&times).Error
+	err := t.db.WithContext(ctx).Preload("Field").Where("uuid = ?", uuid).First(&times).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errWrap.WrapError(errTime.ErrTimeNotFound)
@@ -79,3 +93,37 @@ func (t *TimeRepository) Create(ctx context.Context, req *models.Time) (*models.
 
 	return req, nil
 }
+
+func (t *TimeRepository) Update(ctx context.Context, req *models.Time) (*models.Time, error) {
+	err := t.db.WithContext(ctx).Model(req).Updates(map[string]any{
+		"start_time": req.StartTime,
+		"end_time":   req.EndTime,
+	}).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return req, nil
+}
+
+func (t *TimeRepository) Delete(ctx context.Context, req *models.Time) error {
+	err := t.db.WithContext(ctx).Delete(req).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+// IsUsed reports whether any field schedule from today onwards uses the time.
+func (t *TimeRepository) IsUsed(ctx context.Context, id uint) (bool, error) {
+	var count int64
+	err := t.db.WithContext(ctx).Model(&models.FieldSchedule{}).
+		Where("time_id = ? AND date >= CURRENT_DATE", id).
+		Count(&count).Error
+	if err != nil {
+		return false, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return count > 0, nil
+}
@@ -0,0 +1,120 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	errWrap "field-service/common/error"
+	errConstant "field-service/constants/error"
+	errVenue "field-service/constants/error/venue"
+	"field-service/domain/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// haversine is the great-circle distance in kilometres between the venue and
+// the point bound to its three placeholders (lat, lng, lat).
+const haversine = "6371 * acos(least(1, cos(radians(?)) * cos(radians(latitude)) * cos(radians(longitude) - radians(?)) + sin(radians(?)) * sin(radians(latitude))))"
+
+type VenueRepository struct {
+	db *gorm.DB
+}
+
+type IVenueRepository interface {
+	FindAll(context.Context) ([]models.Venue, error)
+	FindNearby(context.Context, float64, float64, float64, int) ([]models.Venue, error)
+	FindByUUID(context.Context, string) (*models.Venue, error)
+	Create(context.Context, *models.Venue) (*models.Venue, error)
+	Update(context.Context, string, *models.Venue) (*models.Venue, error)
+	Delete(context.Context, string) error
+}
+
+func NewVenueRepository(db *gorm.DB) IVenueRepository {
+	return &VenueRepository{db: db}
+}
+
+func (v *VenueRepository) FindAll(ctx context.Context) ([]models.Venue, error) {
+	var venues []models.Venue
+	err := v.db.WithContext(ctx).Preload("Fields").Order("name asc").Find(&venues).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return venues, nil
+}
+
+// FindNearby returns the venues within radiusKm of the point, nearest first,
+// with Distance filled in.
+func (v *VenueRepository) FindNearby(ctx context.Context, lat, lng, radiusKm float64, limit int) ([]models.Venue, error) {
+	var venues []models.Venue
+	err := v.db.WithContext(ctx).Preload("Fields").
+		Select("venues.*, "+haversine+" AS distance", lat, lng, lat).
+		Where(haversine+" <= ?", lat, lng, lat, radiusKm).
+		Order("distance asc").
+		Limit(limit).
+		Find(&venues).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return venues, nil
+}
+
+func (v *VenueRepository) FindByUUID(ctx context.Context, uuid string) (*models.Venue, error) {
+	var venue models.Venue
+	err := v.db.WithContext(ctx).Preload("Fields").Where("uuid = ?", uuid).First(&venue).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapError(errVenue.ErrVenueNotFound)
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &venue, nil
+}
+
+func (v *VenueRepository) Create(ctx context.Context, req *models.Venue) (*models.Venue, error) {
+	req.UUID = uuid.New()
+
+	err := v.db.WithContext(ctx).Create(req).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return req, nil
+}
+
+func (v *VenueRepository) Update(ctx context.Context, uuid string, req *models.Venue) (*models.Venue, error) {
+	venue, err := v.FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	err = v.db.WithContext(ctx).Model(venue).Updates(map[string]any{
+		"name":               req.Name,
+		"address":            req.Address,
+		"city":               req.City,
+		"latitude":           req.Latitude,
+		"longitude":          req.Longitude,
+		"timezone":           req.Timezone,
+		"open_time":          req.OpenTime,
+		"close_time":         req.CloseTime,
+		"phone":              req.Phone,
+		"email":              req.Email,
+		"deposit_percentage": req.DepositPercentage,
+	}).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return v.FindByUUID(ctx, uuid)
+}
+
+func (v *VenueRepository) Delete(ctx context.Context, uuid string) error {
+	err := v.db.WithContext(ctx).Where("uuid = ?", uuid).Delete(&models.Venue{}).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
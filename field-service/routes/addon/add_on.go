@@ -0,0 +1,40 @@
+package routes
+
+import (
+	"field-service/clients"
+	"field-service/constants"
+	"field-service/controllers"
+	"field-service/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AddOnRoute struct {
+	controller controllers.IControllerRegistry
+	group      *gin.RouterGroup
+	client     clients.IClientRegistry
+}
+
+type IAddOnRoute interface {
+	Run()
+}
+
+func NewAddOnRoute(controller controllers.IControllerRegistry, group *gin.RouterGroup, client clients.IClientRegistry) IAddOnRoute {
+	return &AddOnRoute{
+		controller: controller,
+		group:      group,
+		client:     client,
+	}
+}
+
+func (a *AddOnRoute) Run() {
+	publicGroup := a.group.Group("/addon").Use(middlewares.AuthenticateWithoutToken())
+	publicGroup.GET("/lists/:uuid", a.controller.GetAddOn().GetAllByVenueUUID)
+
+	protectedGroup := a.group.Group("/addon").Use(middlewares.Authenticate())
+	protectedGroup.GET("", middlewares.CheckRole([]string{constants.Admin}, a.client), a.controller.GetAddOn().GetAll)
+	protectedGroup.GET("/:uuid", middlewares.CheckRole([]string{constants.Admin}, a.client), a.controller.GetAddOn().GetByUUID)
+	protectedGroup.POST("", middlewares.CheckRole([]string{constants.Admin}, a.client), a.controller.GetAddOn().Create)
+	protectedGroup.PUT("/:uuid", middlewares.CheckRole([]string{constants.Admin}, a.client), a.controller.GetAddOn().Update)
+	protectedGroup.DELETE("/:uuid", middlewares.CheckRole([]string{constants.Admin}, a.client), a.controller.GetAddOn().Delete)
+}
@@ -0,0 +1,39 @@
+package routes
+
+import (
+	"field-service/clients"
+	"field-service/constants"
+	"field-service/controllers"
+	"field-service/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AmenityRoute struct {
+	controller controllers.IControllerRegistry
+	group      *gin.RouterGroup
+	client     clients.IClientRegistry
+}
+
+type IAmenityRoute interface {
+	Run()
+}
+
+func NewAmenityRoute(controller controllers.IControllerRegistry, group *gin.RouterGroup, client clients.IClientRegistry) IAmenityRoute {
+	return &AmenityRoute{
+		controller: controller,
+		group:      group,
+		client:     client,
+	}
+}
+
+func (a *AmenityRoute) Run() {
+	publicGroup := a.group.Group("/amenity").Use(middlewares.AuthenticateWithoutToken())
+	publicGroup.GET("", a.controller.GetAmenity().GetAll)
+	publicGroup.GET("/:uuid", a.controller.GetAmenity().GetByUUID)
+
+	protectedGroup := a.group.Group("/amenity").Use(middlewares.Authenticate())
+	protectedGroup.POST("", middlewares.CheckRole([]string{constants.Admin}, a.client), a.controller.GetAmenity().Create)
+	protectedGroup.PUT("/:uuid", middlewares.CheckRole([]string{constants.Admin}, a.client), a.controller.GetAmenity().Update)
+	protectedGroup.DELETE("/:uuid", middlewares.CheckRole([]string{constants.Admin}, a.client), a.controller.GetAmenity().Delete)
+}
@@ -31,8 +31,15 @@ func (f *FieldRoute) Run() {
 	// Public routes (no token required)
 	publicGroup := f.group.Group("/field").Use(middlewares.AuthenticateWithoutToken())
 	publicGroup.GET("", f.controller.GetField().GetAllWithoutPagination)
+	publicGroup.GET("/search", f.controller.GetField().Search)
 	publicGroup.GET("/:uuid", f.controller.GetField().GetByUUID)
 
+	// Calendar apps authenticate with the feed token of a venue admin.
+	f.group.GET("/field/:uuid/calendar.ics",
+		middlewares.AuthenticateCalendarFeed([]string{constants.Admin}, f.client),
+		f.controller.GetField().GetCalendar,
+	)
+
 	// Protected routes (authentication + role check)
 	protectedGroup := f.group.Group("/field").Use(middlewares.Authenticate())
 	protectedGroup.GET("/pagination",
@@ -0,0 +1,37 @@
+package routes
+
+import (
+	"field-service/clients"
+	"field-service/constants"
+	"field-service/controllers"
+	"field-service/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FieldBlackoutRoute struct {
+	controller controllers.IControllerRegistry
+	group      *gin.RouterGroup
+	client     clients.IClientRegistry
+}
+
+type IFieldBlackoutRoute interface {
+	Run()
+}
+
+func NewFieldBlackoutRoute(controller controllers.IControllerRegistry, group *gin.RouterGroup, client clients.IClientRegistry) IFieldBlackoutRoute {
+	return &FieldBlackoutRoute{
+		controller: controller,
+		group:      group,
+		client:     client,
+	}
+}
+
+func (f *FieldBlackoutRoute) Run() {
+	group := f.group.Group("/field/blackout").Use(middlewares.Authenticate())
+	group.GET("", middlewares.CheckRole([]string{constants.Admin}, f.client), f.controller.GetFieldBlackout().GetAll)
+	group.GET("/:uuid", middlewares.CheckRole([]string{constants.Admin}, f.client), f.controller.GetFieldBlackout().GetByUUID)
+	group.POST("", middlewares.CheckRole([]string{constants.Admin}, f.client), f.controller.GetFieldBlackout().Create)
+	group.PUT("/:uuid", middlewares.CheckRole([]string{constants.Admin}, f.client), f.controller.GetFieldBlackout().Update)
+	group.DELETE("/:uuid", middlewares.CheckRole([]string{constants.Admin}, f.client), f.controller.GetFieldBlackout().Delete)
+}
@@ -0,0 +1,38 @@
+package routes
+
+import (
+	"field-service/clients"
+	"field-service/constants"
+	"field-service/controllers"
+	"field-service/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FieldReviewRoute struct {
+	controller controllers.IControllerRegistry
+	group      *gin.RouterGroup
+	client     clients.IClientRegistry
+}
+
+type IFieldReviewRoute interface {
+	Run()
+}
+
+func NewFieldReviewRoute(controller controllers.IControllerRegistry, group *gin.RouterGroup, client clients.IClientRegistry) IFieldReviewRoute {
+	return &FieldReviewRoute{
+		controller: controller,
+		group:      group,
+		client:     client,
+	}
+}
+
+func (f *FieldReviewRoute) Run() {
+	group := f.group.Group("/field/review").Use(middlewares.AuthenticateWithoutToken())
+	group.GET("/lists/:uuid", f.controller.GetFieldReview().GetAllByFieldUUID)
+	group.Use(middlewares.Authenticate())
+	group.POST("", middlewares.CheckRole([]string{constants.Customer}, f.client), f.controller.GetFieldReview().Create)
+	group.GET("", middlewares.CheckRole([]string{constants.Admin}, f.client), f.controller.GetFieldReview().GetAllWithPagination)
+	group.PUT("/:uuid/moderate", middlewares.CheckRole([]string{constants.Admin}, f.client), f.controller.GetFieldReview().Moderate)
+	group.DELETE("/:uuid", middlewares.CheckRole([]string{constants.Admin}, f.client), f.controller.GetFieldReview().Delete)
+}
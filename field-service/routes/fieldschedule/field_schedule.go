@@ -28,14 +28,20 @@ func NewFieldScheduleRoute(controller controllers.IControllerRegistry, group *gi
 }
 
 func (f *FieldScheduleRoute) Run() {
+	// EventSource cannot send custom headers, so the stream is public like the
+	// schedule lists it keeps up to date.
+	f.group.GET("/field/schedule/stream/:uuid", f.controller.GetFieldSchedule().Stream)
 	group := f.group.Group("/field/schedule").Use(middlewares.AuthenticateWithoutToken())
 	group.GET("/lists/:uuid", f.controller.GetFieldSchedule().GetAllFieldIdAndDate)
-	group.PATCH("/status", f.controller.GetFieldSchedule().UpdateStatus)
+	group.GET("/availability", f.controller.GetFieldSchedule().GetAvailability)
 	group.Use(middlewares.Authenticate())
 	group.GET("/:uuid", middlewares.CheckRole([]string{constants.Admin, constants.Customer}, f.client), f.controller.GetFieldSchedule().GetByUUID)
 	group.GET("/pagination", middlewares.CheckRole([]string{constants.Admin, constants.Customer}, f.client), f.controller.GetFieldSchedule().GetAllWithPagination)
 	group.POST("", middlewares.CheckRole([]string{constants.Admin}, f.client), f.controller.GetFieldSchedule().Create)
 	group.POST("/one-month", middlewares.CheckRole([]string{constants.Admin}, f.client), f.controller.GetFieldSchedule().GenerateScheduleForOneMonth)
+	group.POST("/generate", middlewares.CheckRole([]string{constants.Admin}, f.client), f.controller.GetFieldSchedule().Generate)
+	group.GET("/template/:uuid", middlewares.CheckRole([]string{constants.Admin}, f.client), f.controller.GetScheduleTemplate().GetByFieldUUID)
+	group.PUT("/template/:uuid", middlewares.CheckRole([]string{constants.Admin}, f.client), f.controller.GetScheduleTemplate().Update)
 	group.PUT("/:uuid", middlewares.CheckRole([]string{constants.Admin}, f.client), f.controller.GetFieldSchedule().Update)
 	group.DELETE("/:uuid", middlewares.CheckRole([]string{constants.Admin}, f.client), f.controller.GetFieldSchedule().Delete)
 
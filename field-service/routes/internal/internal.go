@@ -0,0 +1,39 @@
+package routes
+
+import (
+	"field-service/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+type InternalRoute struct {
+	controller controllers.IControllerRegistry
+	group      *gin.RouterGroup
+}
+
+type IInternalRoute interface {
+	Run()
+}
+
+// NewInternalRoute registers endpoints that are only called by other
+// services. The group is expected to require middlewares.AuthenticateInternal.
+func NewInternalRoute(controller controllers.IControllerRegistry, group *gin.RouterGroup) IInternalRoute {
+	return &InternalRoute{
+		controller: controller,
+		group:      group,
+	}
+}
+
+func (i *InternalRoute) Run() {
+	group := i.group.Group("/field/schedule")
+	group.PATCH("/status", i.controller.GetFieldSchedule().UpdateStatus)
+	group.POST("/reschedule", i.controller.GetFieldSchedule().Reschedule)
+	group.POST("/release", i.controller.GetFieldSchedule().Release)
+	group.POST("/announce", i.controller.GetFieldSchedule().Announce)
+	group.POST("/lookup", i.controller.GetFieldSchedule().GetByUUIDs)
+
+	addOnGroup := i.group.Group("/addon")
+	addOnGroup.POST("/lookup", i.controller.GetAddOn().GetByUUIDs)
+	addOnGroup.POST("/stock/take", i.controller.GetAddOn().TakeStock)
+	addOnGroup.POST("/stock/return", i.controller.GetAddOn().ReturnStock)
+}
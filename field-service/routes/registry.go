@@ -3,28 +3,36 @@ package routes
 import (
 	"field-service/clients"
 	"field-service/controllers"
+	routesAO "field-service/routes/addon"
+	routesA "field-service/routes/amenity"
 	routesF "field-service/routes/field"
+	routesFB "field-service/routes/fieldblackout"
+	routesFR "field-service/routes/fieldreview"
 	routesFS "field-service/routes/fieldschedule"
+	routesI "field-service/routes/internal"
 	routesT "field-service/routes/time"
+	routesV "field-service/routes/venue"
 
 	"github.com/gin-gonic/gin"
 )
 
 type Registry struct {
-	controller controllers.IControllerRegistry
-	group      *gin.RouterGroup
-	client     clients.IClientRegistry
+	controller    controllers.IControllerRegistry
+	group         *gin.RouterGroup
+	internalGroup *gin.RouterGroup
+	client        clients.IClientRegistry
 }
 
 type IRegistry interface {
 	Serve()
 }
 
-func NewRouteRegistry(controller controllers.IControllerRegistry, group *gin.RouterGroup, client clients.IClientRegistry) IRegistry {
+func NewRouteRegistry(controller controllers.IControllerRegistry, group, internalGroup *gin.RouterGroup, client clients.IClientRegistry) IRegistry {
 	return &Registry{
-		controller: controller,
-		group:      group,
-		client:     client,
+		controller:    controller,
+		group:         group,
+		internalGroup: internalGroup,
+		client:        client,
 	}
 }
 
@@ -36,12 +44,42 @@ func (r *Registry) fieldScheduleRoute() routesFS.IFieldScheduleRoute {
 	return routesFS.NewFieldScheduleRoute(r.controller, r.group, r.client)
 }
 
+func (r *Registry) fieldBlackoutRoute() routesFB.IFieldBlackoutRoute {
+	return routesFB.NewFieldBlackoutRoute(r.controller, r.group, r.client)
+}
+
+func (r *Registry) fieldReviewRoute() routesFR.IFieldReviewRoute {
+	return routesFR.NewFieldReviewRoute(r.controller, r.group, r.client)
+}
+
+func (r *Registry) addOnRoute() routesAO.IAddOnRoute {
+	return routesAO.NewAddOnRoute(r.controller, r.group, r.client)
+}
+
 func (r *Registry) timeRoute() routesT.ITimeRoute {
 	return routesT.NewTimeRoute(r.controller, r.group, r.client)
 }
 
+func (r *Registry) venueRoute() routesV.IVenueRoute {
+	return routesV.NewVenueRoute(r.controller, r.group, r.client)
+}
+
+func (r *Registry) amenityRoute() routesA.IAmenityRoute {
+	return routesA.NewAmenityRoute(r.controller, r.group, r.client)
+}
+
+func (r *Registry) internalRoute() routesI.IInternalRoute {
+	return routesI.NewInternalRoute(r.controller, r.internalGroup)
+}
+
 func (r *Registry) Serve() {
 	r.fieldRoute().Run()
 	r.fieldScheduleRoute().Run()
+	r.fieldBlackoutRoute().Run()
+	r.fieldReviewRoute().Run()
 	r.timeRoute().Run()
+	r.venueRoute().Run()
+	r.amenityRoute().Run()
+	r.addOnRoute().Run()
+	r.internalRoute().Run()
 }
@@ -32,5 +32,7 @@ func (f *TimeRoute) Run() {
 	group.GET("", middlewares.CheckRole([]string{constants.Admin}, f.client), f.controller.GetTime().GetAll)
 	group.GET("/:uuid", middlewares.CheckRole([]string{constants.Admin}, f.client), f.controller.GetTime().GetByUUID)
 	group.POST("", middlewares.CheckRole([]string{constants.Admin}, f.client), f.controller.GetTime().Create)
+	group.PUT("/:uuid", middlewares.CheckRole([]string{constants.Admin}, f.client), f.controller.GetTime().Update)
+	group.DELETE("/:uuid", middlewares.CheckRole([]string{constants.Admin}, f.client), f.controller.GetTime().Delete)
 
 }
@@ -0,0 +1,40 @@
+package routes
+
+import (
+	"field-service/clients"
+	"field-service/constants"
+	"field-service/controllers"
+	"field-service/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+type VenueRoute struct {
+	controller controllers.IControllerRegistry
+	group      *gin.RouterGroup
+	client     clients.IClientRegistry
+}
+
+type IVenueRoute interface {
+	Run()
+}
+
+func NewVenueRoute(controller controllers.IControllerRegistry, group *gin.RouterGroup, client clients.IClientRegistry) IVenueRoute {
+	return &VenueRoute{
+		controller: controller,
+		group:      group,
+		client:     client,
+	}
+}
+
+func (v *VenueRoute) Run() {
+	publicGroup := v.group.Group("/venue").Use(middlewares.AuthenticateWithoutToken())
+	publicGroup.GET("", v.controller.GetVenue().GetAll)
+	publicGroup.GET("/nearby", v.controller.GetVenue().GetNearby)
+	publicGroup.GET("/:uuid", v.controller.GetVenue().GetByUUID)
+
+	protectedGroup := v.group.Group("/venue").Use(middlewares.Authenticate())
+	protectedGroup.POST("", middlewares.CheckRole([]string{constants.Admin}, v.client), v.controller.GetVenue().Create)
+	protectedGroup.PUT("/:uuid", middlewares.CheckRole([]string{constants.Admin}, v.client), v.controller.GetVenue().Update)
+	protectedGroup.DELETE("/:uuid", middlewares.CheckRole([]string{constants.Admin}, v.client), v.controller.GetVenue().Delete)
+}
@@ -0,0 +1,284 @@
+package services
+
+import (
+	"context"
+	"errors"
+	errAddOn "field-service/constants/error/add_on"
+	"field-service/domain/dto"
+	"field-service/domain/models"
+	"field-service/repositories"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type AddOnService struct {
+	repository repositories.IRepositoryRegistry
+}
+
+type IAddOnService interface {
+	GetAllByVenueUUID(context.Context, string, bool) ([]dto.AddOnResponse, error)
+	GetByUUID(context.Context, string) (*dto.AddOnResponse, error)
+	GetByUUIDs(context.Context, *dto.AddOnLookupRequest) ([]dto.AddOnResponse, error)
+	Create(context.Context, *dto.AddOnRequest) (*dto.AddOnResponse, error)
+	Update(context.Context, string, *dto.UpdateAddOnRequest) (*dto.AddOnResponse, error)
+	Delete(context.Context, string) error
+	TakeStock(context.Context, *dto.TakeAddOnStockRequest) error
+	ReturnStock(context.Context, *dto.ReturnAddOnStockRequest) error
+}
+
+func NewAddOnService(repository repositories.IRepositoryRegistry) IAddOnService {
+	return &AddOnService{repository: repository}
+}
+
+// GetAllByVenueUUID lists the add-ons of a venue. Customers only see the
+// active ones.
+func (s *AddOnService) GetAllByVenueUUID(ctx context.Context, venueUUID string, activeOnly bool) ([]dto.AddOnResponse, error) {
+	venue, err := s.repository.GetVenue().FindByUUID(ctx, venueUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	addOns, err := s.repository.GetAddOn().FindByVenueID(ctx, venue.ID, activeOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.AddOnResponse, 0, len(addOns))
+	for i := range addOns {
+		response = append(response, toAddOnResponse(&addOns[i]))
+	}
+
+	return response, nil
+}
+
+func (s *AddOnService) GetByUUID(ctx context.Context, uuid string) (*dto.AddOnResponse, error) {
+	addOn, err := s.repository.GetAddOn().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toAddOnResponse(addOn)
+	return &response, nil
+}
+
+// GetByUUIDs looks up many add-ons at once for other services. Unknown IDs
+// are left out of the result.
+func (s *AddOnService) GetByUUIDs(ctx context.Context, req *dto.AddOnLookupRequest) ([]dto.AddOnResponse, error) {
+	addOns, err := s.repository.GetAddOn().FindByUUIDs(ctx, req.AddOnIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.AddOnResponse, 0, len(addOns))
+	for i := range addOns {
+		response = append(response, toAddOnResponse(&addOns[i]))
+	}
+
+	return response, nil
+}
+
+func (s *AddOnService) Create(ctx context.Context, req *dto.AddOnRequest) (*dto.AddOnResponse, error) {
+	venue, err := s.repository.GetVenue().FindByUUID(ctx, req.VenueID)
+	if err != nil {
+		return nil, err
+	}
+
+	code := normalizeCode(req.Code)
+	err = s.checkCode(ctx, venue.ID, code, "")
+	if err != nil {
+		return nil, err
+	}
+
+	addOn, err := s.repository.GetAddOn().Create(ctx, &models.AddOn{
+		VenueID:  venue.ID,
+		Code:     code,
+		Name:     req.Name,
+		Category: req.Category,
+		Price:    req.Price,
+		Stock:    req.Stock,
+		IsActive: req.IsActive == nil || *req.IsActive,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	addOn.Venue = *venue
+	response := toAddOnResponse(addOn)
+	return &response, nil
+}
+
+func (s *AddOnService) Update(ctx context.Context, uuid string, req *dto.UpdateAddOnRequest) (*dto.AddOnResponse, error) {
+	current, err := s.repository.GetAddOn().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	code := normalizeCode(req.Code)
+	err = s.checkCode(ctx, current.VenueID, code, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	isActive := current.IsActive
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	addOn, err := s.repository.GetAddOn().Update(ctx, uuid, &models.AddOn{
+		Code:     code,
+		Name:     req.Name,
+		Category: req.Category,
+		Price:    req.Price,
+		Stock:    req.Stock,
+		IsActive: isActive,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := toAddOnResponse(addOn)
+	return &response, nil
+}
+
+func (s *AddOnService) Delete(ctx context.Context, uuid string) error {
+	_, err := s.repository.GetAddOn().FindByUUID(ctx, uuid)
+	if err != nil {
+		return err
+	}
+
+	return s.repository.GetAddOn().Delete(ctx, uuid)
+}
+
+// TakeStock takes the add-ons of a paid order out of stock. Taking them again
+// for the same order does nothing. Stock is not checked here: the order is
+// already paid, so an item sold out in the meantime leaves the stock negative
+// for the venue to sort out.
+func (s *AddOnService) TakeStock(ctx context.Context, req *dto.TakeAddOnStockRequest) error {
+	orderID, _ := uuid.Parse(req.OrderID)
+
+	addOnIDs := make([]string, 0, len(req.Items))
+	for _, item := range req.Items {
+		addOnIDs = append(addOnIDs, item.AddOnID)
+	}
+
+	addOns, err := s.repository.GetAddOn().FindByUUIDs(ctx, addOnIDs)
+	if err != nil {
+		return err
+	}
+
+	byUUID := make(map[string]*models.AddOn, len(addOns))
+	for i := range addOns {
+		byUUID[addOns[i].UUID.String()] = &addOns[i]
+	}
+
+	quantities := make(map[uint]int, len(req.Items))
+	stocks := make([]models.AddOnStock, 0, len(req.Items))
+	for _, item := range req.Items {
+		addOn, ok := byUUID[item.AddOnID]
+		if !ok {
+			return errAddOn.ErrAddOnNotFound
+		}
+
+		if _, seen := quantities[addOn.ID]; !seen {
+			stocks = append(stocks, models.AddOnStock{OrderID: orderID, AddOnID: addOn.ID})
+		}
+		quantities[addOn.ID] += item.Quantity
+	}
+
+	for i := range stocks {
+		stocks[i].Quantity = quantities[stocks[i].AddOnID]
+	}
+
+	return s.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		taken, txErr := s.repository.GetAddOnStock().FindByOrderIDForUpdate(ctx, tx, orderID)
+		if txErr != nil {
+			return txErr
+		}
+
+		if len(taken) > 0 {
+			return nil
+		}
+
+		txErr = s.repository.GetAddOnStock().Create(ctx, tx, stocks)
+		if txErr != nil {
+			return txErr
+		}
+
+		for _, stock := range stocks {
+			txErr = s.repository.GetAddOn().AdjustStock(ctx, tx, stock.AddOnID, -stock.Quantity)
+			if txErr != nil {
+				return txErr
+			}
+		}
+
+		return nil
+	})
+}
+
+// ReturnStock puts back what an expired or cancelled order took. Orders that
+// never took anything, or already gave it back, are left alone.
+func (s *AddOnService) ReturnStock(ctx context.Context, req *dto.ReturnAddOnStockRequest) error {
+	orderID, _ := uuid.Parse(req.OrderID)
+
+	return s.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		taken, txErr := s.repository.GetAddOnStock().FindByOrderIDForUpdate(ctx, tx, orderID)
+		if txErr != nil {
+			return txErr
+		}
+
+		returned := make([]uint, 0, len(taken))
+		for _, stock := range taken {
+			if stock.ReturnedAt != nil {
+				continue
+			}
+
+			txErr = s.repository.GetAddOn().AdjustStock(ctx, tx, stock.AddOnID, stock.Quantity)
+			if txErr != nil {
+				return txErr
+			}
+			returned = append(returned, stock.ID)
+		}
+
+		return s.repository.GetAddOnStock().MarkReturned(ctx, tx, returned)
+	})
+}
+
+// checkCode makes sure no other add-on of the venue than the one being
+// updated already uses the code.
+func (s *AddOnService) checkCode(ctx context.Context, venueID uint, code, uuid string) error {
+	addOn, err := s.repository.GetAddOn().FindByVenueIDAndCode(ctx, venueID, code)
+	if err != nil {
+		if errors.Is(err, errAddOn.ErrAddOnNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if addOn.UUID.String() != uuid {
+		return errAddOn.ErrAddOnExists
+	}
+
+	return nil
+}
+
+func normalizeCode(code string) string {
+	return strings.ToLower(strings.TrimSpace(code))
+}
+
+func toAddOnResponse(addOn *models.AddOn) dto.AddOnResponse {
+	return dto.AddOnResponse{
+		UUID:      addOn.UUID,
+		VenueID:   addOn.Venue.UUID,
+		VenueName: addOn.Venue.Name,
+		Code:      addOn.Code,
+		Name:      addOn.Name,
+		Category:  addOn.Category,
+		Price:     addOn.Price,
+		Stock:     addOn.Stock,
+		IsActive:  addOn.IsActive,
+		CreatedAt: addOn.CreatedAt,
+		UpdateAt:  addOn.UpdatedAt,
+	}
+}
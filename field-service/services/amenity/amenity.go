@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"errors"
+	errAmenity "field-service/constants/error/amenity"
+	"field-service/domain/dto"
+	"field-service/domain/models"
+	"field-service/repositories"
+	"strings"
+)
+
+type AmenityService struct {
+	repository repositories.IRepositoryRegistry
+}
+
+type IAmenityService interface {
+	GetAll(context.Context) ([]dto.AmenityResponse, error)
+	GetByUUID(context.Context, string) (*dto.AmenityResponse, error)
+	Create(context.Context, *dto.AmenityRequest) (*dto.AmenityResponse, error)
+	Update(context.Context, string, *dto.AmenityRequest) (*dto.AmenityResponse, error)
+	Delete(context.Context, string) error
+}
+
+func NewAmenityService(repository repositories.IRepositoryRegistry) IAmenityService {
+	return &AmenityService{repository: repository}
+}
+
+func (s *AmenityService) GetAll(ctx context.Context) ([]dto.AmenityResponse, error) {
+	amenities, err := s.repository.GetAmenity().FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.AmenityResponse, 0, len(amenities))
+	for i := range amenities {
+		response = append(response, toAmenityResponse(&amenities[i]))
+	}
+
+	return response, nil
+}
+
+func (s *AmenityService) GetByUUID(ctx context.Context, uuid string) (*dto.AmenityResponse, error) {
+	amenity, err := s.repository.GetAmenity().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toAmenityResponse(amenity)
+	return &response, nil
+}
+
+func (s *AmenityService) Create(ctx context.Context, req *dto.AmenityRequest) (*dto.AmenityResponse, error) {
+	code := normalizeCode(req.Code)
+	err := s.checkCode(ctx, code, "")
+	if err != nil {
+		return nil, err
+	}
+
+	amenity, err := s.repository.GetAmenity().Create(ctx, &models.Amenity{
+		Code: code,
+		Name: req.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := toAmenityResponse(amenity)
+	return &response, nil
+}
+
+func (s *AmenityService) Update(ctx context.Context, uuid string, req *dto.AmenityRequest) (*dto.AmenityResponse, error) {
+	code := normalizeCode(req.Code)
+	err := s.checkCode(ctx, code, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	amenity, err := s.repository.GetAmenity().Update(ctx, uuid, &models.Amenity{
+		Code: code,
+		Name: req.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := toAmenityResponse(amenity)
+	return &response, nil
+}
+
+func (s *AmenityService) Delete(ctx context.Context, uuid string) error {
+	_, err := s.repository.GetAmenity().FindByUUID(ctx, uuid)
+	if err != nil {
+		return err
+	}
+
+	return s.repository.GetAmenity().Delete(ctx, uuid)
+}
+
+// checkCode makes sure no other amenity than the one being updated already
+// uses the code.
+func (s *AmenityService) checkCode(ctx context.Context, code, uuid string) error {
+	amenity, err := s.repository.GetAmenity().FindByCode(ctx, code)
+	if err != nil {
+		if errors.Is(err, errAmenity.ErrAmenityNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if amenity.UUID.String() != uuid {
+		return errAmenity.ErrAmenityExists
+	}
+
+	return nil
+}
+
+func normalizeCode(code string) string {
+	return strings.ToLower(strings.TrimSpace(code))
+}
+
+func toAmenityResponse(amenity *models.Amenity) dto.AmenityResponse {
+	return dto.AmenityResponse{
+		UUID:      amenity.UUID,
+		Code:      amenity.Code,
+		Name:      amenity.Name,
+		CreatedAt: amenity.CreatedAt,
+		UpdateAt:  amenity.UpdatedAt,
+	}
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"common/ical"
+	"context"
+	"field-service/config"
+	"field-service/domain/models"
+	"fmt"
+	"time"
+)
+
+// calendarFeedPastDays keeps recent bookings in the feed so cancellations of
+// games that already happened still reach the calendars.
+const calendarFeedPastDays = 30
+
+// GetCalendar renders the bookings of a field as an iCalendar feed. Cancelled
+// bookings stay in the feed as cancelled events under the same UID.
+func (s *FieldService) GetCalendar(ctx context.Context, uuid string) ([]byte, error) {
+	field, err := s.repository.GetField().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	from := time.Now().AddDate(0, 0, -calendarFeedPastDays).Format(time.DateOnly)
+	bookings, err := s.repository.GetFieldBooking().FindByFieldIDFromDate(ctx, field.ID, from)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]ical.Event, 0, len(bookings))
+	for i := range bookings {
+		events = append(events, toCalendarEvent(field, &bookings[i]))
+	}
+
+	calendar := ical.Calendar{
+		ProductID: fmt.Sprintf("-//%s//field calendar//EN", config.Cfg.AppName),
+		Name:      field.Name,
+		Events:    events,
+	}
+	return calendar.Encode(), nil
+}
+
+func toCalendarEvent(field *models.Field, booking *models.FieldBooking) ical.Event {
+	schedule := booking.FieldSchedule
+	start, end := schedule.Time.RangeOn(schedule.Date)
+
+	location := ""
+	if field.Venue != nil {
+		location = field.Venue.Location()
+	}
+
+	summary := field.Name
+	description := ""
+	if booking.OrderCode != "" {
+		summary = fmt.Sprintf("%s - %s", field.Name, booking.OrderCode)
+		description = fmt.Sprintf("Order %s", booking.OrderCode)
+	}
+
+	stamp := *booking.CreatedAt
+	sequence := 0
+	if booking.CancelledAt != nil {
+		stamp = *booking.CancelledAt
+		sequence = 1
+	}
+
+	return ical.Event{
+		UID:         fmt.Sprintf("%s@%s", booking.UUID, config.Cfg.AppName),
+		Summary:     summary,
+		Description: description,
+		Location:    location,
+		Start:       start,
+		End:         end,
+		Stamp:       stamp,
+		Sequence:    sequence,
+		Cancelled:   booking.CancelledAt != nil,
+	}
+}
@@ -2,18 +2,20 @@ package services
 
 import (
 	"bytes"
+	"common/storage"
 	"context"
-	"field-service/common/gcs"
+	"errors"
+	"field-service/common/imageproc"
 	"field-service/common/util"
 	errConstant "field-service/constants/error"
+	errAmenity "field-service/constants/error/amenity"
+	errField "field-service/constants/error/field"
 	"field-service/domain/dto"
 	"field-service/domain/models"
 	"field-service/repositories"
 	"fmt"
 	"io"
 	"mime/multipart"
-	"path"
-	"time"
 
 	"github.com/google/uuid"
 	"github.com/lib/pq"
@@ -22,7 +24,7 @@ import (
 
 type FieldService struct {
 	repository repositories.IRepositoryRegistry
-	gcs        gcs.IGCSClient
+	storage    storage.IStorageClient
 }
 
 type IfieldService interface {
@@ -32,10 +34,12 @@ type IfieldService interface {
 	Create(context.Context, *dto.FieldRequest) (*dto.FieldResponse, error)
 	Update(context.Context, string, *dto.UpdateFieldRequest) (*dto.FieldResponse, error)
 	Delete(context.Context, string) error
+	Search(context.Context, *dto.FieldSearchRequestParam) (*dto.FieldSearchResponse, error)
+	GetCalendar(context.Context, string) ([]byte, error)
 }
 
-func NewFieldService(repository repositories.IRepositoryRegistry, gcs gcs.IGCSClient) IfieldService {
-	return &FieldService{repository: repository, gcs: gcs}
+func NewFieldService(repository repositories.IRepositoryRegistry, storage storage.IStorageClient) IfieldService {
+	return &FieldService{repository: repository, storage: storage}
 }
 
 func (s *FieldService) GetAllWithPagination(ctx context.Context, req *dto.FieldRequestParam) (*util.PaginationResult, error) {
@@ -46,15 +50,19 @@ func (s *FieldService) GetAllWithPagination(ctx context.Context, req *dto.FieldR
 
 	fieldsResult := make([]dto.FieldResponse, 0, len(fields))
 	for _, field := range fields {
-		fieldsResult = append(fieldsResult, dto.FieldResponse{
+		fieldResult := dto.FieldResponse{
 			UUID:         field.UUID,
 			Code:         field.Code,
 			Name:         field.Name,
 			PricePerHour: field.PricePerHour,
-			Images:       field.Images,
+			Images:       storage.SignURLs(ctx, s.storage, field.Images),
+			Thumbnails:   storage.SignURLs(ctx, s.storage, field.Thumbnails),
 			CreatedAt:    field.CreatedAt,
 			UpdateAt:     field.UpdatedAt,
-		})
+		}
+		setVenue(&fieldResult, field.Venue)
+		setAttributes(&fieldResult, &field)
+		fieldsResult = append(fieldsResult, fieldResult)
 	}
 
 	pagination := util.PaginationParam{
@@ -76,12 +84,16 @@ func (s *FieldService) GetAllWithoutPagination(ctx context.Context) ([]dto.Field
 
 	fieldsResult := make([]dto.FieldResponse, 0, len(fields))
 	for _, field := range fields {
-		fieldsResult = append(fieldsResult, dto.FieldResponse{
+		fieldResult := dto.FieldResponse{
 			UUID:         field.UUID,
 			Name:         field.Name,
 			PricePerHour: field.PricePerHour,
-			Images:       field.Images,
-		})
+			Images:       storage.SignURLs(ctx, s.storage, field.Images),
+			Thumbnails:   storage.SignURLs(ctx, s.storage, field.Thumbnails),
+		}
+		setVenue(&fieldResult, field.Venue)
+		setAttributes(&fieldResult, &field)
+		fieldsResult = append(fieldsResult, fieldResult)
 	}
 
 	return fieldsResult, nil
@@ -98,9 +110,12 @@ func (s *FieldService) GetByUUID(ctx context.Context, uuid string) (*dto.FieldRe
 	fieldsResult.Code = field.Code
 	fieldsResult.Name = field.Name
 	fieldsResult.PricePerHour = field.PricePerHour
-	fieldsResult.Images = field.Images
+	fieldsResult.Images = storage.SignURLs(ctx, s.storage, field.Images)
+	fieldsResult.Thumbnails = storage.SignURLs(ctx, s.storage, field.Thumbnails)
 	fieldsResult.CreatedAt = field.CreatedAt
 	fieldsResult.UpdateAt = field.UpdatedAt
+	setVenue(fieldsResult, field.Venue)
+	setAttributes(fieldsResult, field)
 
 	return fieldsResult, nil
 
@@ -119,117 +134,244 @@ func (s *FieldService) validateUpload(images []multipart.FileHeader) error {
 	return nil
 }
 
-func (s *FieldService) processAndUpload(ctx context.Context, image multipart.FileHeader) (string, error) {
+// processAndUpload validates the image by its content, strips its metadata
+// and uploads a web-size and a thumbnail variant. Object keys are derived
+// from the field and the image content, so uploading the same file again
+// overwrites the same objects.
+func (s *FieldService) processAndUpload(ctx context.Context, fieldUUID uuid.UUID, image multipart.FileHeader) (string, string, error) {
 	file, err := image.Open()
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
+	defer file.Close()
 
 	buffer := new(bytes.Buffer)
 	_, err = io.Copy(buffer, file)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 
-	fileName := fmt.Sprintf("images/%s-%s-%s", time.Now().Format("20060102150405"), image.Filename, path.Ext(image.Filename))
-	url, err := s.gcs.UploadFile(ctx, fileName, buffer.Bytes())
+	result, err := imageproc.Process(buffer.Bytes())
 	if err != nil {
-		return "", nil
+		switch {
+		case errors.Is(err, imageproc.ErrUnsupportedImage):
+			return "", "", errConstant.ErrUnsupportedImage
+		case errors.Is(err, imageproc.ErrImageTooLarge):
+			return "", "", errConstant.ErrImageTooLarge
+		}
+		return "", "", err
 	}
 
-	return url, nil
+	prefix := fmt.Sprintf("images/fields/%s/%s", fieldUUID, result.Hash)
+	webName, err := s.storage.UploadFile(ctx, fmt.Sprintf("%s-web.%s", prefix, result.Web.Extension), result.Web.Data)
+	if err != nil {
+		return "", "", err
+	}
+
+	thumbnailName, err := s.storage.UploadFile(ctx, fmt.Sprintf("%s-thumb.%s", prefix, result.Thumbnail.Extension), result.Thumbnail.Data)
+	if err != nil {
+		s.deleteImages(ctx, []string{webName}, nil)
+		return "", "", err
+	}
+
+	return webName, thumbnailName, nil
 }
 
-func (s *FieldService) uploadImages(ctx context.Context, images []multipart.FileHeader) ([]string, error) {
+// uploadImages uploads every image, or none: the variants already uploaded
+// are removed again when one of the images fails.
+func (s *FieldService) uploadImages(ctx context.Context, fieldUUID uuid.UUID, images []multipart.FileHeader) ([]string, []string, error) {
 	err := s.validateUpload(images)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	urls := make([]string, 0, len(images))
+
+	names := make([]string, 0, len(images))
+	thumbnails := make([]string, 0, len(images))
 	for _, v := range images {
-		url, err := s.processAndUpload(ctx, v)
+		name, thumbnail, err := s.processAndUpload(ctx, fieldUUID, v)
 		if err != nil {
-			return nil, err
+			s.deleteImages(ctx, append(names, thumbnails...), nil)
+			return nil, nil, err
 		}
-		urls = append(urls, url)
+		names = append(names, name)
+		thumbnails = append(thumbnails, thumbnail)
+	}
+
+	return names, thumbnails, nil
+}
+
+// deleteImages removes the objects behind names that are not in keep. Failures
+// are only logged: the field is already saved and a leftover object is
+// harmless.
+func (s *FieldService) deleteImages(ctx context.Context, names []string, keep []string) {
+	kept := make(map[string]bool, len(keep))
+	for _, name := range keep {
+		kept[name] = true
 	}
 
-	return urls, nil
+	for _, name := range names {
+		if kept[name] {
+			continue
+		}
+
+		err := s.storage.DeleteFile(ctx, name)
+		if err != nil {
+			logrus.Errorf("error delete image %s: %v", name, err)
+		}
+	}
 }
 
 func (s *FieldService) Create(ctx context.Context, req *dto.FieldRequest) (*dto.FieldResponse, error) {
-	imageUrl, err := s.uploadImages(ctx, req.Images)
+	venue, err := s.findVenue(ctx, req.VenueID)
+	if err != nil {
+		return nil, err
+	}
+
+	parent, err := s.findParent(ctx, req.ParentID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	amenities, err := s.findAmenities(ctx, req.AmenityIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldUUID := uuid.New()
+	imageNames, thumbnailName, err := s.uploadImages(ctx, fieldUUID, req.Images)
 	if err != nil {
 		return nil, err
 	}
 
 	field, err := s.repository.GetField().Create(ctx, &models.Field{
+		UUID:         fieldUUID,
+		VenueID:      venueID(venue),
+		ParentID:     parentID(parent),
 		Code:         req.Code,
 		Name:         req.Name,
 		PricePerHour: req.PricePerHour,
-		Images:       pq.StringArray(imageUrl),
+		Images:       pq.StringArray(imageNames),
+		Thumbnails:   pq.StringArray(thumbnailName),
+		Surface:      req.Surface,
+		Size:         req.Size,
+		IsIndoor:     req.IsIndoor,
+		HasLighting:  req.HasLighting,
+		Amenities:    amenities,
 	})
 	if err != nil {
 		logrus.Errorf("error create field: %v", err)
+		s.deleteImages(ctx, append(imageNames, thumbnailName...), nil)
 		return nil, err
 	}
+	field.Parent = parent
 
 	response := &dto.FieldResponse{
 		UUID:         field.UUID,
 		Code:         field.Code,
 		Name:         field.Name,
 		PricePerHour: field.PricePerHour,
-		Images:       field.Images,
+		Images:       storage.SignURLs(ctx, s.storage, field.Images),
+		Thumbnails:   storage.SignURLs(ctx, s.storage, field.Thumbnails),
 		CreatedAt:    field.CreatedAt,
 		UpdateAt:     field.UpdatedAt,
 	}
+	setVenue(response, venue)
+	setAttributes(response, field)
 
 	return response, nil
 
 }
 func (s *FieldService) Update(ctx context.Context, uuidParam string, req *dto.UpdateFieldRequest) (*dto.FieldResponse, error) {
-	var image []string
+	var image, thumbnail []string
 
 	field, err := s.repository.GetField().FindByUUID(ctx, uuidParam)
 	if err != nil {
 		return nil, err
 	}
 
+	venue, err := s.findVenue(ctx, req.VenueID)
+	if err != nil {
+		return nil, err
+	}
+
+	if venue == nil {
+		venue = field.Venue
+	}
+
+	parent, err := s.findParent(ctx, req.ParentID, field)
+	if err != nil {
+		return nil, err
+	}
+
+	if parent == nil {
+		parent = field.Parent
+	}
+
+	amenities := field.Amenities
+	if req.AmenityIDs != nil {
+		amenities, err = s.findAmenities(ctx, req.AmenityIDs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if req.Images == nil {
 		image = field.Images
+		thumbnail = field.Thumbnails
 	} else {
-		imageUrl, err := s.uploadImages(ctx, req.Images)
+		image, thumbnail, err = s.uploadImages(ctx, field.UUID, req.Images)
+		if err != nil {
+			return nil, err
+		}
+	}
+	previous := append(append([]string{}, field.Images...), field.Thumbnails...)
+
+	if req.AmenityIDs != nil {
+		err = s.repository.GetField().ReplaceAmenities(ctx, field, amenities)
 		if err != nil {
 			return nil, err
 		}
-		image = append(image, imageUrl...)
 	}
 
 	field, err = s.repository.GetField().Update(ctx, uuidParam, &models.Field{
+		VenueID:      venueID(venue),
+		ParentID:     parentID(parent),
 		Code:         req.Code,
 		Name:         req.Name,
 		PricePerHour: req.PricePerHour,
 		Images:       image,
+		Thumbnails:   thumbnail,
+		Surface:      req.Surface,
+		Size:         req.Size,
+		IsIndoor:     req.IsIndoor,
+		HasLighting:  req.HasLighting,
 	})
 	if err != nil {
 		return nil, err
 	}
+	// Replaced images are no longer referenced anywhere.
+	s.deleteImages(ctx, previous, append(image, thumbnail...))
+	field.Parent = parent
+	field.Amenities = amenities
 	uuidParsed, _ := uuid.Parse(uuidParam)
 	response := &dto.FieldResponse{
 		UUID:         uuidParsed,
 		Code:         field.Code,
 		Name:         field.Name,
 		PricePerHour: field.PricePerHour,
-		Images:       field.Images,
+		Images:       storage.SignURLs(ctx, s.storage, field.Images),
+		Thumbnails:   storage.SignURLs(ctx, s.storage, field.Thumbnails),
 		CreatedAt:    field.CreatedAt,
 		UpdateAt:     field.UpdatedAt,
 	}
+	setVenue(response, venue)
+	setAttributes(response, field)
 
 	return response, nil
 }
 func (s *FieldService) Delete(ctx context.Context, uuid string) error {
 
-	_, err := s.repository.GetField().FindByUUID(ctx, uuid)
+	field, err := s.repository.GetField().FindByUUID(ctx, uuid)
 	if err != nil {
 		return err
 	}
@@ -239,5 +381,164 @@ func (s *FieldService) Delete(ctx context.Context, uuid string) error {
 		return err
 	}
 
+	s.deleteImages(ctx, append(field.Images, field.Thumbnails...), nil)
+
 	return nil
 }
+
+// Search filters fields by text, attributes, amenities and price, and returns
+// the page of results together with the facet counts of the current filters.
+func (s *FieldService) Search(ctx context.Context, req *dto.FieldSearchRequestParam) (*dto.FieldSearchResponse, error) {
+	fields, total, err := s.repository.GetField().Search(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	facets, err := s.repository.GetField().Facets(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldsResult := make([]dto.FieldResponse, 0, len(fields))
+	for _, field := range fields {
+		fieldResult := dto.FieldResponse{
+			UUID:         field.UUID,
+			Code:         field.Code,
+			Name:         field.Name,
+			PricePerHour: field.PricePerHour,
+			Images:       storage.SignURLs(ctx, s.storage, field.Images),
+			Thumbnails:   storage.SignURLs(ctx, s.storage, field.Thumbnails),
+		}
+		setVenue(&fieldResult, field.Venue)
+		setAttributes(&fieldResult, &field)
+		fieldsResult = append(fieldsResult, fieldResult)
+	}
+
+	pagination := util.GeneratePagination(util.PaginationParam{
+		Count: total,
+		Page:  req.Page,
+		Limit: req.Limit,
+		Data:  fieldsResult,
+	})
+
+	return &dto.FieldSearchResponse{
+		Fields: pagination,
+		Facets: *facets,
+	}, nil
+}
+
+// findAmenities resolves the amenity UUIDs of a field request. Blank and
+// repeated entries are ignored, so an empty form value clears the amenities.
+func (s *FieldService) findAmenities(ctx context.Context, uuids []string) ([]models.Amenity, error) {
+	ids := make([]string, 0, len(uuids))
+	seen := make(map[string]bool, len(uuids))
+	for _, id := range uuids {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	if len(ids) == 0 {
+		return []models.Amenity{}, nil
+	}
+
+	amenities, err := s.repository.GetAmenity().FindByUUIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(amenities) != len(ids) {
+		return nil, errAmenity.ErrAmenityNotFound
+	}
+
+	return amenities, nil
+}
+
+// findParent resolves the optional full pitch a field is part of. Only one
+// level is allowed: the parent must not be a half itself, and a field that
+// already has halves cannot become one.
+func (s *FieldService) findParent(ctx context.Context, parentUUID string, field *models.Field) (*models.Field, error) {
+	if parentUUID == "" {
+		return nil, nil
+	}
+
+	parent, err := s.repository.GetField().FindByUUID(ctx, parentUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if parent.ParentID != nil {
+		return nil, errField.ErrInvalidParent
+	}
+
+	if field == nil {
+		return parent, nil
+	}
+
+	if parent.ID == field.ID {
+		return nil, errField.ErrInvalidParent
+	}
+
+	children, err := s.repository.GetField().FindChildren(ctx, field.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(children) > 0 {
+		return nil, errField.ErrInvalidParent
+	}
+
+	return parent, nil
+}
+
+// findVenue resolves the optional venue of a field request.
+func (s *FieldService) findVenue(ctx context.Context, venueUUID string) (*models.Venue, error) {
+	if venueUUID == "" {
+		return nil, nil
+	}
+
+	return s.repository.GetVenue().FindByUUID(ctx, venueUUID)
+}
+
+func venueID(venue *models.Venue) *uint {
+	if venue == nil {
+		return nil
+	}
+	return &venue.ID
+}
+
+func parentID(parent *models.Field) *uint {
+	if parent == nil {
+		return nil
+	}
+	return &parent.ID
+}
+
+func setVenue(response *dto.FieldResponse, venue *models.Venue) {
+	if venue == nil {
+		return
+	}
+	response.VenueID = &venue.UUID
+	response.VenueName = venue.Name
+}
+
+func setAttributes(response *dto.FieldResponse, field *models.Field) {
+	response.Surface = field.Surface
+	response.Size = field.Size
+	response.IsIndoor = field.IsIndoor
+	response.HasLighting = field.HasLighting
+	response.Rating = field.AverageRating()
+	response.RatingCount = field.RatingCount
+	if field.Parent != nil {
+		response.ParentID = &field.Parent.UUID
+	}
+	response.Amenities = make([]dto.AmenityResponse, 0, len(field.Amenities))
+	for _, amenity := range field.Amenities {
+		response.Amenities = append(response.Amenities, dto.AmenityResponse{
+			UUID: amenity.UUID,
+			Code: amenity.Code,
+			Name: amenity.Name,
+		})
+	}
+}
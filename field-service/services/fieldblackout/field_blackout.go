@@ -0,0 +1,389 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"field-service/common/broker"
+	"field-service/config"
+	"field-service/constants"
+	errFieldBlackout "field-service/constants/error/field_blackout"
+	"field-service/controllers/kafka"
+	"field-service/domain/dto"
+	"field-service/domain/models"
+	"field-service/repositories"
+	servicesFieldSchedule "field-service/services/fieldschedule"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	blackoutTimeLayout = "2006-01-02 15:04"
+	blackoutEventName  = "field-blackout"
+)
+
+type FieldBlackoutService struct {
+	repository repositories.IRepositoryRegistry
+	kafka      kafka.IKafkaRegistry
+	broker     broker.IBroker
+	schedule   servicesFieldSchedule.IFieldScheduleService
+}
+
+type IFieldBlackoutService interface {
+	GetAllByFieldUUID(context.Context, string) ([]dto.FieldBlackoutResponse, error)
+	GetByUUID(context.Context, string) (*dto.FieldBlackoutResponse, error)
+	Create(context.Context, *dto.FieldBlackoutRequest) (*dto.FieldBlackoutResponse, error)
+	Update(context.Context, string, *dto.UpdateFieldBlackoutRequest) (*dto.FieldBlackoutResponse, error)
+	Delete(context.Context, string) error
+}
+
+func NewFieldBlackoutService(repository repositories.IRepositoryRegistry, kafka kafka.IKafkaRegistry, broker broker.IBroker, schedule servicesFieldSchedule.IFieldScheduleService) IFieldBlackoutService {
+	return &FieldBlackoutService{repository: repository, kafka: kafka, broker: broker, schedule: schedule}
+}
+
+func (s *FieldBlackoutService) GetAllByFieldUUID(ctx context.Context, fieldUUID string) ([]dto.FieldBlackoutResponse, error) {
+	field, err := s.repository.GetField().FindByUUID(ctx, fieldUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	blackouts, err := s.repository.GetFieldBlackout().FindByFieldID(ctx, field.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.FieldBlackoutResponse, 0, len(blackouts))
+	for i := range blackouts {
+		response = append(response, toFieldBlackoutResponse(&blackouts[i], field))
+	}
+
+	return response, nil
+}
+
+func (s *FieldBlackoutService) GetByUUID(ctx context.Context, uuid string) (*dto.FieldBlackoutResponse, error) {
+	blackout, err := s.repository.GetFieldBlackout().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toFieldBlackoutResponse(blackout, &blackout.Field)
+	return &response, nil
+}
+
+func (s *FieldBlackoutService) Create(ctx context.Context, req *dto.FieldBlackoutRequest) (*dto.FieldBlackoutResponse, error) {
+	startAt, endAt, err := parseBlackoutRange(req.StartAt, req.EndAt)
+	if err != nil {
+		return nil, err
+	}
+
+	field, err := s.repository.GetField().FindByUUID(ctx, req.FieldID)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		response dto.FieldBlackoutResponse
+		changed  []models.FieldSchedule
+	)
+	err = s.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		blackout, txErr := s.repository.GetFieldBlackout().Create(ctx, tx, &models.FieldBlackout{
+			FieldID: field.ID,
+			StartAt: startAt,
+			EndAt:   endAt,
+			Reason:  req.Reason,
+		})
+		if txErr != nil {
+			return txErr
+		}
+
+		response = toFieldBlackoutResponse(blackout, field)
+		response.ClosedCount, response.CancelledBookings, changed, txErr = s.close(ctx, tx, blackout, field)
+		return txErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.publish(changed)
+	return &response, nil
+}
+
+// Update reopens the schedules of the old range, saves the blackout and
+// closes the schedules of the new range in one transaction.
+func (s *FieldBlackoutService) Update(ctx context.Context, uuid string, req *dto.UpdateFieldBlackoutRequest) (*dto.FieldBlackoutResponse, error) {
+	startAt, endAt, err := parseBlackoutRange(req.StartAt, req.EndAt)
+	if err != nil {
+		return nil, err
+	}
+
+	blackout, err := s.repository.GetFieldBlackout().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		response dto.FieldBlackoutResponse
+		changed  []models.FieldSchedule
+	)
+	err = s.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		reopened, txErr := s.reopen(ctx, tx, blackout)
+		if txErr != nil {
+			return txErr
+		}
+
+		updated, txErr := s.repository.GetFieldBlackout().Update(ctx, tx, uuid, &models.FieldBlackout{
+			StartAt: startAt,
+			EndAt:   endAt,
+			Reason:  req.Reason,
+		})
+		if txErr != nil {
+			return txErr
+		}
+
+		var closed []models.FieldSchedule
+		response = toFieldBlackoutResponse(updated, &blackout.Field)
+		response.ClosedCount, response.CancelledBookings, closed, txErr = s.close(ctx, tx, updated, &blackout.Field)
+		if txErr != nil {
+			return txErr
+		}
+
+		changed = append(reopened, closed...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.publish(changed)
+	return &response, nil
+}
+
+func (s *FieldBlackoutService) Delete(ctx context.Context, uuid string) error {
+	blackout, err := s.repository.GetFieldBlackout().FindByUUID(ctx, uuid)
+	if err != nil {
+		return err
+	}
+
+	var reopened []models.FieldSchedule
+	err = s.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		txErr := s.repository.GetFieldBlackout().Delete(ctx, tx, uuid)
+		if txErr != nil {
+			return txErr
+		}
+
+		reopened, txErr = s.reopen(ctx, tx, blackout)
+		return txErr
+	})
+	if err != nil {
+		return err
+	}
+
+	s.publish(reopened)
+	return nil
+}
+
+// close marks every schedule inside the blackout as unavailable, cancels
+// their bookings and unblocks the linked schedules those bookings held. The
+// booked schedules are announced on Kafka as the last step, so order-service
+// can cancel and refund their orders; when that fails the transaction rolls
+// back and the admin can retry. It returns the number of closed schedules and
+// cancelled bookings, and the schedules it changed.
+func (s *FieldBlackoutService) close(ctx context.Context, tx *gorm.DB, blackout *models.FieldBlackout, field *models.Field) (int, int, []models.FieldSchedule, error) {
+	schedules, err := s.overlappingSchedules(ctx, tx, blackout)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+
+	ids := make([]uint, 0, len(schedules))
+	changed := make([]models.FieldSchedule, 0, len(schedules))
+	booked := make([]uuid.UUID, 0)
+	bookedIDs := make([]uint, 0)
+	bookedSchedules := make([]models.FieldSchedule, 0)
+	for _, schedule := range schedules {
+		if schedule.Status == constants.Booked {
+			booked = append(booked, schedule.UUID)
+			bookedIDs = append(bookedIDs, schedule.ID)
+			schedule.Field = *field
+			bookedSchedules = append(bookedSchedules, schedule)
+		}
+		// Blocked slots are held by a booking of a linked field and stay as
+		// they are, so reopening cannot release them.
+		if schedule.Status == constants.Available || schedule.Status == constants.Booked {
+			ids = append(ids, schedule.ID)
+			schedule.Status = constants.Unavailable
+			changed = append(changed, schedule)
+		}
+	}
+
+	err = s.repository.GetFieldSchedule().UpdateStatusByIDs(ctx, tx, constants.Unavailable, ids)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+
+	err = s.repository.GetFieldBooking().CancelByFieldScheduleIDs(ctx, tx, bookedIDs)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+
+	for i := range bookedSchedules {
+		unblocked, err := s.schedule.UnblockLinked(ctx, tx, &bookedSchedules[i])
+		if err != nil {
+			return 0, 0, nil, err
+		}
+		changed = append(changed, unblocked...)
+	}
+
+	if len(booked) > 0 {
+		err = s.produceToKafka(blackout, field, booked)
+		if err != nil {
+			logrus.Errorf("failed to announce blackout %s: %v", blackout.UUID, err)
+			return 0, 0, nil, errFieldBlackout.ErrBlackoutNotAnnounced
+		}
+	}
+
+	return len(ids), len(booked), changed, nil
+}
+
+// reopen makes the schedules closed by the blackout available again, unless
+// another blackout of the same field still covers them. It returns the
+// schedules it reopened.
+func (s *FieldBlackoutService) reopen(ctx context.Context, tx *gorm.DB, blackout *models.FieldBlackout) ([]models.FieldSchedule, error) {
+	schedules, err := s.overlappingSchedules(ctx, tx, blackout)
+	if err != nil {
+		return nil, err
+	}
+
+	others, err := s.repository.GetFieldBlackout().FindOverlapping(ctx, blackout.FieldID, blackout.StartAt.AddDate(0, 0, -1), blackout.EndAt.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint, 0, len(schedules))
+	reopened := make([]models.FieldSchedule, 0, len(schedules))
+	for _, schedule := range schedules {
+		if schedule.Status != constants.Unavailable {
+			continue
+		}
+
+		start, end := schedule.Time.RangeOn(schedule.Date)
+		covered := false
+		for i := range others {
+			if others[i].ID != blackout.ID && others[i].Overlaps(start, end) {
+				covered = true
+				break
+			}
+		}
+
+		if !covered {
+			ids = append(ids, schedule.ID)
+			schedule.Status = constants.Available
+			reopened = append(reopened, schedule)
+		}
+	}
+
+	err = s.repository.GetFieldSchedule().UpdateStatusByIDs(ctx, tx, constants.Available, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return reopened, nil
+}
+
+// publish announces the schedules a blackout closed or reopened on the
+// schedule stream.
+func (s *FieldBlackoutService) publish(schedules []models.FieldSchedule) {
+	for _, schedule := range schedules {
+		data, err := json.Marshal(dto.FieldScheduleStatusEvent{
+			UUID:   schedule.UUID,
+			Date:   schedule.Date.Format(time.DateOnly),
+			Status: schedule.Status.GetStatusString(),
+		})
+		if err != nil {
+			logrus.Errorf("failed to encode schedule event: %v", err)
+			continue
+		}
+
+		s.broker.Publish(schedule.Topic(), schedule.Status.GetEventName(), data)
+	}
+}
+
+// overlappingSchedules locks the schedules of the field that overlap the
+// blackout.
+func (s *FieldBlackoutService) overlappingSchedules(ctx context.Context, tx *gorm.DB, blackout *models.FieldBlackout) ([]models.FieldSchedule, error) {
+	// Start a day early to catch slots that begin before midnight.
+	startDate := blackout.StartAt.In(time.Local).AddDate(0, 0, -1).Format(time.DateOnly)
+	endDate := blackout.EndAt.In(time.Local).Format(time.DateOnly)
+	schedules, err := s.repository.GetFieldSchedule().FindByFieldIDAndDateRangeForUpdate(ctx, tx, blackout.FieldID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]models.FieldSchedule, 0, len(schedules))
+	for _, schedule := range schedules {
+		start, end := schedule.Time.RangeOn(schedule.Date)
+		if blackout.Overlaps(start, end) {
+			result = append(result, schedule)
+		}
+	}
+
+	return result, nil
+}
+
+func (s *FieldBlackoutService) produceToKafka(blackout *models.FieldBlackout, field *models.Field, fieldScheduleIDs []uuid.UUID) error {
+	kafkaMsg := dto.KafkaMessage{
+		Event: dto.KafkaEvent{
+			Name: blackoutEventName,
+		},
+		Metadata: dto.KafkaMetaData{
+			Sender:    "field-service",
+			SendingAt: time.Now().Format(time.RFC3339),
+		},
+		Body: dto.KafkaBody{
+			Type: "JSON",
+			Data: &dto.KafkaData{
+				BlackoutID:       blackout.UUID,
+				FieldID:          field.UUID,
+				Reason:           blackout.Reason,
+				StartAt:          blackout.StartAt,
+				EndAt:            blackout.EndAt,
+				FieldScheduleIDs: fieldScheduleIDs,
+			},
+		},
+	}
+
+	kafkaMsgJSON, _ := json.Marshal(kafkaMsg)
+	return s.kafka.GetKafkaProducer().ProduceMessage(config.Cfg.Kafka.Topic, kafkaMsgJSON)
+}
+
+func parseBlackoutRange(startAt, endAt string) (time.Time, time.Time, error) {
+	start, err := time.ParseInLocation(blackoutTimeLayout, startAt, time.Local)
+	if err != nil {
+		return time.Time{}, time.Time{}, errFieldBlackout.ErrInvalidBlackoutRange
+	}
+
+	end, err := time.ParseInLocation(blackoutTimeLayout, endAt, time.Local)
+	if err != nil {
+		return time.Time{}, time.Time{}, errFieldBlackout.ErrInvalidBlackoutRange
+	}
+
+	if !end.After(start) {
+		return time.Time{}, time.Time{}, errFieldBlackout.ErrInvalidBlackoutRange
+	}
+
+	return start, end, nil
+}
+
+func toFieldBlackoutResponse(blackout *models.FieldBlackout, field *models.Field) dto.FieldBlackoutResponse {
+	return dto.FieldBlackoutResponse{
+		UUID:      blackout.UUID,
+		FieldID:   field.UUID,
+		FieldName: field.Name,
+		StartAt:   blackout.StartAt.In(time.Local).Format(blackoutTimeLayout),
+		EndAt:     blackout.EndAt.In(time.Local).Format(blackoutTimeLayout),
+		Reason:    blackout.Reason,
+		CreatedAt: blackout.CreatedAt,
+		UpdatedAt: blackout.UpdatedAt,
+	}
+}
@@ -0,0 +1,224 @@
+package services
+
+import (
+	"context"
+	clientUser "field-service/clients/user"
+	"field-service/common/util"
+	"field-service/constants"
+	errFieldReview "field-service/constants/error/field_review"
+	"field-service/domain/dto"
+	"field-service/domain/models"
+	"field-service/repositories"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type FieldReviewService struct {
+	repository repositories.IRepositoryRegistry
+}
+
+type IFieldReviewService interface {
+	GetAllByFieldUUID(context.Context, string, *dto.FieldReviewRequestParam) (*util.PaginationResult, error)
+	GetAllWithPagination(context.Context, *dto.FieldReviewAdminRequestParam) (*util.PaginationResult, error)
+	Create(context.Context, *dto.FieldReviewRequest) (*dto.FieldReviewResponse, error)
+	Moderate(context.Context, string, *dto.ModerateFieldReviewRequest) (*dto.FieldReviewResponse, error)
+	Delete(context.Context, string) error
+}
+
+func NewFieldReviewService(repository repositories.IRepositoryRegistry) IFieldReviewService {
+	return &FieldReviewService{repository: repository}
+}
+
+func (s *FieldReviewService) GetAllByFieldUUID(ctx context.Context, fieldUUID string, req *dto.FieldReviewRequestParam) (*util.PaginationResult, error) {
+	field, err := s.repository.GetField().FindByUUID(ctx, fieldUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	reviews, total, err := s.repository.GetFieldReview().FindVisibleByFieldID(ctx, field.ID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	response := util.GeneratePagination(util.PaginationParam{
+		Count: total,
+		Page:  req.Page,
+		Limit: req.Limit,
+		Data:  toFieldReviewResponses(reviews),
+	})
+	return &response, nil
+}
+
+func (s *FieldReviewService) GetAllWithPagination(ctx context.Context, req *dto.FieldReviewAdminRequestParam) (*util.PaginationResult, error) {
+	var fieldID uint
+	if req.FieldID != "" {
+		field, err := s.repository.GetField().FindByUUID(ctx, req.FieldID)
+		if err != nil {
+			return nil, err
+		}
+		fieldID = field.ID
+	}
+
+	reviews, total, err := s.repository.GetFieldReview().FindAllWithPagination(ctx, fieldID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	response := util.GeneratePagination(util.PaginationParam{
+		Count: total,
+		Page:  req.Page,
+		Limit: req.Limit,
+		Data:  toFieldReviewResponses(reviews),
+	})
+	return &response, nil
+}
+
+// Create rates the booking of a schedule. Only the customer who paid for the
+// booking may rate it, once, and only while it has not been cancelled.
+func (s *FieldReviewService) Create(ctx context.Context, req *dto.FieldReviewRequest) (*dto.FieldReviewResponse, error) {
+	user := ctx.Value(constants.User).(*clientUser.UserData)
+
+	schedule, err := s.repository.GetFieldSchedule().FindByUUID(ctx, req.FieldScheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	booking, err := s.repository.GetFieldBooking().FindActiveByFieldScheduleID(ctx, schedule.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if booking == nil || booking.UserID == nil || *booking.UserID != user.UUID {
+		return nil, errFieldReview.ErrFieldReviewNotAllowed
+	}
+
+	_, endAt := schedule.Time.RangeOn(schedule.Date)
+	if endAt.After(time.Now()) {
+		return nil, errFieldReview.ErrFieldReviewTooEarly
+	}
+
+	exists, err := s.repository.GetFieldReview().ExistsByFieldBookingID(ctx, booking.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if exists {
+		return nil, errFieldReview.ErrFieldReviewExists
+	}
+
+	review := models.FieldReview{
+		FieldID:        schedule.FieldID,
+		FieldBookingID: booking.ID,
+		UserID:         user.UUID,
+		UserName:       user.Name,
+		Rating:         req.Rating,
+		Comment:        req.Comment,
+	}
+	err = s.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		txErr := s.repository.GetFieldReview().Create(ctx, tx, &review)
+		if txErr != nil {
+			return txErr
+		}
+
+		return s.repository.GetField().AdjustRating(ctx, tx, review.FieldID, 1, review.Rating)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	review.Field = schedule.Field
+	response := toFieldReviewResponse(&review)
+	return &response, nil
+}
+
+// Moderate hides or shows a review again. Hidden reviews do not count towards
+// the field's rating.
+func (s *FieldReviewService) Moderate(ctx context.Context, uuid string, req *dto.ModerateFieldReviewRequest) (*dto.FieldReviewResponse, error) {
+	err := s.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		review, txErr := s.repository.GetFieldReview().FindByUUIDForUpdate(ctx, tx, uuid)
+		if txErr != nil {
+			return txErr
+		}
+
+		if review.IsHidden() == *req.Hidden {
+			if !review.IsHidden() {
+				return nil
+			}
+			review.HiddenReason = req.Reason
+			return s.repository.GetFieldReview().Update(ctx, tx, review)
+		}
+
+		count, sum := 1, review.Rating
+		if *req.Hidden {
+			now := time.Now()
+			review.HiddenAt = &now
+			review.HiddenReason = req.Reason
+			count, sum = -1, -review.Rating
+		} else {
+			review.HiddenAt = nil
+			review.HiddenReason = ""
+		}
+
+		txErr = s.repository.GetFieldReview().Update(ctx, tx, review)
+		if txErr != nil {
+			return txErr
+		}
+
+		return s.repository.GetField().AdjustRating(ctx, tx, review.FieldID, count, sum)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	review, err := s.repository.GetFieldReview().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toFieldReviewResponse(review)
+	return &response, nil
+}
+
+func (s *FieldReviewService) Delete(ctx context.Context, uuid string) error {
+	return s.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		review, err := s.repository.GetFieldReview().FindByUUIDForUpdate(ctx, tx, uuid)
+		if err != nil {
+			return err
+		}
+
+		err = s.repository.GetFieldReview().Delete(ctx, tx, review.ID)
+		if err != nil {
+			return err
+		}
+
+		if review.IsHidden() {
+			return nil
+		}
+
+		return s.repository.GetField().AdjustRating(ctx, tx, review.FieldID, -1, -review.Rating)
+	})
+}
+
+func toFieldReviewResponses(reviews []models.FieldReview) []dto.FieldReviewResponse {
+	response := make([]dto.FieldReviewResponse, 0, len(reviews))
+	for i := range reviews {
+		response = append(response, toFieldReviewResponse(&reviews[i]))
+	}
+	return response
+}
+
+func toFieldReviewResponse(review *models.FieldReview) dto.FieldReviewResponse {
+	return dto.FieldReviewResponse{
+		UUID:         review.UUID,
+		FieldID:      review.Field.UUID,
+		FieldName:    review.Field.Name,
+		UserName:     review.UserName,
+		Rating:       review.Rating,
+		Comment:      review.Comment,
+		Hidden:       review.IsHidden(),
+		HiddenReason: review.HiddenReason,
+		CreatedAt:    review.CreatedAt,
+		UpdatedAt:    review.UpdatedAt,
+	}
+}
@@ -2,6 +2,8 @@ package services
 
 import (
 	"context"
+	"encoding/json"
+	"field-service/common/broker"
 	"field-service/common/util"
 	"field-service/constants"
 	errFieldSchedule "field-service/constants/error/field_schedule"
@@ -12,25 +14,42 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	oneMonthDays        = 30
+	maxGenerateDays     = 366
+	maxAvailabilityDays = 62
 )
 
 type FieldScheduleService struct {
 	repository repositories.IRepositoryRegistry
+	broker     broker.IBroker
 }
 
 type IFieldScheduleService interface {
 	GetAllWithPagination(context.Context, *dto.FieldScheduleRequestParam) (*util.PaginationResult, error)
 	GetAllFieldIdAndDate(context.Context, string, string) ([]dto.FieldScheduleForBookResponse, error)
 	GetByUUID(context.Context, string) (*dto.FieldScheduleResponse, error)
-	GenerateScheduleForOneMonth(context.Context, *dto.GenerateFieldScheduleOneMonthRequest) error
+	GetAvailability(context.Context, *dto.FieldAvailabilityRequestParam) (*dto.FieldAvailabilityResponse, error)
+	GenerateScheduleForOneMonth(context.Context, *dto.GenerateFieldScheduleOneMonthRequest) (*dto.GenerateFieldScheduleResponse, error)
+	Generate(context.Context, *dto.GenerateFieldScheduleRequest) (*dto.GenerateFieldScheduleResponse, error)
+	GenerateForField(context.Context, *models.Field, time.Time, time.Time) (*dto.GenerateFieldScheduleResponse, error)
 	Create(context.Context, *dto.FieldScheduleRequest) error
 	Update(context.Context, string, *dto.UpdateFieldScheduleRequest) (*dto.FieldScheduleResponse, error)
 	UpdateStatus(context.Context, *dto.UpdatStatuseFieldScheduleRequest) error
+	Reschedule(context.Context, *dto.RescheduleFieldScheduleRequest) error
+	Release(context.Context, *dto.ReleaseFieldScheduleRequest) error
+	Announce(context.Context, *dto.AnnounceFieldScheduleRequest) error
+	UnblockLinked(context.Context, *gorm.DB, *models.FieldSchedule) ([]models.FieldSchedule, error)
+	GetByUUIDs(context.Context, *dto.FieldScheduleLookupRequest) ([]dto.FieldScheduleDetailResponse, error)
+	Subscribe(context.Context, string, *dto.FieldScheduleStreamRequestParam) (*broker.Subscription, error)
 	Delete(context.Context, string) error
 }
 
-func NewFieldScheduleService(repository repositories.IRepositoryRegistry) IFieldScheduleService {
-	return &FieldScheduleService{repository: repository}
+func NewFieldScheduleService(repository repositories.IRepositoryRegistry, broker broker.IBroker) IFieldScheduleService {
+	return &FieldScheduleService{repository: repository, broker: broker}
 }
 
 func (s *FieldScheduleService) GetAllWithPagination(ctx context.Context, req *dto.FieldScheduleRequestParam) (*util.PaginationResult, error) {
@@ -45,6 +64,7 @@ func (s *FieldScheduleService) GetAllWithPagination(ctx context.Context, req *dt
 			UUID:         FieldSchedule.UUID,
 			FieldName:    FieldSchedule.Field.Name,
 			PricePerHour: FieldSchedule.Field.PricePerHour,
+			Price:        FieldSchedule.Time.Price(FieldSchedule.Field.PricePerHour),
 			Date:         FieldSchedule.Date.Format("2006-01-02"),
 			Status:       FieldSchedule.Status.GetStatusString(),
 			Time:         fmt.Sprintf("%s - %s", FieldSchedule.Time.StartTime, FieldSchedule.Time.EndTime),
@@ -106,9 +126,11 @@ func (s *FieldScheduleService) GetAllFieldIdAndDate(ctx context.Context, uuid st
 	fieldSchedulesResult := make([]dto.FieldScheduleForBookResponse, 0, len(fieldSchedules))
 	for _, v := range fieldSchedules {
 		priceperHour := float64(v.Field.PricePerHour)
+		price := float64(v.Time.Price(v.Field.PricePerHour))
 		fieldSchedulesResult = append(fieldSchedulesResult, dto.FieldScheduleForBookResponse{
 			UUID:         v.UUID,
 			PricePerHour: util.RupiahFormat(&priceperHour),
+			Price:        util.RupiahFormat(&price),
 			Date:         s.converOneMonthName(v.Date.Format(time.DateOnly)),
 			Status:       v.Status.GetStatusString(),
 			Time:         fmt.Sprintf("%s - %s", v.Time.StartTime, v.Time.EndTime),
@@ -127,7 +149,12 @@ func (s *FieldScheduleService) GetByUUID(ctx context.Context, uuid string) (*dto
 	FieldSchedulesResult := new(dto.FieldScheduleResponse)
 	FieldSchedulesResult.UUID = FieldSchedule.UUID
 	FieldSchedulesResult.FieldName = FieldSchedule.Field.Name
+	if FieldSchedule.Field.Venue != nil {
+		FieldSchedulesResult.VenueID = &FieldSchedule.Field.Venue.UUID
+		FieldSchedulesResult.DepositPercentage = FieldSchedule.Field.Venue.DepositPercentage
+	}
 	FieldSchedulesResult.PricePerHour = FieldSchedule.Field.PricePerHour
+	FieldSchedulesResult.Price = FieldSchedule.Time.Price(FieldSchedule.Field.PricePerHour)
 	FieldSchedulesResult.Date = s.converOneMonthName(FieldSchedule.Date.Format(time.DateOnly))
 	FieldSchedulesResult.Status = FieldSchedule.Status.GetStatusString()
 	FieldSchedulesResult.CreatedAt = FieldSchedule.CreatedAt
@@ -138,6 +165,37 @@ func (s *FieldScheduleService) GetByUUID(ctx context.Context, uuid string) (*dto
 
 }
 
+// GetByUUIDs looks up many schedules at once for other services. Unknown IDs
+// are left out of the result.
+func (s *FieldScheduleService) GetByUUIDs(ctx context.Context, req *dto.FieldScheduleLookupRequest) ([]dto.FieldScheduleDetailResponse, error) {
+	fieldSchedules, err := s.repository.GetFieldSchedule().FindByUUIDs(ctx, req.FieldScheduleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.FieldScheduleDetailResponse, 0, len(fieldSchedules))
+	for _, fieldSchedule := range fieldSchedules {
+		location := ""
+		if fieldSchedule.Field.Venue != nil {
+			location = fieldSchedule.Field.Venue.Location()
+		}
+
+		startAt, endAt := fieldSchedule.Time.RangeOn(fieldSchedule.Date)
+		response = append(response, dto.FieldScheduleDetailResponse{
+			UUID:      fieldSchedule.UUID,
+			FieldUUID: fieldSchedule.Field.UUID,
+			FieldName: fieldSchedule.Field.Name,
+			Location:  location,
+			Date:      fieldSchedule.Date.Format(time.DateOnly),
+			StartAt:   startAt,
+			EndAt:     endAt,
+			Status:    fieldSchedule.Status.GetStatusString(),
+		})
+	}
+
+	return response, nil
+}
+
 func (s *FieldScheduleService) Create(ctx context.Context, req *dto.FieldScheduleRequest) error {
 	Field, err := s.repository.GetField().FindByUUID(ctx, req.FieldID)
 	if err != nil {
@@ -179,49 +237,315 @@ func (s *FieldScheduleService) Create(ctx context.Context, req *dto.FieldSchedul
 
 }
 
-func (s *FieldScheduleService) GenerateScheduleForOneMonth(ctx context.Context, req *dto.GenerateFieldScheduleOneMonthRequest) error {
+func (s *FieldScheduleService) GenerateScheduleForOneMonth(ctx context.Context, req *dto.GenerateFieldScheduleOneMonthRequest) (*dto.GenerateFieldScheduleResponse, error) {
 	Field, err := s.repository.GetField().FindByUUID(ctx, req.FieldID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	times, err := s.repository.GetTime().FindAll(ctx)
+	startDate := today().AddDate(0, 0, 1)
+	endDate := startDate.AddDate(0, 0, oneMonthDays-1)
+	return s.GenerateForField(ctx, Field, startDate, endDate)
+}
+
+func (s *FieldScheduleService) Generate(ctx context.Context, req *dto.GenerateFieldScheduleRequest) (*dto.GenerateFieldScheduleResponse, error) {
+	startDate, err := time.Parse(time.DateOnly, req.StartDate)
 	if err != nil {
-		return err
+		return nil, errFieldSchedule.ErrInvalidDateRange
 	}
 
-	numberOfDays := 30
-	fieldSchedules := make([]models.FieldSchedule, 0, numberOfDays)
-	now := time.Now().Add(time.Duration(1) * 24 * time.Hour)
-	for i := 0; i < numberOfDays; i++ {
-		currentDate := now.AddDate(0, 0, i)
-		for _, v := range times {
-			schedule, err := s.repository.GetFieldSchedule().FindByDateAndTimeId(ctx, currentDate.Format(time.DateOnly), int(v.ID), int(Field.ID))
-			if err != nil {
-				return err
+	endDate, err := time.Parse(time.DateOnly, req.EndDate)
+	if err != nil {
+		return nil, errFieldSchedule.ErrInvalidDateRange
+	}
+
+	if endDate.Before(startDate) {
+		return nil, errFieldSchedule.ErrInvalidDateRange
+	}
+
+	if startDate.Before(today()) {
+		return nil, errFieldSchedule.ErrDateInThePast
+	}
+
+	if endDate.Sub(startDate) >= maxGenerateDays*24*time.Hour {
+		return nil, errFieldSchedule.ErrDateRangeTooLong
+	}
+
+	Field, err := s.repository.GetField().FindByUUID(ctx, req.FieldID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GenerateForField(ctx, Field, startDate, endDate)
+}
+
+// GetAvailability returns the per-day status counts and slots of one field, or
+// of every field when none is given, between the two dates inclusive.
+func (s *FieldScheduleService) GetAvailability(ctx context.Context, req *dto.FieldAvailabilityRequestParam) (*dto.FieldAvailabilityResponse, error) {
+	startDate, err := time.Parse(time.DateOnly, req.StartDate)
+	if err != nil {
+		return nil, errFieldSchedule.ErrInvalidDateRange
+	}
+
+	endDate, err := time.Parse(time.DateOnly, req.EndDate)
+	if err != nil {
+		return nil, errFieldSchedule.ErrInvalidDateRange
+	}
+
+	if endDate.Before(startDate) {
+		return nil, errFieldSchedule.ErrInvalidDateRange
+	}
+
+	if endDate.Sub(startDate) >= maxAvailabilityDays*24*time.Hour {
+		return nil, errFieldSchedule.ErrDateRangeTooLong
+	}
+
+	var fieldID *uint
+	if req.FieldID != "" {
+		field, err := s.repository.GetField().FindByUUID(ctx, req.FieldID)
+		if err != nil {
+			return nil, err
+		}
+		fieldID = &field.ID
+	}
+
+	rows, err := s.repository.GetFieldSchedule().FindAvailability(ctx, fieldID, req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.FieldAvailabilityResponse{
+		StartDate: req.StartDate,
+		EndDate:   req.EndDate,
+		Fields:    make([]dto.FieldAvailability, 0),
+	}
+
+	for _, row := range rows {
+		var slots []struct {
+			UUID      uuid.UUID                     `json:"uuid"`
+			StartTime string                        `json:"startTime"`
+			EndTime   string                        `json:"endTime"`
+			Status    constants.FieldScheduleStatus `json:"status"`
+		}
+		err = json.Unmarshal([]byte(row.Slots), &slots)
+		if err != nil {
+			return nil, err
+		}
+
+		day := dto.DayAvailability{
+			Date:      row.Date.Format(time.DateOnly),
+			Available: row.Available,
+			Booked:    row.Booked,
+			Blocked:   row.Blocked,
+			Slots:     make([]dto.SlotAvailability, 0, len(slots)),
+		}
+		for _, slot := range slots {
+			day.Slots = append(day.Slots, dto.SlotAvailability{
+				UUID:      slot.UUID,
+				StartTime: slot.StartTime,
+				EndTime:   slot.EndTime,
+				Status:    slot.Status.GetStatusString(),
+			})
+		}
+
+		// Rows come ordered by field, so a new field starts a new entry.
+		last := len(response.Fields) - 1
+		if last < 0 || response.Fields[last].FieldID != row.FieldUUID {
+			response.Fields = append(response.Fields, dto.FieldAvailability{
+				FieldID:   row.FieldUUID,
+				FieldName: row.FieldName,
+				Days:      make([]dto.DayAvailability, 0),
+			})
+			last++
+		}
+		response.Fields[last].Days = append(response.Fields[last].Days, day)
+	}
+
+	return response, nil
+}
+
+// GenerateForField creates the slots of the field's weekly template for every
+// date between startDate and endDate inclusive. Fields without a template
+// open every slot of their slot set on every day. Slots that already exist or fall inside a
+// blackout are reported as skipped, so running it again over the same range is
+// harmless. Slots inserted by a concurrent run in the meantime are skipped by
+// the unique index and reported as skipped too.
+func (s *FieldScheduleService) GenerateForField(ctx context.Context, field *models.Field, startDate, endDate time.Time) (*dto.GenerateFieldScheduleResponse, error) {
+	slots, err := s.weeklySlots(ctx, field)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repository.GetFieldSchedule().FindByFieldIDAndDateRange(ctx, field.ID, startDate.Format(time.DateOnly), endDate.Format(time.DateOnly))
+	if err != nil {
+		return nil, err
+	}
+
+	blackouts, err := s.repository.GetFieldBlackout().FindOverlapping(ctx, field.ID, startDate.AddDate(0, 0, -1), endDate.AddDate(0, 0, 2))
+	if err != nil {
+		return nil, err
+	}
+
+	linkedBookings, err := s.linkedBookings(ctx, field, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.GenerateFieldScheduleResponse{
+		FieldID:   field.UUID,
+		StartDate: startDate.Format(time.DateOnly),
+		EndDate:   endDate.Format(time.DateOnly),
+		Created:   make([]dto.GeneratedFieldScheduleSlot, 0),
+		Skipped:   make([]dto.GeneratedFieldScheduleSlot, 0),
+	}
+
+	fieldSchedules := make([]models.FieldSchedule, 0)
+	createdSlots := make([]dto.GeneratedFieldScheduleSlot, 0)
+	for currentDate := startDate; !currentDate.After(endDate); currentDate = currentDate.AddDate(0, 0, 1) {
+		date := currentDate.Format(time.DateOnly)
+		for _, v := range slots[currentDate.Weekday()] {
+			slot := dto.GeneratedFieldScheduleSlot{
+				Date: date,
+				Time: fmt.Sprintf("%s - %s", v.StartTime, v.EndTime),
 			}
 
-			if schedule != nil {
-				return errFieldSchedule.ErrFieldScheduleExists
+			// A slot of another slot set, such as the shared hourly slots a
+			// field used before it got its own, blocks any new slot it overlaps.
+			if overlapsSchedule(existing, &v, currentDate) || inBlackout(blackouts, &v, currentDate) {
+				response.Skipped = append(response.Skipped, slot)
+				continue
+			}
+
+			status := constants.Available
+			if overlapsSchedule(linkedBookings, &v, currentDate) {
+				status = constants.Blocked
 			}
 
 			fieldSchedules = append(fieldSchedules, models.FieldSchedule{
 				UUID:    uuid.New(),
-				FieldID: Field.ID,
+				FieldID: field.ID,
 				TimeID:  v.ID,
 				Date:    currentDate,
-				Status:  constants.Available,
+				Status:  status,
 			})
+			createdSlots = append(createdSlots, slot)
+		}
+	}
 
+	if len(fieldSchedules) > 0 {
+		err = s.repository.GetFieldSchedule().Create(ctx, fieldSchedules)
+		if err != nil {
+			return nil, err
+		}
+
+		stored, err := s.repository.GetFieldSchedule().FindByFieldIDAndDateRange(ctx, field.ID, startDate.Format(time.DateOnly), endDate.Format(time.DateOnly))
+		if err != nil {
+			return nil, err
+		}
+
+		inserted := make(map[uuid.UUID]bool, len(stored))
+		for _, schedule := range stored {
+			inserted[schedule.UUID] = true
+		}
+
+		for i := range fieldSchedules {
+			if inserted[fieldSchedules[i].UUID] {
+				response.Created = append(response.Created, createdSlots[i])
+			} else {
+				response.Skipped = append(response.Skipped, createdSlots[i])
+			}
 		}
 	}
 
-	err = s.repository.GetFieldSchedule().Create(ctx, fieldSchedules)
+	response.CreatedCount = len(response.Created)
+	response.SkippedCount = len(response.Skipped)
+	return response, nil
+}
+
+func (s *FieldScheduleService) weeklySlots(ctx context.Context, field *models.Field) (map[time.Weekday][]models.Time, error) {
+	slots := make(map[time.Weekday][]models.Time)
+
+	if field.HasScheduleTemplate {
+		templates, err := s.repository.GetScheduleTemplate().FindByFieldID(ctx, field.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, template := range templates {
+			weekday := time.Weekday(template.Weekday)
+			slots[weekday] = append(slots[weekday], template.Time)
+		}
+		return slots, nil
+	}
+
+	times, err := s.repository.GetTime().FindByFieldID(ctx, field.ID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	if len(times) == 0 {
+		times, err = s.repository.GetTime().FindAll(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		slots[weekday] = times
+	}
+
+	return slots, nil
+}
+
+func inBlackout(blackouts []models.FieldBlackout, slot *models.Time, date time.Time) bool {
+	start, end := slot.RangeOn(date)
+	for i := range blackouts {
+		if blackouts[i].Overlaps(start, end) {
+			return true
+		}
+	}
+	return false
+}
+
+// linkedBookings returns the booked schedules of the linked full pitch or
+// halves between startDate and endDate.
+func (s *FieldScheduleService) linkedBookings(ctx context.Context, field *models.Field, startDate, endDate time.Time) ([]models.FieldSchedule, error) {
+	linked, err := s.linkedFieldIDs(ctx, field)
+	if err != nil {
+		return nil, err
+	}
+
+	bookings := make([]models.FieldSchedule, 0)
+	for _, id := range linked {
+		schedules, err := s.repository.GetFieldSchedule().FindByFieldIDAndDateRange(ctx, id, startDate.Format(time.DateOnly), endDate.Format(time.DateOnly))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, schedule := range schedules {
+			if schedule.Status == constants.Booked {
+				bookings = append(bookings, schedule)
+			}
+		}
+	}
+
+	return bookings, nil
+}
+
+// overlapsSchedule reports whether the slot shares any minute with one of the
+// schedules on the same date.
+func overlapsSchedule(schedules []models.FieldSchedule, slot *models.Time, date time.Time) bool {
+	for i := range schedules {
+		if schedules[i].Date.Format(time.DateOnly) == date.Format(time.DateOnly) && schedules[i].Time.Overlaps(slot) {
+			return true
+		}
+	}
+	return false
+}
+
+func today() time.Time {
+	date, _ := time.Parse(time.DateOnly, time.Now().Format(time.DateOnly))
+	return date
 }
 
 func (s *FieldScheduleService) Update(ctx context.Context, uuid string, req *dto.UpdateFieldScheduleRequest) (*dto.FieldScheduleResponse, error) {
@@ -266,6 +590,7 @@ func (s *FieldScheduleService) Update(ctx context.Context, uuid string, req *dto
 		FieldName:    fieldRes.Field.Name,
 		Date:         fieldRes.Date.Format(time.DateOnly),
 		PricePerHour: fieldRes.Field.PricePerHour,
+		Price:        scheduleTime.Price(fieldRes.Field.PricePerHour),
 		Status:       FieldSchedule.Status.GetStatusString(),
 		Time:         fmt.Sprintf("%s - %s", scheduleTime.StartTime, scheduleTime.EndTime),
 		CreatedAt:    fieldRes.CreatedAt,
@@ -275,21 +600,149 @@ func (s *FieldScheduleService) Update(ctx context.Context, uuid string, req *dto
 	return &response, nil
 }
 
+// UpdateStatus books the schedules in one transaction. Booking a slot blocks
+// the overlapping slots of the linked full pitch or halves, and fails when one
+// of them is already booked.
 func (s *FieldScheduleService) UpdateStatus(ctx context.Context, req *dto.UpdatStatuseFieldScheduleRequest) error {
+	booking := models.FieldBooking{OrderCode: req.OrderCode}
+	if userID, err := uuid.Parse(req.UserID); err == nil {
+		booking.UserID = &userID
+	}
 
-	for _, item := range req.FiledSchedulesIDs {
-		_, err := s.repository.GetFieldSchedule().FindByUUID(ctx, item)
-		if err != nil {
-			return err
+	changed := make([]models.FieldSchedule, 0, len(req.FiledSchedulesIDs))
+	err := s.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		for _, item := range req.FiledSchedulesIDs {
+			schedules, err := s.book(ctx, tx, item, booking)
+			if err != nil {
+				return err
+			}
+			changed = append(changed, schedules...)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.publish(changed)
+	return nil
+}
+
+// book records booking against the schedule and returns the schedules it
+// changed, with their new status.
+func (s *FieldScheduleService) book(ctx context.Context, tx *gorm.DB, uuid string, booking models.FieldBooking) ([]models.FieldSchedule, error) {
+	fieldSchedule, err := s.repository.GetFieldSchedule().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	linked, err := s.linkedFieldIDs(ctx, &fieldSchedule.Field)
+	if err != nil {
+		return nil, err
+	}
+
+	// The schedule itself is locked together with the linked ones so every
+	// status below is read after the lock is held.
+	schedules, err := s.repository.GetFieldSchedule().FindOverlappingForUpdate(ctx, tx, append(linked, fieldSchedule.FieldID), fieldSchedule.Date.Format(time.DateOnly), fieldSchedule.Time.StartTime, fieldSchedule.Time.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	found := false
+	blocked := make([]uint, 0, len(schedules))
+	changed := make([]models.FieldSchedule, 0, len(schedules))
+	for _, schedule := range schedules {
+		if schedule.ID == fieldSchedule.ID {
+			found = true
+			switch schedule.Status {
+			case constants.Unavailable:
+				return nil, errFieldSchedule.ErrFieldScheduleClosed
+			case constants.Blocked:
+				return nil, errFieldSchedule.ErrFieldScheduleBlocked
+			case constants.Booked:
+				held, err := s.heldBy(ctx, schedule.ID, booking.OrderCode)
+				if err != nil {
+					return nil, err
+				}
+
+				// A repeated request of the order already holding the
+				// schedule changes nothing.
+				if held {
+					return nil, nil
+				}
+				return nil, errFieldSchedule.ErrFieldScheduleBooked
+			}
+			continue
 		}
 
-		err = s.repository.GetFieldSchedule().UpdateStatus(ctx, constants.Booked, item)
-		if err != nil {
-			return err
+		if schedule.FieldID == fieldSchedule.FieldID {
+			continue
+		}
+
+		switch schedule.Status {
+		case constants.Booked:
+			return nil, errFieldSchedule.ErrFieldScheduleBlocked
+		case constants.Available:
+			blocked = append(blocked, schedule.ID)
+			schedule.Status = constants.Blocked
+			changed = append(changed, schedule)
 		}
 	}
 
-	return nil
+	if !found {
+		return nil, errFieldSchedule.ErrFieldScheduleNotFound
+	}
+
+	err = s.repository.GetFieldSchedule().UpdateStatusByIDs(ctx, tx, constants.Booked, []uint{fieldSchedule.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	booking.FieldID = fieldSchedule.FieldID
+	booking.FieldScheduleID = fieldSchedule.ID
+	err = s.repository.GetFieldBooking().Create(ctx, tx, &booking)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.repository.GetFieldSchedule().UpdateStatusByIDs(ctx, tx, constants.Blocked, blocked)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldSchedule.Status = constants.Booked
+	return append(changed, *fieldSchedule), nil
+}
+
+// heldBy reports whether the active booking of a schedule belongs to the
+// order.
+func (s *FieldScheduleService) heldBy(ctx context.Context, fieldScheduleID uint, orderCode string) (bool, error) {
+	booking, err := s.repository.GetFieldBooking().FindActiveByFieldScheduleID(ctx, fieldScheduleID)
+	if err != nil {
+		return false, err
+	}
+
+	return booking != nil && orderCode != "" && booking.OrderCode == orderCode, nil
+}
+
+// linkedFieldIDs returns the full pitch of a half, or the halves of a full
+// pitch.
+func (s *FieldScheduleService) linkedFieldIDs(ctx context.Context, field *models.Field) ([]uint, error) {
+	if field.ParentID != nil {
+		return []uint{*field.ParentID}, nil
+	}
+
+	children, err := s.repository.GetField().FindChildren(ctx, field.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint, 0, len(children))
+	for _, child := range children {
+		ids = append(ids, child.ID)
+	}
+
+	return ids, nil
 }
 
 func (s *FieldScheduleService) Delete(ctx context.Context, uuid string) error {
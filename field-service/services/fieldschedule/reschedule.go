@@ -0,0 +1,259 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"field-service/constants"
+	errFieldSchedule "field-service/constants/error/field_schedule"
+	"field-service/domain/dto"
+	"field-service/domain/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Reschedule moves the bookings of an order to other schedules in one
+// transaction. The old schedules are released first, so a new slot may take
+// the place of one the order blocked itself. Every new schedule must still be
+// available once locked.
+func (s *FieldScheduleService) Reschedule(ctx context.Context, req *dto.RescheduleFieldScheduleRequest) error {
+	booking := models.FieldBooking{OrderCode: req.OrderCode}
+	if userID, err := uuid.Parse(req.UserID); err == nil {
+		booking.UserID = &userID
+	}
+
+	changed := make([]models.FieldSchedule, 0, len(req.FromFieldScheduleIDs)+len(req.ToFieldScheduleIDs))
+	err := s.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		for _, item := range req.FromFieldScheduleIDs {
+			schedules, err := s.release(ctx, tx, item, req.OrderCode)
+			if err != nil {
+				return err
+			}
+			changed = append(changed, schedules...)
+		}
+
+		for _, item := range req.ToFieldScheduleIDs {
+			err := s.lockAvailable(ctx, tx, item)
+			if err != nil {
+				return err
+			}
+
+			schedules, err := s.book(ctx, tx, item, booking)
+			if err != nil {
+				return err
+			}
+			changed = append(changed, schedules...)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.publish(changed)
+	return nil
+}
+
+// Release frees the schedules a cancelled order still holds. Schedules the
+// order no longer holds, such as those a blackout already closed, are skipped.
+func (s *FieldScheduleService) Release(ctx context.Context, req *dto.ReleaseFieldScheduleRequest) error {
+	changed := make([]models.FieldSchedule, 0, len(req.FieldScheduleIDs))
+	err := s.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		for _, item := range req.FieldScheduleIDs {
+			schedules, err := s.release(ctx, tx, item, req.OrderCode)
+			if errors.Is(err, errFieldSchedule.ErrFieldScheduleNotBooked) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			changed = append(changed, schedules...)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.publish(changed)
+	return nil
+}
+
+// release makes a schedule booked by orderCode available again, cancels its
+// booking and unblocks the linked schedules no other booking holds. It
+// returns the schedules it changed, with their new status.
+func (s *FieldScheduleService) release(ctx context.Context, tx *gorm.DB, uuid, orderCode string) ([]models.FieldSchedule, error) {
+	fieldSchedule, err := s.repository.GetFieldSchedule().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	linked, err := s.linkedFieldIDs(ctx, &fieldSchedule.Field)
+	if err != nil {
+		return nil, err
+	}
+
+	schedules, err := s.repository.GetFieldSchedule().FindOverlappingForUpdate(ctx, tx, append(linked, fieldSchedule.FieldID), fieldSchedule.Date.Format(time.DateOnly), fieldSchedule.Time.StartTime, fieldSchedule.Time.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	booking, err := s.repository.GetFieldBooking().FindActiveByFieldScheduleID(ctx, fieldSchedule.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if booking == nil || booking.OrderCode != orderCode {
+		return nil, errFieldSchedule.ErrFieldScheduleNotBooked
+	}
+
+	found := false
+	for _, schedule := range schedules {
+		if schedule.ID == fieldSchedule.ID {
+			found = true
+			if schedule.Status != constants.Booked {
+				return nil, errFieldSchedule.ErrFieldScheduleNotBooked
+			}
+		}
+	}
+
+	if !found {
+		return nil, errFieldSchedule.ErrFieldScheduleNotFound
+	}
+
+	changed, err := s.unblockLinked(ctx, tx, fieldSchedule, schedules)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.repository.GetFieldSchedule().UpdateStatusByIDs(ctx, tx, constants.Available, []uint{fieldSchedule.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.repository.GetFieldBooking().CancelByFieldScheduleIDs(ctx, tx, []uint{fieldSchedule.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	fieldSchedule.Status = constants.Available
+	return append(changed, *fieldSchedule), nil
+}
+
+// UnblockLinked unblocks the schedules of the linked fields that only the
+// booking of fieldSchedule held, for a booking cancelled outside release. It
+// returns the schedules it changed, with their new status.
+func (s *FieldScheduleService) UnblockLinked(ctx context.Context, tx *gorm.DB, fieldSchedule *models.FieldSchedule) ([]models.FieldSchedule, error) {
+	linked, err := s.linkedFieldIDs(ctx, &fieldSchedule.Field)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(linked) == 0 {
+		return nil, nil
+	}
+
+	schedules, err := s.repository.GetFieldSchedule().FindOverlappingForUpdate(ctx, tx, linked, fieldSchedule.Date.Format(time.DateOnly), fieldSchedule.Time.StartTime, fieldSchedule.Time.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.unblockLinked(ctx, tx, fieldSchedule, schedules)
+}
+
+// unblockLinked gives every blocked schedule of a linked field among
+// schedules the status it takes once the booking of fieldSchedule is gone.
+func (s *FieldScheduleService) unblockLinked(ctx context.Context, tx *gorm.DB, fieldSchedule *models.FieldSchedule, schedules []models.FieldSchedule) ([]models.FieldSchedule, error) {
+	statuses := make(map[constants.FieldScheduleStatus][]uint)
+	changed := make([]models.FieldSchedule, 0, len(schedules))
+	for _, schedule := range schedules {
+		if schedule.FieldID == fieldSchedule.FieldID || schedule.Status != constants.Blocked {
+			continue
+		}
+
+		status, err := s.unblockedStatus(ctx, tx, schedule.UUID.String(), fieldSchedule.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		if status != constants.Blocked {
+			statuses[status] = append(statuses[status], schedule.ID)
+			schedule.Status = status
+			changed = append(changed, schedule)
+		}
+	}
+
+	for status, ids := range statuses {
+		err := s.repository.GetFieldSchedule().UpdateStatusByIDs(ctx, tx, status, ids)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return changed, nil
+}
+
+// unblockedStatus returns the status a blocked schedule takes once the
+// booking of releasedID is gone: still blocked when another booking of a
+// linked field overlaps it, closed when a blackout covers it, otherwise
+// available.
+func (s *FieldScheduleService) unblockedStatus(ctx context.Context, tx *gorm.DB, uuid string, releasedID uint) (constants.FieldScheduleStatus, error) {
+	fieldSchedule, err := s.repository.GetFieldSchedule().FindByUUID(ctx, uuid)
+	if err != nil {
+		return 0, err
+	}
+
+	linked, err := s.linkedFieldIDs(ctx, &fieldSchedule.Field)
+	if err != nil {
+		return 0, err
+	}
+
+	schedules, err := s.repository.GetFieldSchedule().FindOverlappingForUpdate(ctx, tx, linked, fieldSchedule.Date.Format(time.DateOnly), fieldSchedule.Time.StartTime, fieldSchedule.Time.EndTime)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, schedule := range schedules {
+		if schedule.ID != releasedID && schedule.Status == constants.Booked {
+			return constants.Blocked, nil
+		}
+	}
+
+	start, end := fieldSchedule.Time.RangeOn(fieldSchedule.Date)
+	blackouts, err := s.repository.GetFieldBlackout().FindOverlapping(ctx, fieldSchedule.FieldID, start, end)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(blackouts) > 0 {
+		return constants.Unavailable, nil
+	}
+
+	return constants.Available, nil
+}
+
+// lockAvailable locks a schedule and fails unless it is available. book
+// alone would book a schedule twice.
+func (s *FieldScheduleService) lockAvailable(ctx context.Context, tx *gorm.DB, uuid string) error {
+	fieldSchedule, err := s.repository.GetFieldSchedule().FindByUUID(ctx, uuid)
+	if err != nil {
+		return err
+	}
+
+	schedules, err := s.repository.GetFieldSchedule().FindOverlappingForUpdate(ctx, tx, []uint{fieldSchedule.FieldID}, fieldSchedule.Date.Format(time.DateOnly), fieldSchedule.Time.StartTime, fieldSchedule.Time.EndTime)
+	if err != nil {
+		return err
+	}
+
+	for _, schedule := range schedules {
+		if schedule.ID == fieldSchedule.ID {
+			if schedule.Status != constants.Available {
+				return errFieldSchedule.ErrFieldScheduleNotAvailable
+			}
+			return nil
+		}
+	}
+
+	return errFieldSchedule.ErrFieldScheduleNotFound
+}
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"field-service/common/broker"
+	"field-service/constants"
+	errFieldSchedule "field-service/constants/error/field_schedule"
+	"field-service/domain/dto"
+	"field-service/domain/models"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Subscribe streams the status changes of a field on one day, starting after
+// the last event the client has seen.
+func (s *FieldScheduleService) Subscribe(ctx context.Context, uuid string, req *dto.FieldScheduleStreamRequestParam) (*broker.Subscription, error) {
+	_, err := time.Parse(time.DateOnly, req.Date)
+	if err != nil {
+		return nil, errFieldSchedule.ErrInvalidDate
+	}
+
+	field, err := s.repository.GetField().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.broker.Subscribe(models.ScheduleTopic(field.ID, req.Date), req.LastEventID), nil
+}
+
+// Announce tells the streams that an order awaiting payment reserved
+// schedules, or released them when it expired. Only available schedules are
+// announced; a booked one has already announced its booking.
+func (s *FieldScheduleService) Announce(ctx context.Context, req *dto.AnnounceFieldScheduleRequest) error {
+	schedules, err := s.repository.GetFieldSchedule().FindByUUIDs(ctx, req.FieldScheduleIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, schedule := range schedules {
+		if schedule.Status == constants.Available {
+			s.publishEvent(schedule, req.Event)
+		}
+	}
+
+	return nil
+}
+
+// publish announces status changes. It must run after the transaction that
+// made them has committed.
+func (s *FieldScheduleService) publish(schedules []models.FieldSchedule) {
+	for _, schedule := range schedules {
+		s.publishEvent(schedule, schedule.Status.GetEventName())
+	}
+}
+
+func (s *FieldScheduleService) publishEvent(schedule models.FieldSchedule, name string) {
+	data, err := json.Marshal(dto.FieldScheduleStatusEvent{
+		UUID:   schedule.UUID,
+		Date:   schedule.Date.Format(time.DateOnly),
+		Status: schedule.Status.GetStatusString(),
+	})
+	if err != nil {
+		logrus.Errorf("failed to encode schedule event: %v", err)
+		return
+	}
+
+	s.broker.Publish(schedule.Topic(), name, data)
+}
@@ -1,36 +1,76 @@
 package services
 
 import (
-	"field-service/common/gcs"
+	"common/storage"
+	"field-service/common/broker"
+	"field-service/controllers/kafka"
 	"field-service/repositories"
+	servicesAddOn "field-service/services/addon"
+	servicesAmenity "field-service/services/amenity"
 	servicesField "field-service/services/field"
+	servicesFieldBlackout "field-service/services/fieldblackout"
+	servicesFieldReview "field-service/services/fieldreview"
 	servicesFieldSchedule "field-service/services/fieldschedule"
+	servicesScheduleTemplate "field-service/services/scheduletemplate"
 	servicesTime "field-service/services/time"
+	servicesVenue "field-service/services/venue"
 )
 
 type Registry struct {
 	repository repositories.IRepositoryRegistry
-	gcs        gcs.IGCSClient
+	storage    storage.IStorageClient
+	kafka      kafka.IKafkaRegistry
+	broker     broker.IBroker
 }
 
 type IServiceRegistry interface {
 	GetField() servicesField.IfieldService
 	GetFieldSchedule() servicesFieldSchedule.IFieldScheduleService
 	GetTime() servicesTime.ITimeService
+	GetScheduleTemplate() servicesScheduleTemplate.IScheduleTemplateService
+	GetFieldBlackout() servicesFieldBlackout.IFieldBlackoutService
+	GetVenue() servicesVenue.IVenueService
+	GetAmenity() servicesAmenity.IAmenityService
+	GetFieldReview() servicesFieldReview.IFieldReviewService
+	GetAddOn() servicesAddOn.IAddOnService
 }
 
-func NewServiceRegistry(repository repositories.IRepositoryRegistry, gcs gcs.IGCSClient) IServiceRegistry {
-	return &Registry{repository: repository, gcs: gcs}
+func NewServiceRegistry(repository repositories.IRepositoryRegistry, storage storage.IStorageClient, kafka kafka.IKafkaRegistry, broker broker.IBroker) IServiceRegistry {
+	return &Registry{repository: repository, storage: storage, kafka: kafka, broker: broker}
 }
 
 func (r *Registry) GetField() servicesField.IfieldService {
-	return servicesField.NewFieldService(r.repository, r.gcs)
+	return servicesField.NewFieldService(r.repository, r.storage)
 }
 
 func (r *Registry) GetFieldSchedule() servicesFieldSchedule.IFieldScheduleService {
-	return servicesFieldSchedule.NewFieldScheduleService(r.repository)
+	return servicesFieldSchedule.NewFieldScheduleService(r.repository, r.broker)
 }
 
 func (r *Registry) GetTime() servicesTime.ITimeService {
 	return servicesTime.NewTimeService(r.repository)
 }
+
+func (r *Registry) GetScheduleTemplate() servicesScheduleTemplate.IScheduleTemplateService {
+	return servicesScheduleTemplate.NewScheduleTemplateService(r.repository)
+}
+
+func (r *Registry) GetFieldBlackout() servicesFieldBlackout.IFieldBlackoutService {
+	return servicesFieldBlackout.NewFieldBlackoutService(r.repository, r.kafka, r.broker, r.GetFieldSchedule())
+}
+
+func (r *Registry) GetVenue() servicesVenue.IVenueService {
+	return servicesVenue.NewVenueService(r.repository, r.storage)
+}
+
+func (r *Registry) GetAmenity() servicesAmenity.IAmenityService {
+	return servicesAmenity.NewAmenityService(r.repository)
+}
+
+func (r *Registry) GetFieldReview() servicesFieldReview.IFieldReviewService {
+	return servicesFieldReview.NewFieldReviewService(r.repository)
+}
+
+func (r *Registry) GetAddOn() servicesAddOn.IAddOnService {
+	return servicesAddOn.NewAddOnService(r.repository)
+}
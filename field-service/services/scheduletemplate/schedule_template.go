@@ -0,0 +1,135 @@
+package services
+
+import (
+	"context"
+	errTime "field-service/constants/error/time"
+	"field-service/domain/dto"
+	"field-service/domain/models"
+	"field-service/repositories"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ScheduleTemplateService struct {
+	repository repositories.IRepositoryRegistry
+}
+
+type IScheduleTemplateService interface {
+	GetByFieldUUID(context.Context, string) (*dto.ScheduleTemplateResponse, error)
+	Update(context.Context, string, *dto.ScheduleTemplateRequest) (*dto.ScheduleTemplateResponse, error)
+}
+
+func NewScheduleTemplateService(repository repositories.IRepositoryRegistry) IScheduleTemplateService {
+	return &ScheduleTemplateService{repository: repository}
+}
+
+func (s *ScheduleTemplateService) GetByFieldUUID(ctx context.Context, fieldUUID string) (*dto.ScheduleTemplateResponse, error) {
+	field, err := s.repository.GetField().FindByUUID(ctx, fieldUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	templates, err := s.repository.GetScheduleTemplate().FindByFieldID(ctx, field.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toScheduleTemplateResponse(field, templates), nil
+}
+
+// Update replaces the whole weekly template of a field. Weekdays that are
+// left out are closed, so an empty template closes the field.
+func (s *ScheduleTemplateService) Update(ctx context.Context, fieldUUID string, req *dto.ScheduleTemplateRequest) (*dto.ScheduleTemplateResponse, error) {
+	field, err := s.repository.GetField().FindByUUID(ctx, fieldUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	enabled := req.Enabled == nil || *req.Enabled
+	if !enabled {
+		err = s.repository.GetScheduleTemplate().ReplaceByFieldID(ctx, field.ID, false, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		return s.GetByFieldUUID(ctx, fieldUUID)
+	}
+
+	seen := make(map[string]bool)
+	times := make(map[string]*models.Time)
+	templates := make([]models.ScheduleTemplate, 0)
+	for _, day := range req.Days {
+		dayTimes := make([]*models.Time, 0, len(day.TimeIDs))
+		for _, timeID := range day.TimeIDs {
+			scheduleTime, ok := times[timeID]
+			if !ok {
+				scheduleTime, err = s.repository.GetTime().FindByUUID(ctx, timeID)
+				if err != nil {
+					return nil, err
+				}
+				if scheduleTime.FieldID != nil && *scheduleTime.FieldID != field.ID {
+					return nil, errTime.ErrTimeNotFound
+				}
+				times[timeID] = scheduleTime
+			}
+
+			key := fmt.Sprintf("%d:%s", day.Weekday, timeID)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			for _, other := range dayTimes {
+				if other.Overlaps(scheduleTime) {
+					return nil, errTime.ErrTimeOverlap
+				}
+			}
+			dayTimes = append(dayTimes, scheduleTime)
+
+			templates = append(templates, models.ScheduleTemplate{
+				UUID:    uuid.New(),
+				FieldID: field.ID,
+				Weekday: day.Weekday,
+				TimeID:  scheduleTime.ID,
+			})
+		}
+	}
+
+	err = s.repository.GetScheduleTemplate().ReplaceByFieldID(ctx, field.ID, true, templates)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetByFieldUUID(ctx, fieldUUID)
+}
+
+func toScheduleTemplateResponse(field *models.Field, templates []models.ScheduleTemplate) *dto.ScheduleTemplateResponse {
+	days := make([]dto.ScheduleTemplateDayResponse, 0)
+	for _, template := range templates {
+		if len(days) == 0 || days[len(days)-1].Weekday != template.Weekday {
+			days = append(days, dto.ScheduleTemplateDayResponse{
+				Weekday:     template.Weekday,
+				WeekdayName: time.Weekday(template.Weekday).String(),
+				Times:       make([]dto.TimeResponse, 0),
+			})
+		}
+
+		day := &days[len(days)-1]
+		day.Times = append(day.Times, dto.TimeResponse{
+			UUID:           template.Time.UUID,
+			StartTime:      template.Time.StartTime.String(),
+			EndTime:        template.Time.EndTime.String(),
+			DurationMinute: template.Time.DurationMinute(),
+			CreatedAt:      template.Time.CreatedAt,
+			UpdateAt:       template.Time.UpdatedAt,
+		})
+	}
+
+	return &dto.ScheduleTemplateResponse{
+		FieldID: field.UUID,
+		Enabled: field.HasScheduleTemplate,
+		Days:    days,
+	}
+}
@@ -2,41 +2,62 @@ package services
 
 import (
 	"context"
+	"field-service/common/timeofday"
+	errTime "field-service/constants/error/time"
 	"field-service/domain/dto"
 	"field-service/domain/models"
 	"field-service/repositories"
 )
 
+// allowedSlotMinutes lists the rental lengths a time slot may have.
+var allowedSlotMinutes = map[int]bool{60: true, 90: true, 120: true}
+
 type TimeService struct {
 	repository repositories.IRepositoryRegistry
 }
 
 type ITimeService interface {
-	GetAll(context.Context) ([]dto.TimeResponse, error)
+	GetAll(context.Context, *dto.TimeRequestParam) ([]dto.TimeResponse, error)
 	GetByUUID(context.Context, string) (*dto.TimeResponse, error)
 	Create(context.Context, *dto.TimeRequest) (*dto.TimeResponse, error)
+	Update(context.Context, string, *dto.UpdateTimeRequest) (*dto.TimeResponse, error)
+	Delete(context.Context, string) error
 }
 
 func NewTimeService(repository repositories.IRepositoryRegistry) ITimeService {
 	return &TimeService{repository: repository}
 }
 
-func (s *TimeService) GetAll(ctx context.Context) ([]dto.TimeResponse, error) {
+// GetAll returns the shared time slots, or the slot set of a field when
+// fieldID is given. Fields without their own slots use the shared ones.
+func (s *TimeService) GetAll(ctx context.Context, param *dto.TimeRequestParam) ([]dto.TimeResponse, error) {
+	var (
+		times []models.Time
+		err   error
+	)
+
+	if param.FieldID != nil && *param.FieldID != "" {
+		field, err := s.repository.GetField().FindByUUID(ctx, *param.FieldID)
+		if err != nil {
+			return nil, err
+		}
+
+		times, err = s.repository.GetTime().FindByFieldID(ctx, field.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
 
-	times, err := s.repository.GetTime().FindAll(ctx)
-	if err != nil {
-		return nil, err
+	if len(times) == 0 {
+		times, err = s.repository.GetTime().FindAll(ctx)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	timeResults := make([]dto.TimeResponse, 0, len(times))
-	for _, time := range times {
-		timeResults = append(timeResults, dto.TimeResponse{
-			UUID:      time.UUID,
-			StartTime: time.StartTime,
-			EndTime:   time.EndTime,
-			CreatedAt: time.CreatedAt,
-			UpdateAt:  time.UpdatedAt,
-		})
+	for i := range times {
+		timeResults = append(timeResults, toTimeResponse(&times[i]))
 	}
 
 	return timeResults, nil
@@ -49,36 +70,162 @@ func (s *TimeService) GetByUUID(ctx context.Context, id string) (*dto.TimeRespon
 		return nil, err
 	}
 
-	timeResult := dto.TimeResponse{
-		UUID:      time.UUID,
-		StartTime: time.StartTime,
-		EndTime:   time.EndTime,
-		CreatedAt: time.CreatedAt,
-		UpdateAt:  time.UpdatedAt,
+	timeResult := toTimeResponse(time)
+	return &timeResult, nil
+}
+
+func (s *TimeService) Create(ctx context.Context, req *dto.TimeRequest) (*dto.TimeResponse, error) {
+	time, err := parseSlot(req.StartTime, req.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.FieldID != nil && *req.FieldID != "" {
+		field, err := s.repository.GetField().FindByUUID(ctx, *req.FieldID)
+		if err != nil {
+			return nil, err
+		}
+		time.FieldID = &field.ID
+		time.Field = field
+	}
+
+	err = s.checkOverlap(ctx, time)
+	if err != nil {
+		return nil, err
+	}
+
+	field := time.Field
+	time.Field = nil
+	time, err = s.repository.GetTime().Create(ctx, time)
+	if err != nil {
+		return nil, err
 	}
+	time.Field = field
 
+	timeResult := toTimeResponse(time)
 	return &timeResult, nil
 }
 
-func (s *TimeService) Create(ctx context.Context, req *dto.TimeRequest) (*dto.TimeResponse, error) {
+func (s *TimeService) Update(ctx context.Context, uuid string, req *dto.UpdateTimeRequest) (*dto.TimeResponse, error) {
+	time, err := s.repository.GetTime().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	slot, err := parseSlot(req.StartTime, req.EndTime)
+	if err != nil {
+		return nil, err
+	}
 
-	time := &models.Time{
-		StartTime: req.StartTime,
-		EndTime:   req.EndTime,
+	if slot.StartTime == time.StartTime && slot.EndTime == time.EndTime {
+		timeResult := toTimeResponse(time)
+		return &timeResult, nil
 	}
 
-	time, err := s.repository.GetTime().Create(ctx, time)
+	used, err := s.repository.GetTime().IsUsed(ctx, time.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	timeResult := dto.TimeResponse{
-		UUID:      time.UUID,
-		StartTime: time.StartTime,
-		EndTime:   time.EndTime,
-		CreatedAt: time.CreatedAt,
-		UpdateAt:  time.UpdatedAt,
+	if used {
+		return nil, errTime.ErrTimeInUse
+	}
+
+	time.StartTime = slot.StartTime
+	time.EndTime = slot.EndTime
+	err = s.checkOverlap(ctx, time)
+	if err != nil {
+		return nil, err
+	}
+
+	time, err = s.repository.GetTime().Update(ctx, time)
+	if err != nil {
+		return nil, err
 	}
 
+	timeResult := toTimeResponse(time)
 	return &timeResult, nil
 }
+
+func (s *TimeService) Delete(ctx context.Context, uuid string) error {
+	time, err := s.repository.GetTime().FindByUUID(ctx, uuid)
+	if err != nil {
+		return err
+	}
+
+	used, err := s.repository.GetTime().IsUsed(ctx, time.ID)
+	if err != nil {
+		return err
+	}
+
+	if used {
+		return errTime.ErrTimeInUse
+	}
+
+	return s.repository.GetTime().Delete(ctx, time)
+}
+
+// checkOverlap rejects a slot that overlaps another slot of the same set.
+func (s *TimeService) checkOverlap(ctx context.Context, time *models.Time) error {
+	var (
+		siblings []models.Time
+		err      error
+	)
+
+	if time.FieldID != nil {
+		siblings, err = s.repository.GetTime().FindByFieldID(ctx, *time.FieldID)
+	} else {
+		siblings, err = s.repository.GetTime().FindAll(ctx)
+	}
+	if err != nil {
+		return err
+	}
+
+	for i := range siblings {
+		if siblings[i].ID != time.ID && siblings[i].Overlaps(time) {
+			return errTime.ErrTimeOverlap
+		}
+	}
+
+	return nil
+}
+
+func parseSlot(startTime, endTime string) (*models.Time, error) {
+	start, err := timeofday.Parse(startTime)
+	if err != nil {
+		return nil, errTime.ErrInvalidTimeFormat
+	}
+
+	end, err := timeofday.Parse(endTime)
+	if err != nil {
+		return nil, errTime.ErrInvalidTimeFormat
+	}
+
+	if end <= start {
+		return nil, errTime.ErrInvalidTimeRange
+	}
+
+	time := &models.Time{StartTime: start, EndTime: end}
+	if !allowedSlotMinutes[time.DurationMinute()] {
+		return nil, errTime.ErrInvalidSlotDuration
+	}
+
+	return time, nil
+}
+
+func toTimeResponse(time *models.Time) dto.TimeResponse {
+	response := dto.TimeResponse{
+		UUID:           time.UUID,
+		StartTime:      time.StartTime.String(),
+		EndTime:        time.EndTime.String(),
+		DurationMinute: time.DurationMinute(),
+		CreatedAt:      time.CreatedAt,
+		UpdateAt:       time.UpdatedAt,
+	}
+
+	if time.Field != nil {
+		response.FieldID = &time.Field.UUID
+	}
+
+	return response
+}
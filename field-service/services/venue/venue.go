@@ -0,0 +1,245 @@
+package services
+
+import (
+	"common/storage"
+	"context"
+	"field-service/common/timeofday"
+	"field-service/constants"
+	errVenue "field-service/constants/error/venue"
+	"field-service/domain/dto"
+	"field-service/domain/models"
+	"field-service/repositories"
+	"time"
+)
+
+const (
+	defaultNearbyRadiusKm = 10
+	defaultNearbyLimit    = 20
+)
+
+type VenueService struct {
+	repository repositories.IRepositoryRegistry
+	storage    storage.IStorageClient
+}
+
+type IVenueService interface {
+	GetAll(context.Context) ([]dto.VenueResponse, error)
+	GetNearby(context.Context, *dto.VenueNearbyRequestParam) ([]dto.VenueResponse, error)
+	GetByUUID(context.Context, string) (*dto.VenueResponse, error)
+	Create(context.Context, *dto.VenueRequest) (*dto.VenueResponse, error)
+	Update(context.Context, string, *dto.VenueRequest) (*dto.VenueResponse, error)
+	Delete(context.Context, string) error
+}
+
+func NewVenueService(repository repositories.IRepositoryRegistry, storage storage.IStorageClient) IVenueService {
+	return &VenueService{repository: repository, storage: storage}
+}
+
+func (s *VenueService) GetAll(ctx context.Context) ([]dto.VenueResponse, error) {
+	venues, err := s.repository.GetVenue().FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.VenueResponse, 0, len(venues))
+	for i := range venues {
+		response = append(response, s.toVenueResponse(ctx, &venues[i], venues[i].Fields))
+	}
+
+	return response, nil
+}
+
+// GetNearby lists venues around a point, nearest first. When a date is given
+// only fields with an available schedule that day are kept, narrowed down to
+// the slot running at the given time if there is one. Venues left without
+// fields are dropped.
+func (s *VenueService) GetNearby(ctx context.Context, param *dto.VenueNearbyRequestParam) ([]dto.VenueResponse, error) {
+	radius := param.RadiusKm
+	if radius == 0 {
+		radius = defaultNearbyRadiusKm
+	}
+
+	limit := param.Limit
+	if limit == 0 {
+		limit = defaultNearbyLimit
+	}
+
+	venues, err := s.repository.GetVenue().FindNearby(ctx, *param.Latitude, *param.Longitude, radius, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.VenueResponse, 0, len(venues))
+	for i := range venues {
+		fields := venues[i].Fields
+		if param.Date != "" {
+			fields, err = s.availableFields(ctx, fields, param.Date, param.Time)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(fields) == 0 {
+				continue
+			}
+		}
+
+		response = append(response, s.toVenueResponse(ctx, &venues[i], fields))
+	}
+
+	return response, nil
+}
+
+func (s *VenueService) availableFields(ctx context.Context, fields []models.Field, date, at string) ([]models.Field, error) {
+	var (
+		clock timeofday.TimeOfDay
+		err   error
+	)
+
+	if at != "" {
+		clock, err = timeofday.Parse(at)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := make([]models.Field, 0, len(fields))
+	for _, field := range fields {
+		schedules, err := s.repository.GetFieldSchedule().FindAllWithFieldIdAndDate(ctx, int(field.ID), date)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, schedule := range schedules {
+			if schedule.Status != constants.Available {
+				continue
+			}
+
+			if at != "" && (clock < schedule.Time.StartTime || clock >= schedule.Time.EndTime) {
+				continue
+			}
+
+			result = append(result, field)
+			break
+		}
+	}
+
+	return result, nil
+}
+
+func (s *VenueService) GetByUUID(ctx context.Context, uuid string) (*dto.VenueResponse, error) {
+	venue, err := s.repository.GetVenue().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	response := s.toVenueResponse(ctx, venue, venue.Fields)
+	return &response, nil
+}
+
+func (s *VenueService) Create(ctx context.Context, req *dto.VenueRequest) (*dto.VenueResponse, error) {
+	venue, err := toVenueModel(req)
+	if err != nil {
+		return nil, err
+	}
+
+	venue, err = s.repository.GetVenue().Create(ctx, venue)
+	if err != nil {
+		return nil, err
+	}
+
+	response := s.toVenueResponse(ctx, venue, nil)
+	return &response, nil
+}
+
+func (s *VenueService) Update(ctx context.Context, uuid string, req *dto.VenueRequest) (*dto.VenueResponse, error) {
+	venue, err := toVenueModel(req)
+	if err != nil {
+		return nil, err
+	}
+
+	venue, err = s.repository.GetVenue().Update(ctx, uuid, venue)
+	if err != nil {
+		return nil, err
+	}
+
+	response := s.toVenueResponse(ctx, venue, venue.Fields)
+	return &response, nil
+}
+
+func (s *VenueService) Delete(ctx context.Context, uuid string) error {
+	_, err := s.repository.GetVenue().FindByUUID(ctx, uuid)
+	if err != nil {
+		return err
+	}
+
+	return s.repository.GetVenue().Delete(ctx, uuid)
+}
+
+func toVenueModel(req *dto.VenueRequest) (*models.Venue, error) {
+	_, err := time.LoadLocation(req.Timezone)
+	if err != nil {
+		return nil, errVenue.ErrInvalidTimezone
+	}
+
+	openTime, err := timeofday.Parse(req.OpenTime)
+	if err != nil {
+		return nil, errVenue.ErrInvalidOpeningHours
+	}
+
+	closeTime, err := timeofday.Parse(req.CloseTime)
+	if err != nil {
+		return nil, errVenue.ErrInvalidOpeningHours
+	}
+
+	if closeTime <= openTime {
+		return nil, errVenue.ErrInvalidOpeningHours
+	}
+
+	return &models.Venue{
+		Name:              req.Name,
+		Address:           req.Address,
+		City:              req.City,
+		Latitude:          *req.Latitude,
+		Longitude:         *req.Longitude,
+		Timezone:          req.Timezone,
+		OpenTime:          openTime,
+		CloseTime:         closeTime,
+		Phone:             req.Phone,
+		Email:             req.Email,
+		DepositPercentage: req.DepositPercentage,
+	}, nil
+}
+
+func (s *VenueService) toVenueResponse(ctx context.Context, venue *models.Venue, fields []models.Field) dto.VenueResponse {
+	fieldResults := make([]dto.VenueFieldResponse, 0, len(fields))
+	for _, field := range fields {
+		fieldResults = append(fieldResults, dto.VenueFieldResponse{
+			UUID:         field.UUID,
+			Code:         field.Code,
+			Name:         field.Name,
+			PricePerHour: field.PricePerHour,
+			Images:       storage.SignURLs(ctx, s.storage, field.Images),
+			Rating:       field.AverageRating(),
+			RatingCount:  field.RatingCount,
+		})
+	}
+
+	return dto.VenueResponse{
+		UUID:              venue.UUID,
+		Name:              venue.Name,
+		Address:           venue.Address,
+		City:              venue.City,
+		Latitude:          venue.Latitude,
+		Longitude:         venue.Longitude,
+		Timezone:          venue.Timezone,
+		OpenTime:          venue.OpenTime.String(),
+		CloseTime:         venue.CloseTime.String(),
+		Phone:             venue.Phone,
+		Email:             venue.Email,
+		DepositPercentage: venue.DepositPercentage,
+		DistanceKm:        venue.Distance,
+		Fields:            fieldResults,
+		CreatedAt:         venue.CreatedAt,
+		UpdateAt:          venue.UpdatedAt,
+	}
+}
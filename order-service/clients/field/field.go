@@ -2,6 +2,7 @@ package clients
 
 import (
 	"bytes"
+	"common/signature"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -24,6 +25,13 @@ type FieldClient struct {
 type IFieldClient interface {
 	GetFieldByUUID(context.Context, uuid.UUID) (*FieldData, error)
 	UpdateStatus(*dto.UpdateFieldScheduleStatusRequest) error
+	GetFieldSchedulesByUUIDs(context.Context, []string) ([]FieldScheduleDetailData, error)
+	GetAddOnsByUUIDs(context.Context, []string) ([]AddOnData, error)
+	TakeAddOnStock(context.Context, *dto.TakeAddOnStockRequest) error
+	ReturnAddOnStock(context.Context, *dto.ReturnAddOnStockRequest) error
+	Reschedule(context.Context, *dto.RescheduleFieldScheduleRequest) error
+	Release(context.Context, *dto.ReleaseFieldScheduleRequest) error
+	Announce(context.Context, *dto.AnnounceFieldScheduleRequest) error
 }
 
 func NewFieldClient(client config.IClientConfig) IFieldClient {
@@ -122,20 +130,24 @@ func (f *FieldClient) GetFieldByUUID(c context.Context, uuid uuid.UUID) (*FieldD
 // }
 
 func (f *FieldClient) UpdateStatus(request *dto.UpdateFieldScheduleStatusRequest) error {
-	unixTime := time.Now().Unix()
-	generateApikey := fmt.Sprintf("%s:%s:%d", "field-services", Cfg.Cfg.InternalService.Field.SignatureKey, unixTime)
-	apiKey := util.GenerateSHA256(generateApikey)
+	requestAt := fmt.Sprintf("%d", time.Now().Unix())
+	nonce, err := signature.GenerateNonce()
+	if err != nil {
+		return err
+	}
 
 	body, err := json.Marshal(request)
 	if err != nil {
 		return err
 	}
 
-	req, _ := http.NewRequest("PATCH", fmt.Sprintf("%s/api/v1/field/schedule/status", Cfg.Cfg.InternalService.Field.Host), bytes.NewBuffer(body))
-	//req.Header.Set("Authorization", bearerToken)
-	req.Header.Set(constants.XApiKey, apiKey)
-	req.Header.Set(constants.XrequestAt, fmt.Sprintf("%d", unixTime))
-	req.Header.Set(constants.XserviceName, "field-services")
+	req, _ := http.NewRequest("PATCH", fmt.Sprintf("%s/internal/v1/field/schedule/status", Cfg.Cfg.InternalService.Field.Host), bytes.NewBuffer(body))
+	sign := signature.Sign(Cfg.Cfg.InternalService.Field.SignatureKey, Cfg.Cfg.AppName, req.Method, req.URL.RequestURI(), body, requestAt, nonce)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(constants.XserviceName, Cfg.Cfg.AppName)
+	req.Header.Set(constants.XrequestAt, requestAt)
+	req.Header.Set(constants.XNonce, nonce)
+	req.Header.Set(constants.XSignature, sign)
 
 	client := &http.Client{}
 	resp, err := client.Do(req)
@@ -145,5 +157,140 @@ func (f *FieldClient) UpdateStatus(request *dto.UpdateFieldScheduleStatusRequest
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode != http.StatusOK {
+		var response FieldResponse
+		json.NewDecoder(resp.Body).Decode(&response)
+		return fmt.Errorf("field response: %s", response.Message)
+	}
+
+	return nil
+}
+
+// GetFieldSchedulesByUUIDs looks up many schedules in one signed internal
+// call, so it works without the access token of a user.
+func (f *FieldClient) GetFieldSchedulesByUUIDs(c context.Context, uuids []string) ([]FieldScheduleDetailData, error) {
+	requestAt := fmt.Sprintf("%d", time.Now().Unix())
+	nonce, err := signature.GenerateNonce()
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(&dto.FieldScheduleLookupRequest{FieldScheduleIDs: uuids})
+	if err != nil {
+		return nil, err
+	}
+
+	req, _ := http.NewRequestWithContext(c, "POST", fmt.Sprintf("%s/internal/v1/field/schedule/lookup", Cfg.Cfg.InternalService.Field.Host), bytes.NewBuffer(body))
+	sign := signature.Sign(Cfg.Cfg.InternalService.Field.SignatureKey, Cfg.Cfg.AppName, req.Method, req.URL.RequestURI(), body, requestAt, nonce)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(constants.XserviceName, Cfg.Cfg.AppName)
+	req.Header.Set(constants.XrequestAt, requestAt)
+	req.Header.Set(constants.XNonce, nonce)
+	req.Header.Set(constants.XSignature, sign)
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		logrus.Errorf("err: %v", err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var response FieldScheduleDetailResponse
+	json.NewDecoder(resp.Body).Decode(&response)
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("field response: %s", response.Message)
+	}
+
+	return response.Data, nil
+}
+
+// GetAddOnsByUUIDs looks up the catalogue entries of many add-ons. Unknown
+// IDs are left out of the result.
+func (f *FieldClient) GetAddOnsByUUIDs(c context.Context, uuids []string) ([]AddOnData, error) {
+	resp, err := f.postInternal(c, "/internal/v1/addon/lookup", &dto.AddOnLookupRequest{AddOnIDs: uuids})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var response AddOnResponse
+	json.NewDecoder(resp.Body).Decode(&response)
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("field response: %s", response.Message)
+	}
+
+	return response.Data, nil
+}
+
+func (f *FieldClient) TakeAddOnStock(c context.Context, request *dto.TakeAddOnStockRequest) error {
+	return f.sendInternal(c, "/internal/v1/addon/stock/take", request)
+}
+
+func (f *FieldClient) ReturnAddOnStock(c context.Context, request *dto.ReturnAddOnStockRequest) error {
+	return f.sendInternal(c, "/internal/v1/addon/stock/return", request)
+}
+
+// Reschedule moves the bookings of an order to other schedules. field-service
+// does it in one transaction, so either every schedule moves or none does.
+func (f *FieldClient) Reschedule(c context.Context, request *dto.RescheduleFieldScheduleRequest) error {
+	return f.sendInternal(c, "/internal/v1/field/schedule/reschedule", request)
+}
+
+// Release frees the schedules a cancelled order still holds.
+func (f *FieldClient) Release(c context.Context, request *dto.ReleaseFieldScheduleRequest) error {
+	return f.sendInternal(c, "/internal/v1/field/schedule/release", request)
+}
+
+// Announce publishes an event on the schedule stream without changing the
+// status of the schedules.
+func (f *FieldClient) Announce(c context.Context, request *dto.AnnounceFieldScheduleRequest) error {
+	return f.sendInternal(c, "/internal/v1/field/schedule/announce", request)
+}
+
+func (f *FieldClient) sendInternal(c context.Context, path string, request any) error {
+	resp, err := f.postInternal(c, path, request)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var response FieldResponse
+		json.NewDecoder(resp.Body).Decode(&response)
+		return fmt.Errorf("field response: %s", response.Message)
+	}
+
 	return nil
 }
+
+// postInternal sends a signed POST to an internal endpoint of field-service.
+func (f *FieldClient) postInternal(c context.Context, path string, request any) (*http.Response, error) {
+	requestAt := fmt.Sprintf("%d", time.Now().Unix())
+	nonce, err := signature.GenerateNonce()
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	req, _ := http.NewRequestWithContext(c, "POST", fmt.Sprintf("%s%s", Cfg.Cfg.InternalService.Field.Host, path), bytes.NewBuffer(body))
+	sign := signature.Sign(Cfg.Cfg.InternalService.Field.SignatureKey, Cfg.Cfg.AppName, req.Method, req.URL.RequestURI(), body, requestAt, nonce)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(constants.XserviceName, Cfg.Cfg.AppName)
+	req.Header.Set(constants.XrequestAt, requestAt)
+	req.Header.Set(constants.XNonce, nonce)
+	req.Header.Set(constants.XSignature, sign)
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		logrus.Errorf("err: %v", err)
+		return nil, err
+	}
+
+	return resp, nil
+}
@@ -13,14 +13,52 @@ type FieldResponse struct {
 	Data    FieldData `json:"data"`
 }
 
+type FieldScheduleDetailResponse struct {
+	Code    int                       `json:"code"`
+	Status  string                    `json:"status"`
+	Message string                    `json:"message"`
+	Data    []FieldScheduleDetailData `json:"data"`
+}
+
+type FieldScheduleDetailData struct {
+	UUID      uuid.UUID `json:"uuid"`
+	FieldUUID uuid.UUID `json:"fieldUUID"`
+	FieldName string    `json:"fieldName"`
+	Location  string    `json:"location"`
+	Date      string    `json:"date"`
+	StartAt   time.Time `json:"startAt"`
+	EndAt     time.Time `json:"endAt"`
+	Status    string    `json:"status"`
+}
+
 type FieldData struct {
-	UUID         uuid.UUID  `json:"uuid"`
-	FieldName    string     `json:"FieldName"`
-	PricePerHour float64    `json:"pricePerHour"`
-	Date         string     `json:"date"`
-	StartTime    string     `json:"startTime"`
-	EndTime      string     `json:"endTime"`
-	Status       string     `json:"status"`
-	CreatedAt    *time.Time `json:"createdAt"`
-	UpdatedAt    *time.Time `json:"updatedAt"`
+	UUID              uuid.UUID  `json:"uuid"`
+	FieldName         string     `json:"FieldName"`
+	VenueID           *uuid.UUID `json:"venueID"`
+	DepositPercentage int        `json:"depositPercentage"`
+	PricePerHour      float64    `json:"pricePerHour"`
+	Price             float64    `json:"price"`
+	Date              string     `json:"date"`
+	StartTime         string     `json:"startTime"`
+	EndTime           string     `json:"endTime"`
+	Status            string     `json:"status"`
+	CreatedAt         *time.Time `json:"createdAt"`
+	UpdatedAt         *time.Time `json:"updatedAt"`
+}
+
+type AddOnResponse struct {
+	Code    int         `json:"code"`
+	Status  string      `json:"status"`
+	Message string      `json:"message"`
+	Data    []AddOnData `json:"data"`
+}
+
+type AddOnData struct {
+	UUID     uuid.UUID `json:"uuid"`
+	VenueID  uuid.UUID `json:"venueID"`
+	Name     string    `json:"name"`
+	Category string    `json:"category"`
+	Price    float64   `json:"price"`
+	Stock    int       `json:"stock"`
+	IsActive bool      `json:"isActive"`
 }
@@ -2,12 +2,12 @@ package clients
 
 import (
 	"bytes"
+	"common/signature"
 	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"order-service/clients/config"
-	"order-service/common/util"
 	config2 "order-service/config"
 	"order-service/constants"
 	"order-service/domain/dto"
@@ -24,6 +24,8 @@ type PaymentClient struct {
 type IPaymentClient interface {
 	GetPaymentUUID(context.Context, uuid.UUID) (*PaymentData, error)
 	CreatePaymentLink(context.Context, *dto.PaymentRequest) (*PaymentData, error)
+	SettleBalance(context.Context, uuid.UUID, *dto.SettleBalanceRequest) error
+	Refund(context.Context, uuid.UUID, *dto.RefundRequest) error
 }
 
 func NewPaymentClient(client config.IClientConfig) IPaymentClient {
@@ -57,29 +59,19 @@ func NewPaymentClient(client config.IClientConfig) IPaymentClient {
 // }
 
 func (p *PaymentClient) GetPaymentUUID(c context.Context, uuid uuid.UUID) (*PaymentData, error) {
-	unixTime := time.Now().Unix()
-	generateApikey := fmt.Sprintf("%s:%s:%d", "payment-services", config2.Cfg.InternalService.Payment.SignatureKey, unixTime)
-	apiKey := util.GenerateSHA256(generateApikey)
-	token := c.Value(constants.Token).(string)
-	bearerToken := fmt.Sprintf("Bearer %s", token)
-
-	req, _ := http.NewRequest("GET", fmt.Sprintf("%s/api/v1/payment/%s", config2.Cfg.InternalService.Payment.Host, uuid), nil)
-	req.Header.Set("Authorization", bearerToken)
-	req.Header.Set(constants.XApiKey, apiKey)
-	req.Header.Set(constants.XrequestAt, fmt.Sprintf("%d", unixTime))
-	req.Header.Set(constants.XserviceName, "payment-services")
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := p.doInternal(c, "GET", fmt.Sprintf("/internal/v1/payment/%s", uuid), nil)
 	if err != nil {
-		logrus.Errorf("err: %v", err)
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	var response PaymentResponse
 	json.NewDecoder(resp.Body).Decode(&response)
-	return &response.Data, nil
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("payment response: %s", response.Message)
+	}
 
+	return &response.Data, nil
 }
 
 // func (p *PaymentClient) CreatePaymentLink(c context.Context, req *dto.PaymentRequest) (*PaymentData, error) {
@@ -125,32 +117,87 @@ func (p *PaymentClient) GetPaymentUUID(c context.Context, uuid uuid.UUID) (*Paym
 // }
 
 func (p *PaymentClient) CreatePaymentLink(c context.Context, request *dto.PaymentRequest) (*PaymentData, error) {
-	unixTime := time.Now().Unix()
-	generateApikey := fmt.Sprintf("%s:%s:%d", "user-services", config2.Cfg.InternalService.User.SignatureKey, unixTime)
-	apiKey := util.GenerateSHA256(generateApikey)
-	token := c.Value(constants.Token).(string)
-	bearerToken := fmt.Sprintf("Bearer %s", token)
+	resp, err := p.doInternal(c, "POST", "/internal/v1/payment", request)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
 
-	body, err := json.Marshal(request)
+	var response PaymentResponse
+	json.NewDecoder(resp.Body).Decode(&response)
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("payment response: %s", response.Message)
+	}
+
+	return &response.Data, nil
+}
+
+// SettleBalance tells payment-service the balance of a deposit payment was
+// paid at the venue, so it reissues the invoice as paid.
+func (p *PaymentClient) SettleBalance(c context.Context, paymentID uuid.UUID, request *dto.SettleBalanceRequest) error {
+	resp, err := p.doInternal(c, "POST", fmt.Sprintf("/internal/v1/payment/%s/balance", paymentID), request)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var response PaymentResponse
+		json.NewDecoder(resp.Body).Decode(&response)
+		return fmt.Errorf("payment response: %s", response.Message)
+	}
+
+	return nil
+}
+
+// Refund asks payment-service to give a settled payment back to the customer.
+func (p *PaymentClient) Refund(c context.Context, paymentID uuid.UUID, request *dto.RefundRequest) error {
+	resp, err := p.doInternal(c, "POST", fmt.Sprintf("/internal/v1/payment/%s/refund", paymentID), request)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var response PaymentResponse
+		json.NewDecoder(resp.Body).Decode(&response)
+		return fmt.Errorf("payment response: %s", response.Message)
+	}
+
+	return nil
+}
+
+// doInternal sends a signed request to an internal endpoint of
+// payment-service. A nil request is sent without a body.
+func (p *PaymentClient) doInternal(c context.Context, method, path string, request any) (*http.Response, error) {
+	requestAt := fmt.Sprintf("%d", time.Now().Unix())
+	nonce, err := signature.GenerateNonce()
 	if err != nil {
 		return nil, err
 	}
 
-	req, _ := http.NewRequest("POST", fmt.Sprintf("%s/api/v1/payment", config2.Cfg.InternalService.Payment.Host), bytes.NewBuffer(body))
-	req.Header.Set("Authorization", bearerToken)
-	req.Header.Set(constants.XApiKey, apiKey)
-	req.Header.Set(constants.XrequestAt, fmt.Sprintf("%d", unixTime))
-	req.Header.Set(constants.XserviceName, "user-services")
+	var body []byte
+	if request != nil {
+		body, err = json.Marshal(request)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	req, _ := http.NewRequestWithContext(c, method, fmt.Sprintf("%s%s", config2.Cfg.InternalService.Payment.Host, path), bytes.NewBuffer(body))
+	sign := signature.Sign(config2.Cfg.InternalService.Payment.SignatureKey, config2.Cfg.AppName, req.Method, req.URL.RequestURI(), body, requestAt, nonce)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(constants.XserviceName, config2.Cfg.AppName)
+	req.Header.Set(constants.XrequestAt, requestAt)
+	req.Header.Set(constants.XNonce, nonce)
+	req.Header.Set(constants.XSignature, sign)
+
 	client := &http.Client{}
 	resp, err := client.Do(req)
 	if err != nil {
 		logrus.Errorf("err: %v", err)
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	var response PaymentResponse
-	json.NewDecoder(resp.Body).Decode(&response)
-	return &response.Data, nil
 
+	return resp, nil
 }
@@ -13,6 +13,9 @@ type PaymentData struct {
 	UUID          uuid.UUID `json:"uuid"`
 	OrderID       string    `json:"orderID"`
 	Amount        float64   `json:"amount"`
+	OrderAmount   float64   `json:"orderAmount,omitempty"`
+	Balance       float64   `json:"balance,omitempty"`
+	BalancePaidAt *string   `json:"balancePaidAt,omitempty"`
 	Status        string    `json:"status"`
 	PaymentLink   string    `json:"paymentLink"`
 	InvoiceLink   *string   `json:"invoiceLink,omitempty"`
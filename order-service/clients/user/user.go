@@ -1,12 +1,13 @@
 package clients
 
 import (
+	"bytes"
+	"common/signature"
 	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"order-service/clients/config"
-	"order-service/common/util"
 	config2 "order-service/config"
 	"order-service/constants"
 	"time"
@@ -22,6 +23,7 @@ type UserClient struct {
 type IUserClient interface {
 	GetUserByToken(context.Context) (*UserData, error)
 	GetUserByUUID(ctx context.Context, uuid uuid.UUID) (*UserData, error)
+	GetUserByCalendarFeedToken(context.Context, string) (*UserData, error)
 }
 
 func NewUserClient(client config.IClientConfig) IUserClient {
@@ -80,23 +82,14 @@ func NewUserClient(client config.IClientConfig) IUserClient {
 // 	return &response.Data, nil
 // }
 
+// GetUserByToken resolves the access token of the current request. The token
+// is passed along in a signed internal call.
 func (u *UserClient) GetUserByToken(ctx context.Context) (*UserData, error) {
-	unixTime := time.Now().Unix()
-	generateApikey := fmt.Sprintf("%s:%s:%d", "user-services", u.client.SignatureKey(), unixTime)
-	apiKey := util.GenerateSHA256(generateApikey)
 	token := ctx.Value(constants.Token).(string)
 	bearerToken := fmt.Sprintf("Bearer %s", token)
 
-	req, _ := http.NewRequest("GET", fmt.Sprintf("%s/api/v1/auth/user", u.client.BaseURL()), nil)
-	req.Header.Set("Authorization", bearerToken)
-	req.Header.Set(constants.XApiKey, apiKey)
-	req.Header.Set(constants.XrequestAt, fmt.Sprintf("%d", unixTime))
-	req.Header.Set(constants.XserviceName, "user-services")
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := u.doInternal(ctx, "GET", "/internal/v1/auth/user", nil, bearerToken)
 	if err != nil {
-		logrus.Errorf("err: %v", err)
 		return nil, err
 	}
 	defer resp.Body.Close()
@@ -107,27 +100,74 @@ func (u *UserClient) GetUserByToken(ctx context.Context) (*UserData, error) {
 }
 
 func (u *UserClient) GetUserByUUID(ctx context.Context, uuid uuid.UUID) (*UserData, error) {
-	unixTime := time.Now().Unix()
-	generateApikey := fmt.Sprintf("%s:%s:%d", config2.Cfg.AppName, u.client.SignatureKey(), unixTime)
-	apiKey := util.GenerateSHA256(generateApikey)
-	token := ctx.Value(constants.Token).(string)
-	bearerToken := fmt.Sprintf("Bearer %s", token)
+	resp, err := u.doInternal(ctx, "GET", fmt.Sprintf("/internal/v1/auth/%s", uuid), nil, "")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
 
-	req, _ := http.NewRequest("GET", fmt.Sprintf("%s/api/v1/auth/%s", u.client.BaseURL(), uuid), nil)
-	req.Header.Set("Authorization", bearerToken)
-	req.Header.Set(constants.XApiKey, apiKey)
-	req.Header.Set(constants.XrequestAt, fmt.Sprintf("%d", unixTime))
-	req.Header.Set(constants.XserviceName, "user-services")
+	var userResp UserResponse
+	json.NewDecoder(resp.Body).Decode(&userResp)
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("user response: %s", userResp.Message)
+	}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	return &userResp.Data, nil
+}
+
+// GetUserByCalendarFeedToken resolves the feed token of a calendar
+// subscription, which comes without an access token.
+func (u *UserClient) GetUserByCalendarFeedToken(ctx context.Context, token string) (*UserData, error) {
+	resp, err := u.doInternal(ctx, "POST", "/internal/v1/auth/calendar-token/verify", map[string]string{"token": token}, "")
 	if err != nil {
-		logrus.Errorf("err: %v", err)
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	var userResp UserResponse
 	json.NewDecoder(resp.Body).Decode(&userResp)
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("user response: %s", userResp.Message)
+	}
+
 	return &userResp.Data, nil
 }
+
+// doInternal sends a signed request to an internal endpoint of user-service.
+// A nil request is sent without a body, and bearerToken is only set when the
+// endpoint works on the current user.
+func (u *UserClient) doInternal(ctx context.Context, method, path string, request any, bearerToken string) (*http.Response, error) {
+	requestAt := fmt.Sprintf("%d", time.Now().Unix())
+	nonce, err := signature.GenerateNonce()
+	if err != nil {
+		return nil, err
+	}
+
+	var body []byte
+	if request != nil {
+		body, err = json.Marshal(request)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	req, _ := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s%s", u.client.BaseURL(), path), bytes.NewBuffer(body))
+	sign := signature.Sign(u.client.SignatureKey(), config2.Cfg.AppName, req.Method, req.URL.RequestURI(), body, requestAt, nonce)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(constants.XserviceName, config2.Cfg.AppName)
+	req.Header.Set(constants.XrequestAt, requestAt)
+	req.Header.Set(constants.XNonce, nonce)
+	req.Header.Set(constants.XSignature, sign)
+	if bearerToken != "" {
+		req.Header.Set(constants.Authorization, bearerToken)
+	}
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		logrus.Errorf("err: %v", err)
+		return nil, err
+	}
+
+	return resp, nil
+}
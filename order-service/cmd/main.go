@@ -12,6 +12,7 @@ import (
 	kafka2 "order-service/controllers/kafka"
 	kafka "order-service/controllers/kafka/config"
 	"order-service/domain/models"
+	"order-service/jobs"
 	"order-service/middlewares"
 	"order-service/repositories"
 	"order-service/routes"
@@ -34,6 +35,10 @@ var command = &cobra.Command{
 	Short: "Start the server",
 	Run: func(c *cobra.Command, args []string) {
 		config.Init()
+		if config.Cfg.CheckInSecret == "" {
+			panic("checkInSecret is not configured")
+		}
+
 		db, err := config.InitDatabase()
 		if err != nil {
 			panic(err)
@@ -49,6 +54,11 @@ var command = &cobra.Command{
 			&models.Order{},
 			&models.OrderHistory{},
 			&models.OrderField{},
+			&models.OrderAddOn{},
+			&models.OrderReschedule{},
+			&models.OrderRescheduleItem{},
+			&models.OrderVenuePayment{},
+			&models.OrderShare{},
 		)
 
 		client := clients.NewClientRegistry()
@@ -56,6 +66,10 @@ var command = &cobra.Command{
 		service := services.NewServiceRegistry(repository, client)
 		controller := controllers.NewControllerRegistry(service)
 
+		if config.Cfg.NoShowMarker.Enabled {
+			go jobs.NewNoShowMarker(repository, service).Start(context.Background())
+		}
+
 		serveHttp(controller, client)
 		serveKafkaConsumer(service)
 	},
@@ -14,9 +14,13 @@ type AppConfig struct {
 	AppName                    string          `json:"appName"`
 	AppEnv                     string          `json:"appEnv"`
 	SignatureKey               string          `json:"signatureKey"`
+	CheckInSecret              string          `json:"checkInSecret"`
 	Database                   Database        `json:"database"`
 	RateLimiterMaxRequest      float64         `json:"rateLimiterMaxRequest"`
 	RateLimiterTimeSecond      int             `json:"rateLimiterTimeSecond"`
+	RescheduleWindowHours      int             `json:"rescheduleWindowHours"`
+	CheckInOpenMinutes         int             `json:"checkInOpenMinutes"`
+	NoShowMarker               NoShowMarker    `json:"noShowMarker"`
 	InternalService            InternalService `json:"internalService"`
 	GcsType                    string          `json:"gcsType"`
 	GcsProjectID               string          `json:"gcsProjectID"`
@@ -64,6 +68,11 @@ type Payment struct {
 	Host         string `json:"host"`
 	SignatureKey string `json:"signatureKey"`
 }
+type NoShowMarker struct {
+	Enabled        bool `json:"enabled"`
+	IntervalMinute int  `json:"intervalMinute"`
+}
+
 type Kafka struct {
 	Brokers               []string `json:"brokers"`
 	TimeoutInMs           int      `json:"timeoutInMs"`
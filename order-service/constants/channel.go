@@ -0,0 +1,22 @@
+package constants
+
+// OrderChannel tells where an order was taken.
+type OrderChannel string
+
+const (
+	// OnlineChannel orders are placed by customers and paid through
+	// payment-service.
+	OnlineChannel OrderChannel = "online"
+	// VenueChannel orders are taken by admins at the venue, over the phone
+	// or for walk-ins, and paid there.
+	VenueChannel OrderChannel = "venue"
+)
+
+// VenuePaymentMethod is how a venue order was paid.
+type VenuePaymentMethod string
+
+const (
+	CashPayment          VenuePaymentMethod = "cash"
+	TransferPayment      VenuePaymentMethod = "transfer"
+	ComplimentaryPayment VenuePaymentMethod = "complimentary"
+)
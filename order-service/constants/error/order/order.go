@@ -6,10 +6,58 @@ var (
 	ErrOrderNotFound = errors.New("order not found")
 	ErrOrderExists   = errors.New("order already exist")
 	ErrAlreadyBooked = errors.New("field already booked")
+
+	ErrAddOnNotFound    = errors.New("add-on not found")
+	ErrAddOnUnavailable = errors.New("add-on is not offered at the venue of the field")
+	ErrAddOnOutOfStock  = errors.New("add-on is out of stock")
+
+	ErrOrderNotReschedulable  = errors.New("only paid orders can be rescheduled")
+	ErrRescheduleWindowClosed = errors.New("schedule is too close to be rescheduled")
+	ErrRescheduleCheaper      = errors.New("new schedule must not be cheaper than the old one")
+	ErrReschedulePending      = errors.New("order already has a reschedule waiting for payment")
+	ErrScheduleNotInOrder     = errors.New("schedule is not part of the order")
+	ErrDuplicateSchedule      = errors.New("schedule is listed more than once")
+
+	ErrDepositNotOffered        = errors.New("venue does not take deposits")
+	ErrNothingOutstanding       = errors.New("order has no outstanding balance")
+	ErrAmountExceedsOutstanding = errors.New("amount exceeds the outstanding balance")
+
+	ErrSplitWithDeposit = errors.New("a split order must be paid in full")
+	ErrOrderNotSplit    = errors.New("order is not split")
+
+	ErrCustomerNotFound = errors.New("customer not found")
+	ErrInvalidDateRange = errors.New("to must not be before from")
+
+	ErrInvalidCheckInToken = errors.New("invalid check-in token")
+	ErrOrderNotCheckInable = errors.New("only paid orders can be checked in")
+	ErrAlreadyCheckedIn    = errors.New("order is already checked in")
+	ErrCheckInNotOpen      = errors.New("check-in is not open yet")
+	ErrCheckInClosed       = errors.New("check-in is closed")
 )
 
 var OrderErrors = []error{
 	ErrOrderNotFound,
 	ErrOrderExists,
 	ErrAlreadyBooked,
+	ErrAddOnNotFound,
+	ErrAddOnUnavailable,
+	ErrAddOnOutOfStock,
+	ErrOrderNotReschedulable,
+	ErrRescheduleWindowClosed,
+	ErrRescheduleCheaper,
+	ErrReschedulePending,
+	ErrScheduleNotInOrder,
+	ErrDuplicateSchedule,
+	ErrDepositNotOffered,
+	ErrNothingOutstanding,
+	ErrAmountExceedsOutstanding,
+	ErrSplitWithDeposit,
+	ErrOrderNotSplit,
+	ErrCustomerNotFound,
+	ErrInvalidDateRange,
+	ErrInvalidCheckInToken,
+	ErrOrderNotCheckInable,
+	ErrAlreadyCheckedIn,
+	ErrCheckInNotOpen,
+	ErrCheckInClosed,
 }
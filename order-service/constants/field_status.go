@@ -7,6 +7,14 @@ const (
 	BookedFieldStatus    FieldStatusString = "booked"
 )
 
+// ReservedScheduleEvent and ReleasedScheduleEvent are announced on the
+// schedule stream of field-service when an order awaiting payment takes its
+// schedules and when it expires.
+const (
+	ReservedScheduleEvent = "reserved"
+	ReleasedScheduleEvent = "released"
+)
+
 func (p FieldStatusString) String() string {
 	return string(p)
 }
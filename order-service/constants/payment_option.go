@@ -0,0 +1,11 @@
+package constants
+
+type PaymentOption string
+
+const (
+	// FullPayment pays the whole order online.
+	FullPayment PaymentOption = "full"
+	// DepositPayment pays the deposit of the venue online and the balance
+	// at the venue.
+	DepositPayment PaymentOption = "deposit"
+)
@@ -0,0 +1,10 @@
+package constants
+
+type RescheduleStatus string
+
+const (
+	ReschedulePending   RescheduleStatus = "pending"
+	RescheduleCompleted RescheduleStatus = "completed"
+	RescheduleExpired   RescheduleStatus = "expired"
+	RescheduleFailed    RescheduleStatus = "failed"
+)
@@ -0,0 +1,13 @@
+package constants
+
+type ShareStatus string
+
+const (
+	SharePending ShareStatus = "pending"
+	SharePaid    ShareStatus = "paid"
+	ShareExpired ShareStatus = "expired"
+	// ShareRefundDue marks a paid share of an order that did not complete.
+	ShareRefundDue ShareStatus = "refund-due"
+	// ShareRefunded marks a share whose payment was given back.
+	ShareRefunded ShareStatus = "refunded"
+)
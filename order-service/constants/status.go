@@ -8,11 +8,26 @@ const (
 	PendingPayment OrderStatus = 200
 	PaymentSuccess OrderStatus = 300
 	Expired        OrderStatus = 400
+	Cancelled      OrderStatus = 500
+	PartiallyPaid  OrderStatus = 600
 
 	PendingString        OrderStatusString = "pending"
 	PendingPaymentString OrderStatusString = "pending-payment"
 	PaymentSuccessString OrderStatusString = "payment-success"
 	ExpiredString        OrderStatusString = "expired"
+	CancelledString      OrderStatusString = "cancelled"
+	PartiallyPaidString  OrderStatusString = "partially-paid"
+
+	// Reschedules are only recorded in the order history; the order itself
+	// stays paid.
+	ReschedulePendingString OrderStatusString = "reschedule-pending"
+	RescheduledString       OrderStatusString = "rescheduled"
+	RescheduleExpiredString OrderStatusString = "reschedule-expired"
+	RescheduleFailedString  OrderStatusString = "reschedule-failed"
+
+	// Attendance is recorded in the history too.
+	CheckedInString OrderStatusString = "checked-in"
+	NoShowString    OrderStatusString = "no-show"
 )
 
 var mapStatusStringToInt = map[OrderStatusString]OrderStatus{
@@ -20,6 +35,8 @@ var mapStatusStringToInt = map[OrderStatusString]OrderStatus{
 	PendingPaymentString: PendingPayment,
 	PaymentSuccessString: PaymentSuccess,
 	ExpiredString:        Expired,
+	CancelledString:      Cancelled,
+	PartiallyPaidString:  PartiallyPaid,
 }
 
 var mapStatusIntToString = map[OrderStatus]OrderStatusString{
@@ -27,6 +44,8 @@ var mapStatusIntToString = map[OrderStatus]OrderStatusString{
 	PendingPayment: PendingPaymentString,
 	PaymentSuccess: PaymentSuccessString,
 	Expired:        ExpiredString,
+	Cancelled:      CancelledString,
+	PartiallyPaid:  PartiallyPaidString,
 }
 
 func (p OrderStatus) String() string {
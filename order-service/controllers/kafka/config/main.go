@@ -3,6 +3,7 @@ package kafka
 import (
 	"order-service/config"
 	kafka "order-service/controllers/kafka"
+	fieldKafka "order-service/controllers/kafka/field"
 	paymentKafka "order-service/controllers/kafka/payment"
 
 	"golang.org/x/exp/slices"
@@ -23,6 +24,7 @@ func NewKafkaConsumer(consumer *ConsumerGroup, kafka kafka.IKafkaRegistry) IKafk
 
 func (k *Kafka) Register() {
 	k.PaymendHandler()
+	k.FieldBlackoutHandler()
 }
 
 func (k *Kafka) PaymendHandler() {
@@ -30,3 +32,9 @@ func (k *Kafka) PaymendHandler() {
 		k.consumer.RegisterHandler(paymentKafka.PaymentTopic, k.kafka.GetPayment().HandlePayment)
 	}
 }
+
+func (k *Kafka) FieldBlackoutHandler() {
+	if slices.Contains(config.Cfg.Kafka.Topics, fieldKafka.FieldBlackoutTopic) {
+		k.consumer.RegisterHandler(fieldKafka.FieldBlackoutTopic, k.kafka.GetField().HandleFieldBlackout)
+	}
+}
@@ -0,0 +1,47 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"order-service/common/util"
+	"order-service/domain/dto"
+	"order-service/services"
+
+	"github.com/IBM/sarama"
+	"github.com/sirupsen/logrus"
+)
+
+const FieldBlackoutTopic = "field-service-blackout"
+
+type FieldKafka struct {
+	service services.IServiceRegistry
+}
+
+type IFieldKafka interface {
+	HandleFieldBlackout(context.Context, *sarama.ConsumerMessage) error
+}
+
+func NewFieldKafka(service services.IServiceRegistry) IFieldKafka {
+	return &FieldKafka{service: service}
+}
+
+func (f *FieldKafka) HandleFieldBlackout(c context.Context, message *sarama.ConsumerMessage) error {
+	defer util.Recover()
+	var body dto.FieldBlackoutContent
+
+	err := json.Unmarshal(message.Value, &body)
+	if err != nil {
+		logrus.Errorf("failed to unmarshal message: %v", err)
+		return err
+	}
+
+	data := body.Body.Data
+	err = f.service.GetOrder().HandleFieldBlackout(c, &data)
+	if err != nil {
+		logrus.Errorf("failed to handle field blackout: %v", err)
+		return err
+	}
+
+	logrus.Infof("Success handle field blackout")
+	return nil
+}
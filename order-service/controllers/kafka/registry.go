@@ -1,6 +1,7 @@
 package kafka
 
 import (
+	fieldKafka "order-service/controllers/kafka/field"
 	kafka "order-service/controllers/kafka/payment"
 	"order-service/services"
 )
@@ -11,6 +12,7 @@ type Registry struct {
 
 type IKafkaRegistry interface {
 	GetPayment() kafka.IPaymentKafka
+	GetField() fieldKafka.IFieldKafka
 }
 
 func NewKafkaRegistry(service services.IServiceRegistry) IKafkaRegistry {
@@ -20,3 +22,7 @@ func NewKafkaRegistry(service services.IServiceRegistry) IKafkaRegistry {
 func (r *Registry) GetPayment() kafka.IPaymentKafka {
 	return kafka.NewPaymentKafka(r.service)
 }
+
+func (r *Registry) GetField() fieldKafka.IFieldKafka {
+	return fieldKafka.NewFieldKafka(r.service)
+}
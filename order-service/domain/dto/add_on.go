@@ -0,0 +1,24 @@
+package dto
+
+type OrderAddOnRequest struct {
+	AddOnID  string `json:"addOnID" validate:"required,uuid"`
+	Quantity int    `json:"quantity" validate:"required,min=1,max=100"`
+}
+
+type AddOnLookupRequest struct {
+	AddOnIDs []string `json:"addOnIDs"`
+}
+
+type AddOnStockItem struct {
+	AddOnID  string `json:"addOnID"`
+	Quantity int    `json:"quantity"`
+}
+
+type TakeAddOnStockRequest struct {
+	OrderID string           `json:"orderID"`
+	Items   []AddOnStockItem `json:"items"`
+}
+
+type ReturnAddOnStockRequest struct {
+	OrderID string `json:"orderID"`
+}
@@ -2,4 +2,27 @@ package dto
 
 type UpdateFieldScheduleStatusRequest struct {
 	FieldScheduleIDs []string `json:"fieldScheduleIDs"`
+	OrderCode        string   `json:"orderCode"`
+	UserID           string   `json:"userID"`
+}
+
+type RescheduleFieldScheduleRequest struct {
+	FromFieldScheduleIDs []string `json:"fromFieldScheduleIDs"`
+	ToFieldScheduleIDs   []string `json:"toFieldScheduleIDs"`
+	OrderCode            string   `json:"orderCode"`
+	UserID               string   `json:"userID"`
+}
+
+type ReleaseFieldScheduleRequest struct {
+	FieldScheduleIDs []string `json:"fieldScheduleIDs"`
+	OrderCode        string   `json:"orderCode"`
+}
+
+type AnnounceFieldScheduleRequest struct {
+	FieldScheduleIDs []string `json:"fieldScheduleIDs"`
+	Event            string   `json:"event"`
+}
+
+type FieldScheduleLookupRequest struct {
+	FieldScheduleIDs []string `json:"fieldScheduleIDs"`
 }
@@ -0,0 +1,22 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type FieldBlackoutData struct {
+	BlackoutID       uuid.UUID   `json:"blackoutID"`
+	FieldID          uuid.UUID   `json:"fieldID"`
+	Reason           string      `json:"reason"`
+	StartAt          time.Time   `json:"startAt"`
+	EndAt            time.Time   `json:"endAt"`
+	FieldScheduleIDs []uuid.UUID `json:"fieldScheduleIDs"`
+}
+
+type FieldBlackoutContent struct {
+	Event    KafkaEvent                   `json:"event"`
+	Metadata KafkaMetaData                `json:"metadata"`
+	Body     KafkaBody[FieldBlackoutData] `json:"body"`
+}
@@ -7,14 +7,98 @@ import (
 )
 
 type OrderRequest struct {
-	FieldScheduleIDs []string `json:"fieldScheduleIDs" validate:"required"`
+	FieldScheduleIDs []string                `json:"fieldScheduleIDs" validate:"required"`
+	AddOns           []OrderAddOnRequest     `json:"addOns" validate:"omitempty,dive"`
+	PaymentOption    constants.PaymentOption `json:"paymentOption" validate:"omitempty,oneof=full deposit"`
+	// Shares splits the order between team members, each paying their own
+	// share. The organiser is listed too.
+	Shares []OrderShareRequest `json:"shares" validate:"omitempty,min=2,max=22,dive"`
+}
+
+type OrderShareRequest struct {
+	Name  string `json:"name" validate:"required,max=100"`
+	Email string `json:"email" validate:"omitempty,email,max=100"`
+	Phone string `json:"phone" validate:"omitempty,max=20"`
+}
+
+type OrderShareResponse struct {
+	UUID        uuid.UUID             `json:"uuid"`
+	Name        string                `json:"name"`
+	Amount      float64               `json:"amount"`
+	Status      constants.ShareStatus `json:"status"`
+	PaymentLink string                `json:"paymentLink,omitempty"`
+	PaidAt      *time.Time            `json:"paidAt,omitempty"`
+}
+
+type OrderSharesResponse struct {
+	OrderCode  string                      `json:"orderCode"`
+	Status     constants.OrderStatusString `json:"status"`
+	Amount     float64                     `json:"amount"`
+	AmountPaid float64                     `json:"amountPaid"`
+	Shares     []OrderShareResponse        `json:"shares"`
+}
+
+// ManualOrderRequest is an order an admin takes at the venue for a customer
+// with an account, given by UserID, or for a guest.
+type ManualOrderRequest struct {
+	FieldScheduleIDs []string                     `json:"fieldScheduleIDs" validate:"required,min=1,unique,dive,uuid"`
+	AddOns           []OrderAddOnRequest          `json:"addOns" validate:"omitempty,dive"`
+	UserID           string                       `json:"userID" validate:"omitempty,uuid"`
+	CustomerName     string                       `json:"customerName" validate:"required_without=UserID,max=100"`
+	CustomerPhone    string                       `json:"customerPhone" validate:"max=20"`
+	PaymentMethod    constants.VenuePaymentMethod `json:"paymentMethod" validate:"required,oneof=cash transfer complimentary"`
+}
+
+type CheckInRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+// CheckInTokenResponse carries the token the customer shows as a QR code at
+// the venue, and when staff can scan it.
+type CheckInTokenResponse struct {
+	OrderCode string    `json:"orderCode"`
+	Token     string    `json:"token"`
+	OpensAt   time.Time `json:"opensAt"`
+	ClosesAt  time.Time `json:"closesAt"`
+}
+
+type CustomerAttendanceResponse struct {
+	UserID       uuid.UUID  `json:"userID"`
+	CheckIns     int64      `json:"checkIns"`
+	NoShows      int64      `json:"noShows"`
+	LastNoShowAt *time.Time `json:"lastNoShowAt"`
+}
+
+type VenuePaymentRequest struct {
+	Amount float64 `json:"amount" validate:"required,gt=0"`
+	Method string  `json:"method" validate:"required,oneof=cash qris"`
+	Note   string  `json:"note" validate:"max=255"`
 }
 
 type OrderRequestParam struct {
 	Page       int     `form:"page" validate:"required"`
 	Limit      int     `form:"limit" validate:"required"`
-	SortColumn *string `form:"sortColumn"`
-	SortOrder  *string `form:"sortOrder"`
+	SortColumn *string `form:"sortColumn" validate:"omitempty,oneof=code amount status date created_at"`
+	SortOrder  *string `form:"sortOrder" validate:"omitempty,oneof=asc desc"`
+	Channel    *string `form:"channel" validate:"omitempty,oneof=online venue"`
+}
+
+type OrderSummaryParam struct {
+	From string `form:"from" validate:"required,datetime=2006-01-02"`
+	To   string `form:"to" validate:"required,datetime=2006-01-02"`
+}
+
+type OrderChannelSummary struct {
+	Channel       constants.OrderChannel       `json:"channel"`
+	PaymentMethod constants.VenuePaymentMethod `json:"paymentMethod,omitempty"`
+	Orders        int64                        `json:"orders"`
+	Amount        float64                      `json:"amount"`
+}
+
+type OrderSummaryResponse struct {
+	From     string                `json:"from"`
+	To       string                `json:"to"`
+	Channels []OrderChannelSummary `json:"channels"`
 }
 
 type OrderResponse struct {
@@ -22,16 +106,26 @@ type OrderResponse struct {
 	Code        string                      `json:"code"`
 	UserName    string                      `json:"userName"`
 	Amount      float64                     `json:"amount"`
+	Deposit     float64                     `json:"deposit,omitempty"`
+	AmountPaid  float64                     `json:"amountPaid"`
+	Outstanding float64                     `json:"outstanding"`
 	Status      constants.OrderStatusString `json:"status"`
-	PaymentLink string                      `json:"paymentLink,omitempty"`
-	OrderDate   time.Time                   `json:"orderDate"`
-	CreatedAt   time.Time                   `json:"createdAt"`
-	UpdatedAt   time.Time                   `json:"updatedAt"`
+	Channel     constants.OrderChannel      `json:"channel"`
+	// PaymentMethod is only set on orders taken at the venue.
+	PaymentMethod constants.VenuePaymentMethod `json:"paymentMethod,omitempty"`
+	PaymentLink   string                       `json:"paymentLink,omitempty"`
+	Shares        []OrderShareResponse         `json:"shares,omitempty"`
+	CheckedInAt   *time.Time                   `json:"checkedInAt,omitempty"`
+	NoShowAt      *time.Time                   `json:"noShowAt,omitempty"`
+	OrderDate     time.Time                    `json:"orderDate"`
+	CreatedAt     time.Time                    `json:"createdAt"`
+	UpdatedAt     time.Time                    `json:"updatedAt"`
 }
 
 type OrderByUserIDResponse struct {
 	Code        string                      `json:"code"`
 	Amount      string                      `json:"amount"`
+	Outstanding string                      `json:"outstanding"`
 	Status      constants.OrderStatusString `json:"status"`
 	OrderDate   string                      `json:"orderDate"`
 	PaymentLink string                      `json:"paymentLink"`
@@ -0,0 +1,78 @@
+package dto
+
+import (
+	"order-service/constants"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrderDetailResponse is an order with the schedules it booked, its add-ons,
+// its payment and the history of its status.
+type OrderDetailResponse struct {
+	UUID          uuid.UUID                    `json:"uuid"`
+	Code          string                       `json:"code"`
+	UserName      string                       `json:"userName"`
+	Amount        float64                      `json:"amount"`
+	Deposit       float64                      `json:"deposit,omitempty"`
+	AmountPaid    float64                      `json:"amountPaid"`
+	Outstanding   float64                      `json:"outstanding"`
+	Status        constants.OrderStatusString  `json:"status"`
+	Channel       constants.OrderChannel       `json:"channel"`
+	PaymentMethod constants.VenuePaymentMethod `json:"paymentMethod,omitempty"`
+	CheckedInAt   *time.Time                   `json:"checkedInAt,omitempty"`
+	NoShowAt      *time.Time                   `json:"noShowAt,omitempty"`
+	Schedules     []OrderScheduleResponse      `json:"schedules"`
+	AddOns        []OrderAddOnResponse         `json:"addOns"`
+	// Payment is left out for orders paid at the venue and for split orders,
+	// whose shares are paid one by one.
+	Payment   *OrderPaymentResponse  `json:"payment,omitempty"`
+	Shares    []OrderShareResponse   `json:"shares,omitempty"`
+	Histories []OrderHistoryResponse `json:"histories"`
+	OrderDate time.Time              `json:"orderDate"`
+	CreatedAt time.Time              `json:"createdAt"`
+	UpdatedAt time.Time              `json:"updatedAt"`
+}
+
+// OrderScheduleResponse is a booked schedule. Its details are empty when
+// field-service no longer knows the schedule.
+type OrderScheduleResponse struct {
+	FieldScheduleID uuid.UUID  `json:"fieldScheduleID"`
+	FieldID         *uuid.UUID `json:"fieldID,omitempty"`
+	FieldName       string     `json:"fieldName"`
+	Location        string     `json:"location"`
+	Date            string     `json:"date"`
+	StartTime       string     `json:"startTime"`
+	EndTime         string     `json:"endTime"`
+	Status          string     `json:"status"`
+}
+
+type OrderAddOnResponse struct {
+	AddOnID  uuid.UUID `json:"addOnID"`
+	Name     string    `json:"name"`
+	Price    float64   `json:"price"`
+	Quantity int       `json:"quantity"`
+	Amount   float64   `json:"amount"`
+}
+
+type OrderPaymentResponse struct {
+	UUID          uuid.UUID `json:"uuid"`
+	Amount        float64   `json:"amount"`
+	OrderAmount   float64   `json:"orderAmount,omitempty"`
+	Balance       float64   `json:"balance,omitempty"`
+	Status        string    `json:"status"`
+	PaymentLink   string    `json:"paymentLink"`
+	InvoiceLink   *string   `json:"invoiceLink,omitempty"`
+	VANumber      *string   `json:"vaNumber,omitempty"`
+	Bank          *string   `json:"bank,omitempty"`
+	Acquirer      *string   `json:"acquirer,omitempty"`
+	TransactionID *string   `json:"transactionID,omitempty"`
+	PaidAt        *string   `json:"paidAt,omitempty"`
+	BalancePaidAt *string   `json:"balancePaidAt,omitempty"`
+	ExpiredAt     string    `json:"expiredAt"`
+}
+
+type OrderHistoryResponse struct {
+	Status    constants.OrderStatusString `json:"status"`
+	CreatedAt time.Time                   `json:"createdAt"`
+}
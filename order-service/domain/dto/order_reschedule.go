@@ -0,0 +1,24 @@
+package dto
+
+import (
+	"order-service/constants"
+
+	"github.com/google/uuid"
+)
+
+type RescheduleOrderRequest struct {
+	Schedules []RescheduleScheduleRequest `json:"schedules" validate:"required,min=1,dive"`
+}
+
+type RescheduleScheduleRequest struct {
+	FromFieldScheduleID string `json:"fromFieldScheduleID" validate:"required,uuid"`
+	ToFieldScheduleID   string `json:"toFieldScheduleID" validate:"required,uuid"`
+}
+
+type RescheduleOrderResponse struct {
+	UUID        uuid.UUID                  `json:"uuid"`
+	OrderCode   string                     `json:"orderCode"`
+	Amount      float64                    `json:"amount"`
+	Status      constants.RescheduleStatus `json:"status"`
+	PaymentLink string                     `json:"paymentLink,omitempty"`
+}
@@ -9,6 +9,7 @@ type PaymentRequest struct {
 	OrderID        uuid.UUID      `json:"orderID"`
 	ExpiredAt      time.Time      `json:"expiredAt"`
 	Amount         float64        `json:"amount"`
+	OrderAmount    float64        `json:"orderAmount,omitempty"`
 	Description    string         `json:"description"`
 	CustomerDetail CustomerDetail `json:"customerDetail"`
 	ItemDetails    []ItemDetails  `json:"itemDetails"`
@@ -26,3 +27,12 @@ type ItemDetails struct {
 	Amount   float64   `json:"amount"`
 	Quantity int       `json:"quantity"`
 }
+
+type SettleBalanceRequest struct {
+	Method string `json:"method"`
+}
+
+type RefundRequest struct {
+	Amount float64 `json:"amount,omitempty"`
+	Reason string  `json:"reason"`
+}
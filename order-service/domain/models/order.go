@@ -7,16 +7,62 @@ import (
 )
 
 type Order struct {
-	ID        uint                  `gorm:"primaryKey;autoIncrement"`
-	UUID      uuid.UUID             `gorm:"type:uuid;not null"`
-	Code      string                `gorm:"type:varchar(30);not null"`
-	UserID    uuid.UUID             `gorm:"type:uuid;not null"`
-	PaymentID uuid.UUID             `gorm:"type:uuid;not null"`
-	Amount    float64               `gorm:"type:decimal(10,2);not null"`
-	Status    constants.OrderStatus `gorm:"type:int;not null"`
-	Date      time.Time             `gorm:"type:timestamp;not null"`
-	IsPaid    bool                  `gorm:"type:boolean;not null"`
-	PaidAt    *time.Time            `gorm:"type:timestamp"`
+	ID        uint      `gorm:"primaryKey;autoIncrement"`
+	UUID      uuid.UUID `gorm:"type:uuid;not null"`
+	Code      string    `gorm:"type:varchar(30);not null"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null"`
+	PaymentID uuid.UUID `gorm:"type:uuid;not null"`
+	Amount    float64   `gorm:"type:decimal(10,2);not null"`
+	// Deposit is the part of Amount paid online when the customer chose to
+	// pay the rest at the venue. It is zero for orders paid in full.
+	Deposit    float64                `gorm:"type:decimal(10,2);not null;default:0"`
+	AmountPaid float64                `gorm:"type:decimal(10,2);not null;default:0"`
+	Status     constants.OrderStatus  `gorm:"type:int;not null"`
+	Date       time.Time              `gorm:"type:timestamp;not null"`
+	IsPaid     bool                   `gorm:"type:boolean;not null"`
+	PaidAt     *time.Time             `gorm:"type:timestamp"`
+	Channel    constants.OrderChannel `gorm:"type:varchar(20);not null;default:'online'"`
+	// PaymentMethod, CreatedBy and the customer details are only set on
+	// orders taken at the venue. Guests have no account, so their UserID is
+	// the nil UUID.
+	PaymentMethod constants.VenuePaymentMethod `gorm:"type:varchar(20)"`
+	CreatedBy     *uuid.UUID                   `gorm:"type:uuid"`
+	CustomerName  string                       `gorm:"type:varchar(100)"`
+	CustomerPhone string                       `gorm:"type:varchar(20)"`
+	CheckedInAt   *time.Time                   `gorm:"type:timestamp"`
+	CheckedInBy   *uuid.UUID                   `gorm:"type:uuid"`
+	// NoShowAt is set when the last slot of a paid order ended without a
+	// check-in.
+	NoShowAt  *time.Time `gorm:"type:timestamp"`
 	CreatedAt *time.Time
 	UpdatedAt *time.Time
 }
+
+// PaidAmount is what the customer has paid so far. Orders paid before
+// AmountPaid was kept only have IsPaid set.
+func (o *Order) PaidAmount() float64 {
+	if o.IsPaid && o.AmountPaid == 0 {
+		return o.Amount
+	}
+	return o.AmountPaid
+}
+
+// Outstanding is what is left to pay at the venue.
+func (o *Order) Outstanding() float64 {
+	if o.IsPaid {
+		return 0
+	}
+	return o.Amount - o.PaidAmount()
+}
+
+// HoldsSchedules reports whether the schedules of the order are booked,
+// which they are once the order or its deposit is paid.
+func (o *Order) HoldsSchedules() bool {
+	return o.Status == constants.PaymentSuccess || o.Status == constants.PartiallyPaid
+}
+
+// IsGuest reports whether the order was taken at the venue for a customer
+// without an account.
+func (o *Order) IsGuest() bool {
+	return o.UserID == uuid.Nil
+}
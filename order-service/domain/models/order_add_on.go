@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrderAddOn is an add-on line of an order. Name and price are copied from
+// the catalogue so later changes there do not alter the order.
+type OrderAddOn struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement"`
+	OrderID   uint      `gorm:"type:bigint;not null;index"`
+	AddOnID   uuid.UUID `gorm:"type:uuid;not null"`
+	Name      string    `gorm:"type:varchar(100);not null"`
+	Price     float64   `gorm:"type:decimal(10,2);not null"`
+	Quantity  int       `gorm:"type:int;not null"`
+	CreatedAt *time.Time
+	UpdatedAt *time.Time
+}
+
+func (o *OrderAddOn) Amount() float64 {
+	return o.Price * float64(o.Quantity)
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"order-service/constants"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrderReschedule moves schedules of a paid order to others. When the new
+// schedules cost more, Amount is the difference and UUID is sent to
+// payment-service as the order ID of its payment link.
+type OrderReschedule struct {
+	ID        uint                       `gorm:"primaryKey;autoIncrement"`
+	UUID      uuid.UUID                  `gorm:"type:uuid;not null;uniqueIndex"`
+	OrderID   uint                       `gorm:"type:bigint;not null;index"`
+	Amount    float64                    `gorm:"type:decimal(10,2);not null"`
+	Status    constants.RescheduleStatus `gorm:"type:varchar(20);not null"`
+	PaymentID *uuid.UUID                 `gorm:"type:uuid"`
+	PaidAt    *time.Time                 `gorm:"type:timestamp"`
+	Order     Order                      `gorm:"foreignKey:OrderID;references:ID"`
+	Items     []OrderRescheduleItem      `gorm:"foreignKey:OrderRescheduleID;references:ID"`
+	CreatedAt *time.Time
+	UpdatedAt *time.Time
+}
+
+type OrderRescheduleItem struct {
+	ID                  uint      `gorm:"primaryKey;autoIncrement"`
+	OrderRescheduleID   uint      `gorm:"type:bigint;not null;index"`
+	FromFieldScheduleID uuid.UUID `gorm:"type:uuid;not null"`
+	ToFieldScheduleID   uuid.UUID `gorm:"type:uuid;not null"`
+	CreatedAt           *time.Time
+	UpdatedAt           *time.Time
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"order-service/constants"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrderShare is the part of a split order one team member pays. UUID is sent
+// to payment-service as the order ID of the payment link of the share.
+type OrderShare struct {
+	ID          uint                  `gorm:"primaryKey;autoIncrement"`
+	UUID        uuid.UUID             `gorm:"type:uuid;not null;uniqueIndex"`
+	OrderID     uint                  `gorm:"type:bigint;not null;index"`
+	Name        string                `gorm:"type:varchar(100);not null"`
+	Email       string                `gorm:"type:varchar(100)"`
+	Phone       string                `gorm:"type:varchar(20)"`
+	Amount      float64               `gorm:"type:decimal(10,2);not null"`
+	Status      constants.ShareStatus `gorm:"type:varchar(20);not null"`
+	PaymentID   *uuid.UUID            `gorm:"type:uuid"`
+	PaymentLink string                `gorm:"type:varchar(255)"`
+	PaidAt      *time.Time            `gorm:"type:timestamp"`
+	CreatedAt   *time.Time
+	UpdatedAt   *time.Time
+}
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrderVenuePayment is a payment of the balance of an order taken at the
+// venue by an admin.
+type OrderVenuePayment struct {
+	ID         uint      `gorm:"primaryKey;autoIncrement"`
+	OrderID    uint      `gorm:"type:bigint;not null;index"`
+	Amount     float64   `gorm:"type:decimal(10,2);not null"`
+	Method     string    `gorm:"type:varchar(20);not null"`
+	ReceivedBy uuid.UUID `gorm:"type:uuid;not null"`
+	Note       string    `gorm:"type:varchar(255)"`
+	CreatedAt  *time.Time
+	UpdatedAt  *time.Time
+}
@@ -0,0 +1,81 @@
+package jobs
+
+import (
+	"context"
+	"order-service/config"
+	"order-service/repositories"
+	"order-service/services"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// noShowMarkerLockKey identifies the advisory lock shared by every
+	// replica, so only one of them marks no-shows at a time.
+	noShowMarkerLockKey int64 = 8001
+
+	defaultNoShowIntervalMinute = 15
+)
+
+type NoShowMarker struct {
+	repository repositories.IRepositoryRegistry
+	service    services.IServiceRegistry
+}
+
+type INoShowMarker interface {
+	Start(context.Context)
+	Run(context.Context) error
+}
+
+func NewNoShowMarker(repository repositories.IRepositoryRegistry, service services.IServiceRegistry) INoShowMarker {
+	return &NoShowMarker{repository: repository, service: service}
+}
+
+// Start runs the marker right away and then on every interval until ctx is
+// cancelled.
+func (m *NoShowMarker) Start(ctx context.Context) {
+	interval := config.Cfg.NoShowMarker.IntervalMinute
+	if interval <= 0 {
+		interval = defaultNoShowIntervalMinute
+	}
+
+	ticker := time.NewTicker(time.Duration(interval) * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		err := m.Run(ctx)
+		if err != nil {
+			logrus.Errorf("no-show marker: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Run marks the paid orders whose slots ended without a check-in. It returns
+// without doing anything when another replica holds the lock.
+func (m *NoShowMarker) Run(ctx context.Context) error {
+	release, locked, err := m.repository.GetLock().TryLock(ctx, noShowMarkerLockKey)
+	if err != nil {
+		return err
+	}
+
+	if !locked {
+		logrus.Info("no-show marker: another instance is running, skipping")
+		return nil
+	}
+	defer release()
+
+	marked, err := m.service.GetOrder().MarkNoShows(ctx)
+	if err != nil {
+		return err
+	}
+
+	logrus.Infof("no-show marker: marked %d orders", marked)
+	return nil
+}
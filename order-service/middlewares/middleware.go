@@ -125,6 +125,29 @@ func CheckRole(roles []string, client clients.IClientRegistry) gin.HandlerFunc {
 	}
 }
 
+// AuthenticateCalendarFeed checks the feed token that calendar apps send in
+// the query string, since they cannot send headers, and puts its owner into
+// the request context like CheckRole does.
+func AuthenticateCalendarFeed(roles []string, client clients.IClientRegistry) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		token := ctx.Query("token")
+		if token == "" {
+			responseUnauthorized(ctx, errConstant.ErrUnauthorized.Error())
+			return
+		}
+
+		user, err := client.GetUser().GetUserByCalendarFeedToken(ctx.Request.Context(), token)
+		if err != nil || !contains(roles, user.Role) {
+			responseUnauthorized(ctx, errConstant.ErrUnauthorized.Error())
+			return
+		}
+
+		userLogin := ctx.Request.WithContext(context.WithValue(ctx.Request.Context(), constants.User, user))
+		ctx.Request = userLogin
+		ctx.Next()
+	}
+}
+
 func Authenticate() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		var err error
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	errWrap "order-service/common/error"
+	"order-service/constants"
 	errConstant "order-service/constants/error"
 	errOrder "order-service/constants/error/order"
 	"order-service/domain/dto"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type OrderRepository struct {
@@ -24,8 +26,13 @@ type IOrderRepository interface {
 	FindAllWithPagination(context.Context, *dto.OrderRequestParam) ([]models.Order, int64, error)
 	FindByUserID(context.Context, string) ([]models.Order, error)
 	FindByUUID(context.Context, string) (*models.Order, error)
+	FindByIDForUpdate(context.Context, *gorm.DB, uint) (*models.Order, error)
+	FindByIDs(context.Context, []uint) ([]models.Order, error)
 	Create(context.Context, *gorm.DB, *models.Order) (*models.Order, error)
 	Update(context.Context, *gorm.DB, *models.Order, uuid.UUID) error
+	SummarizePaid(context.Context, time.Time, time.Time) ([]dto.OrderChannelSummary, error)
+	FindAwaitingCheckIn(context.Context, time.Time, uint, int) ([]models.Order, error)
+	CountAttendanceByUserID(context.Context, uuid.UUID) (*dto.CustomerAttendanceResponse, error)
 }
 
 func NewOrderRepository(db *gorm.DB) IOrderRepository {
@@ -40,7 +47,11 @@ func (o *OrderRepository) FindAllWithPagination(c context.Context, param *dto.Or
 	)
 
 	if param.SortColumn != nil {
-		sort = fmt.Sprintf("%s %s", *param.SortColumn, *param.SortOrder)
+		order := "asc"
+		if param.SortOrder != nil {
+			order = *param.SortOrder
+		}
+		sort = fmt.Sprintf("%s %s", *param.SortColumn, order)
 	} else {
 		sort = "created_at desc"
 	}
@@ -48,12 +59,17 @@ func (o *OrderRepository) FindAllWithPagination(c context.Context, param *dto.Or
 	limit := param.Limit
 	offset := (param.Page - 1) * limit
 
-	err := o.db.WithContext(c).Limit(limit).Offset(offset).Order(sort).Find(&orders).Error
+	query := o.db.WithContext(c)
+	if param.Channel != nil {
+		query = query.Where("channel = ?", *param.Channel)
+	}
+
+	err := query.Limit(limit).Offset(offset).Order(sort).Find(&orders).Error
 	if err != nil {
 		return nil, 0, errWrap.WrapError(errConstant.ErrSQLError)
 	}
 
-	err = o.db.WithContext(c).Model(&orders).Count(&total).Error
+	err = query.Model(&models.Order{}).Count(&total).Error
 	if err != nil {
 		return nil, 0, errWrap.WrapError(errConstant.ErrSQLError)
 	}
@@ -90,6 +106,32 @@ func (o *OrderRepository) FindByUUID(c context.Context, uuid string) (*models.Or
 	return order, nil
 }
 
+// FindByIDForUpdate locks the order until tx ends.
+func (o *OrderRepository) FindByIDForUpdate(c context.Context, tx *gorm.DB, id uint) (*models.Order, error) {
+	var order *models.Order
+
+	err := tx.WithContext(c).Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&order).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapError(errOrder.ErrOrderNotFound)
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return order, nil
+}
+
+func (o *OrderRepository) FindByIDs(c context.Context, ids []uint) ([]models.Order, error) {
+	var orders []models.Order
+
+	err := o.db.WithContext(c).Where("id IN ?", ids).Find(&orders).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return orders, nil
+}
+
 func (o *OrderRepository) incrementCode(c context.Context) (*string, error) {
 	var (
 		order  *models.Order
@@ -124,13 +166,21 @@ func (o *OrderRepository) Create(c context.Context, tx *gorm.DB, req *models.Ord
 	}
 
 	order := &models.Order{
-		UUID:   uuid.New(),
-		Code:   *code,
-		UserID: req.UserID,
-		Amount: req.Amount,
-		Date:   req.Date,
-		Status: req.Status,
-		IsPaid: req.IsPaid,
+		UUID:          uuid.New(),
+		Code:          *code,
+		UserID:        req.UserID,
+		Amount:        req.Amount,
+		Deposit:       req.Deposit,
+		AmountPaid:    req.AmountPaid,
+		Date:          req.Date,
+		Status:        req.Status,
+		IsPaid:        req.IsPaid,
+		PaidAt:        req.PaidAt,
+		Channel:       req.Channel,
+		PaymentMethod: req.PaymentMethod,
+		CreatedBy:     req.CreatedBy,
+		CustomerName:  req.CustomerName,
+		CustomerPhone: req.CustomerPhone,
 	}
 
 	err = tx.WithContext(c).Create(order).Error
@@ -149,3 +199,58 @@ func (o *OrderRepository) Update(c context.Context, tx *gorm.DB, req *models.Ord
 	}
 	return nil
 }
+
+// SummarizePaid totals what was paid for the orders placed between from and
+// to, per channel and payment method. Orders paid before AmountPaid was kept
+// count with their amount.
+func (o *OrderRepository) SummarizePaid(c context.Context, from, to time.Time) ([]dto.OrderChannelSummary, error) {
+	var summaries []dto.OrderChannelSummary
+
+	err := o.db.WithContext(c).Model(&models.Order{}).
+		Select(`channel, payment_method, count(*) AS orders,
+			sum(CASE WHEN is_paid AND amount_paid = 0 THEN amount ELSE amount_paid END) AS amount`).
+		Where("date >= ? AND date < ?", from, to).
+		Where("status IN ?", []constants.OrderStatus{constants.PaymentSuccess, constants.PartiallyPaid}).
+		Group("channel, payment_method").
+		Order("channel, payment_method").
+		Scan(&summaries).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return summaries, nil
+}
+
+// FindAwaitingCheckIn returns up to limit orders created before the given
+// time, after afterID in ID order, that hold schedules and are neither
+// checked in nor marked as no-show.
+func (o *OrderRepository) FindAwaitingCheckIn(c context.Context, before time.Time, afterID uint, limit int) ([]models.Order, error) {
+	var orders []models.Order
+
+	err := o.db.WithContext(c).
+		Where("status IN ?", []constants.OrderStatus{constants.PaymentSuccess, constants.PartiallyPaid}).
+		Where("checked_in_at IS NULL AND no_show_at IS NULL").
+		Where("created_at < ? AND id > ?", before, afterID).
+		Order("id").
+		Limit(limit).
+		Find(&orders).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return orders, nil
+}
+
+func (o *OrderRepository) CountAttendanceByUserID(c context.Context, userID uuid.UUID) (*dto.CustomerAttendanceResponse, error) {
+	attendance := &dto.CustomerAttendanceResponse{UserID: userID}
+
+	err := o.db.WithContext(c).Model(&models.Order{}).
+		Select("count(checked_in_at) AS check_ins, count(no_show_at) AS no_shows, max(no_show_at) AS last_no_show_at").
+		Where("user_id = ?", userID).
+		Scan(attendance).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return attendance, nil
+}
@@ -0,0 +1,47 @@
+package repositories
+
+import (
+	"context"
+	errWrap "order-service/common/error"
+	errConstant "order-service/constants/error"
+	"order-service/domain/models"
+
+	"gorm.io/gorm"
+)
+
+type OrderAddOnRepository struct {
+	db *gorm.DB
+}
+
+type IOrderAddOnRepository interface {
+	FindByOrderID(context.Context, uint) ([]models.OrderAddOn, error)
+	Create(context.Context, *gorm.DB, []models.OrderAddOn) error
+}
+
+func NewOrderAddOnRepository(db *gorm.DB) IOrderAddOnRepository {
+	return &OrderAddOnRepository{db: db}
+}
+
+func (o *OrderAddOnRepository) FindByOrderID(c context.Context, orderID uint) ([]models.OrderAddOn, error) {
+	var orderAddOns []models.OrderAddOn
+
+	err := o.db.WithContext(c).Where("order_id = ?", orderID).Order("id asc").Find(&orderAddOns).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return orderAddOns, nil
+}
+
+func (o *OrderAddOnRepository) Create(c context.Context, tx *gorm.DB, orderAddOns []models.OrderAddOn) error {
+	if len(orderAddOns) == 0 {
+		return nil
+	}
+
+	err := tx.WithContext(c).Create(&orderAddOns).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	_ "order-service/constants/error/order"
 	"order-service/domain/models"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
@@ -16,7 +17,10 @@ type OrdertHistoryRepository struct {
 
 type IOrderFieldRepository interface {
 	FindByOrderID(context.Context, uint) ([]models.OrderField, error)
+	FindByOrderIDs(context.Context, []uint) ([]models.OrderField, error)
+	FindByFieldScheduleIDs(context.Context, []uuid.UUID) ([]models.OrderField, error)
 	Create(context.Context, *gorm.DB, []models.OrderField) error
+	UpdateFieldScheduleID(context.Context, *gorm.DB, uint, uuid.UUID, uuid.UUID) error
 }
 
 func NewOrderFieldRepository(db *gorm.DB) IOrderFieldRepository {
@@ -34,6 +38,28 @@ func (o *OrdertHistoryRepository) FindByOrderID(c context.Context, OrderID uint)
 	return orderFields, nil
 }
 
+func (o *OrdertHistoryRepository) FindByOrderIDs(c context.Context, orderIDs []uint) ([]models.OrderField, error) {
+	var orderFields []models.OrderField
+
+	err := o.db.WithContext(c).Where("order_id IN ?", orderIDs).Find(&orderFields).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return orderFields, nil
+}
+
+func (o *OrdertHistoryRepository) FindByFieldScheduleIDs(c context.Context, fieldScheduleIDs []uuid.UUID) ([]models.OrderField, error) {
+	var orderFields []models.OrderField
+
+	err := o.db.WithContext(c).Where("field_schedule_id IN ?", fieldScheduleIDs).Find(&orderFields).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return orderFields, nil
+}
+
 func (o *OrdertHistoryRepository) Create(c context.Context, tx *gorm.DB, req []models.OrderField) error {
 
 	err := tx.WithContext(c).Create(&req).Error
@@ -43,3 +69,16 @@ func (o *OrdertHistoryRepository) Create(c context.Context, tx *gorm.DB, req []m
 
 	return nil
 }
+
+// UpdateFieldScheduleID points the schedule line of an order at another
+// schedule.
+func (o *OrdertHistoryRepository) UpdateFieldScheduleID(c context.Context, tx *gorm.DB, orderID uint, from, to uuid.UUID) error {
+	err := tx.WithContext(c).Model(&models.OrderField{}).
+		Where("order_id = ? AND field_schedule_id = ?", orderID, from).
+		Update("field_schedule_id", to).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
@@ -16,6 +16,7 @@ type OrdertHistoryRepository struct {
 }
 
 type IOrderHistoryRepository interface {
+	FindByOrderID(context.Context, uint) ([]models.OrderHistory, error)
 	Create(context.Context, *gorm.DB, *dto.OrderHistoryRequest) error
 }
 
@@ -23,6 +24,17 @@ func NewOrderHistoryRepository(db *gorm.DB) IOrderHistoryRepository {
 	return &OrdertHistoryRepository{db: db}
 }
 
+func (o *OrdertHistoryRepository) FindByOrderID(c context.Context, orderID uint) ([]models.OrderHistory, error) {
+	var histories []models.OrderHistory
+
+	err := o.db.WithContext(c).Where("order_id = ?", orderID).Order("id asc").Find(&histories).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return histories, nil
+}
+
 func (o *OrdertHistoryRepository) Create(c context.Context, tx *gorm.DB, param *dto.OrderHistoryRequest) error {
 
 	orderHistory := &models.OrderHistory{
@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"context"
+	errWrap "order-service/common/error"
+	"order-service/constants"
+	errConstant "order-service/constants/error"
+	"order-service/domain/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type OrderRescheduleRepository struct {
+	db *gorm.DB
+}
+
+type IOrderRescheduleRepository interface {
+	FindByUUID(context.Context, string) (*models.OrderReschedule, error)
+	FindPendingByOrderID(context.Context, uint) (*models.OrderReschedule, error)
+	Create(context.Context, *gorm.DB, *models.OrderReschedule) error
+	Update(context.Context, *gorm.DB, uint, *models.OrderReschedule) error
+}
+
+func NewOrderRescheduleRepository(db *gorm.DB) IOrderRescheduleRepository {
+	return &OrderRescheduleRepository{db: db}
+}
+
+// FindByUUID returns the reschedule with its order and items, or nil when
+// the UUID is not a reschedule.
+func (o *OrderRescheduleRepository) FindByUUID(c context.Context, uuid string) (*models.OrderReschedule, error) {
+	var reschedules []models.OrderReschedule
+
+	err := o.db.WithContext(c).
+		Preload("Order").
+		Preload("Items").
+		Where("uuid = ?", uuid).
+		Limit(1).
+		Find(&reschedules).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	if len(reschedules) == 0 {
+		return nil, nil
+	}
+
+	return &reschedules[0], nil
+}
+
+// FindPendingByOrderID returns the reschedule of the order that waits for
+// payment, or nil when there is none.
+func (o *OrderRescheduleRepository) FindPendingByOrderID(c context.Context, orderID uint) (*models.OrderReschedule, error) {
+	var reschedules []models.OrderReschedule
+
+	err := o.db.WithContext(c).
+		Where("order_id = ? AND status = ?", orderID, constants.ReschedulePending).
+		Limit(1).
+		Find(&reschedules).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	if len(reschedules) == 0 {
+		return nil, nil
+	}
+
+	return &reschedules[0], nil
+}
+
+// Create saves the reschedule together with its items.
+func (o *OrderRescheduleRepository) Create(c context.Context, tx *gorm.DB, reschedule *models.OrderReschedule) error {
+	reschedule.UUID = uuid.New()
+	err := tx.WithContext(c).Omit("Order").Create(reschedule).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+func (o *OrderRescheduleRepository) Update(c context.Context, tx *gorm.DB, id uint, reschedule *models.OrderReschedule) error {
+	err := tx.WithContext(c).Model(&models.OrderReschedule{}).Where("id = ?", id).
+		Omit("Order", "Items").
+		Updates(reschedule).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package repositories
+
+import (
+	"context"
+	errWrap "order-service/common/error"
+	errConstant "order-service/constants/error"
+	"order-service/domain/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type OrderShareRepository struct {
+	db *gorm.DB
+}
+
+type IOrderShareRepository interface {
+	FindByUUID(context.Context, string) (*models.OrderShare, error)
+	FindByOrderID(context.Context, uint) ([]models.OrderShare, error)
+	FindByOrderIDForUpdate(context.Context, *gorm.DB, uint) ([]models.OrderShare, error)
+	Create(context.Context, *gorm.DB, []models.OrderShare) error
+	Update(context.Context, *gorm.DB, uint, *models.OrderShare) error
+}
+
+func NewOrderShareRepository(db *gorm.DB) IOrderShareRepository {
+	return &OrderShareRepository{db: db}
+}
+
+// FindByUUID returns the share, or nil when the UUID is not a share.
+func (o *OrderShareRepository) FindByUUID(c context.Context, uuid string) (*models.OrderShare, error) {
+	var shares []models.OrderShare
+
+	err := o.db.WithContext(c).Where("uuid = ?", uuid).Limit(1).Find(&shares).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	if len(shares) == 0 {
+		return nil, nil
+	}
+
+	return &shares[0], nil
+}
+
+func (o *OrderShareRepository) FindByOrderID(c context.Context, orderID uint) ([]models.OrderShare, error) {
+	var shares []models.OrderShare
+
+	err := o.db.WithContext(c).Where("order_id = ?", orderID).Order("id asc").Find(&shares).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return shares, nil
+}
+
+// FindByOrderIDForUpdate reads the shares inside tx, which must hold the lock
+// of the order.
+func (o *OrderShareRepository) FindByOrderIDForUpdate(c context.Context, tx *gorm.DB, orderID uint) ([]models.OrderShare, error) {
+	var shares []models.OrderShare
+
+	err := tx.WithContext(c).Where("order_id = ?", orderID).Order("id asc").Find(&shares).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return shares, nil
+}
+
+func (o *OrderShareRepository) Create(c context.Context, tx *gorm.DB, shares []models.OrderShare) error {
+	if len(shares) == 0 {
+		return nil
+	}
+
+	for i := range shares {
+		shares[i].UUID = uuid.New()
+	}
+
+	err := tx.WithContext(c).Create(&shares).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+func (o *OrderShareRepository) Update(c context.Context, tx *gorm.DB, id uint, share *models.OrderShare) error {
+	err := tx.WithContext(c).Model(&models.OrderShare{}).Where("id = ?", id).Updates(share).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
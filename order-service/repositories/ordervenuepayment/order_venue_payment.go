@@ -0,0 +1,43 @@
+package repositories
+
+import (
+	"context"
+	errWrap "order-service/common/error"
+	errConstant "order-service/constants/error"
+	"order-service/domain/models"
+
+	"gorm.io/gorm"
+)
+
+type OrderVenuePaymentRepository struct {
+	db *gorm.DB
+}
+
+type IOrderVenuePaymentRepository interface {
+	FindByOrderID(context.Context, uint) ([]models.OrderVenuePayment, error)
+	Create(context.Context, *gorm.DB, *models.OrderVenuePayment) error
+}
+
+func NewOrderVenuePaymentRepository(db *gorm.DB) IOrderVenuePaymentRepository {
+	return &OrderVenuePaymentRepository{db: db}
+}
+
+func (o *OrderVenuePaymentRepository) FindByOrderID(c context.Context, orderID uint) ([]models.OrderVenuePayment, error) {
+	var payments []models.OrderVenuePayment
+
+	err := o.db.WithContext(c).Where("order_id = ?", orderID).Order("id asc").Find(&payments).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return payments, nil
+}
+
+func (o *OrderVenuePaymentRepository) Create(c context.Context, tx *gorm.DB, payment *models.OrderVenuePayment) error {
+	err := tx.WithContext(c).Create(payment).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
@@ -1,9 +1,14 @@
 package repositories
 
 import (
+	repoLock "order-service/repositories/lock"
 	repoOrder "order-service/repositories/order"
+	repoOrderAddOn "order-service/repositories/orderaddon"
 	repoOrderField "order-service/repositories/orderfield"
 	repoOrderHistory "order-service/repositories/orderhistory"
+	repoOrderReschedule "order-service/repositories/orderreschedule"
+	repoOrderShare "order-service/repositories/ordershare"
+	repoOrderVenuePayment "order-service/repositories/ordervenuepayment"
 
 	"gorm.io/gorm"
 )
@@ -16,6 +21,11 @@ type IRepositoryRegistry interface {
 	GetOrder() repoOrder.IOrderRepository
 	GetOrderHistory() repoOrderHistory.IOrderHistoryRepository
 	GetOrderField() repoOrderField.IOrderFieldRepository
+	GetOrderAddOn() repoOrderAddOn.IOrderAddOnRepository
+	GetOrderReschedule() repoOrderReschedule.IOrderRescheduleRepository
+	GetOrderVenuePayment() repoOrderVenuePayment.IOrderVenuePaymentRepository
+	GetOrderShare() repoOrderShare.IOrderShareRepository
+	GetLock() repoLock.ILockRepository
 	GetTx() *gorm.DB
 }
 
@@ -32,6 +42,21 @@ func (r *Registry) GetOrderHistory() repoOrderHistory.IOrderHistoryRepository {
 func (r *Registry) GetOrderField() repoOrderField.IOrderFieldRepository {
 	return repoOrderField.NewOrderFieldRepository(r.db)
 }
+func (r *Registry) GetOrderAddOn() repoOrderAddOn.IOrderAddOnRepository {
+	return repoOrderAddOn.NewOrderAddOnRepository(r.db)
+}
+func (r *Registry) GetOrderReschedule() repoOrderReschedule.IOrderRescheduleRepository {
+	return repoOrderReschedule.NewOrderRescheduleRepository(r.db)
+}
+func (r *Registry) GetOrderVenuePayment() repoOrderVenuePayment.IOrderVenuePaymentRepository {
+	return repoOrderVenuePayment.NewOrderVenuePaymentRepository(r.db)
+}
+func (r *Registry) GetOrderShare() repoOrderShare.IOrderShareRepository {
+	return repoOrderShare.NewOrderShareRepository(r.db)
+}
+func (r *Registry) GetLock() repoLock.ILockRepository {
+	return repoLock.NewLockRepository(r.db)
+}
 
 func (r *Registry) GetTx() *gorm.DB {
 	return r.db
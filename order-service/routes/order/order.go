@@ -24,10 +24,25 @@ func NewOrderRoute(controller controllers.IControllerRegistry, client clients.IC
 }
 
 func (o *OrderRoute) Run() {
+	// Calendar apps authenticate with the feed token of the customer.
+	o.group.GET("/order/calendar.ics",
+		middlewares.AuthenticateCalendarFeed([]string{constants.Customer}, o.client),
+		o.controller.GetOrder().GetCalendar,
+	)
+
 	group := o.group.Group("/order")
 	group.Use(middlewares.Authenticate())
 	group.GET("", middlewares.CheckRole([]string{constants.Admin, constants.Customer}, o.client), o.controller.GetOrder().GetAllWIthPagination)
 	group.GET("/:uuid", middlewares.CheckRole([]string{constants.Admin, constants.Customer}, o.client), o.controller.GetOrder().GetByUUID)
 	group.GET("/user", middlewares.CheckRole([]string{constants.Customer}, o.client), o.controller.GetOrder().GetOrderByUserID)
+	group.GET("/summary", middlewares.CheckRole([]string{constants.Admin}, o.client), o.controller.GetOrder().GetSummary)
+	group.GET("/customer/:userID/attendance", middlewares.CheckRole([]string{constants.Admin}, o.client), o.controller.GetOrder().GetAttendance)
 	group.POST("", middlewares.CheckRole([]string{constants.Customer}, o.client), o.controller.GetOrder().Create)
+	group.POST("/manual", middlewares.CheckRole([]string{constants.Admin}, o.client), o.controller.GetOrder().CreateManual)
+	group.POST("/check-in", middlewares.CheckRole([]string{constants.Admin}, o.client), o.controller.GetOrder().CheckIn)
+	group.POST("/:uuid/reschedule", middlewares.CheckRole([]string{constants.Customer}, o.client), o.controller.GetOrder().Reschedule)
+	group.POST("/:uuid/venue-payment", middlewares.CheckRole([]string{constants.Admin}, o.client), o.controller.GetOrder().RecordVenuePayment)
+	group.GET("/:uuid/detail", middlewares.CheckRole([]string{constants.Admin, constants.Customer}, o.client), o.controller.GetOrder().GetDetail)
+	group.GET("/:uuid/shares", middlewares.CheckRole([]string{constants.Customer}, o.client), o.controller.GetOrder().GetShares)
+	group.GET("/:uuid/check-in-token", middlewares.CheckRole([]string{constants.Admin, constants.Customer}, o.client), o.controller.GetOrder().GetCheckInToken)
 }
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"common/ical"
+	"context"
+	"fmt"
+	clientField "order-service/clients/field"
+	clientUser "order-service/clients/user"
+	"order-service/config"
+	"order-service/constants"
+	"order-service/domain/models"
+	"time"
+)
+
+// calendarFeedPastDays keeps recent games in the feed so cancellations of
+// games that already happened still reach the calendars.
+const calendarFeedPastDays = 30
+
+// GetCalendar renders the paid orders of the current user as an iCalendar
+// feed with one event per booked slot. An order cancelled after payment stays
+// in the feed as cancelled events under the same UIDs.
+func (o *OrderService) GetCalendar(c context.Context) ([]byte, error) {
+	user := c.Value(constants.User).(*clientUser.UserData)
+	orders, err := o.repository.GetOrder().FindByUserID(c, user.UUID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	ordersByID := make(map[uint]*models.Order, len(orders))
+	orderIDs := make([]uint, 0, len(orders))
+	for i := range orders {
+		paid := orders[i].HoldsSchedules() ||
+			(orders[i].Status == constants.Cancelled && orders[i].PaidAmount() > 0)
+		if !paid {
+			continue
+		}
+		ordersByID[orders[i].ID] = &orders[i]
+		orderIDs = append(orderIDs, orders[i].ID)
+	}
+
+	calendar := ical.Calendar{
+		ProductID: fmt.Sprintf("-//%s//order calendar//EN", config.Cfg.AppName),
+		Name:      "My bookings",
+		Events:    make([]ical.Event, 0),
+	}
+	if len(orderIDs) == 0 {
+		return calendar.Encode(), nil
+	}
+
+	orderFields, err := o.repository.GetOrderField().FindByOrderIDs(c, orderIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduleIDs := make([]string, 0, len(orderFields))
+	for _, item := range orderFields {
+		scheduleIDs = append(scheduleIDs, item.FieldScheduleID.String())
+	}
+
+	schedules, err := o.client.GetField().GetFieldSchedulesByUUIDs(c, scheduleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	schedulesByID := make(map[string]*clientField.FieldScheduleDetailData, len(schedules))
+	for i := range schedules {
+		schedulesByID[schedules[i].UUID.String()] = &schedules[i]
+	}
+
+	from := time.Now().AddDate(0, 0, -calendarFeedPastDays)
+	for _, item := range orderFields {
+		schedule, ok := schedulesByID[item.FieldScheduleID.String()]
+		if !ok || schedule.EndAt.Before(from) {
+			continue
+		}
+
+		calendar.Events = append(calendar.Events, toCalendarEvent(ordersByID[item.OrderID], schedule))
+	}
+
+	return calendar.Encode(), nil
+}
+
+func toCalendarEvent(order *models.Order, schedule *clientField.FieldScheduleDetailData) ical.Event {
+	cancelled := order.Status == constants.Cancelled
+	sequence := 0
+	if cancelled {
+		sequence = 1
+	}
+
+	stamp := order.Date
+	if order.UpdatedAt != nil {
+		stamp = *order.UpdatedAt
+	}
+
+	return ical.Event{
+		UID:         fmt.Sprintf("%s-%s@%s", order.UUID, schedule.UUID, config.Cfg.AppName),
+		Summary:     fmt.Sprintf("%s - %s", schedule.FieldName, order.Code),
+		Description: fmt.Sprintf("Order %s", order.Code),
+		Location:    schedule.Location,
+		Start:       schedule.StartAt,
+		End:         schedule.EndAt,
+		Stamp:       stamp,
+		Sequence:    sequence,
+		Cancelled:   cancelled,
+	}
+}
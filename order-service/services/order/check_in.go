@@ -0,0 +1,299 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	clientField "order-service/clients/field"
+	clientUser "order-service/clients/user"
+	"order-service/config"
+	"order-service/constants"
+	errOrder "order-service/constants/error/order"
+	"order-service/domain/dto"
+	"order-service/domain/models"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultCheckInOpenMinutes = 30
+
+	// noShowBatchSize caps the orders the no-show marker reads at once.
+	noShowBatchSize = 500
+)
+
+// GetCheckInToken issues the token a customer shows as a QR code to check in
+// for a paid order. Admins may fetch it for guests too.
+func (o *OrderService) GetCheckInToken(c context.Context, uuid string) (*dto.CheckInTokenResponse, error) {
+	user := c.Value(constants.User).(*clientUser.UserData)
+
+	order, err := o.repository.GetOrder().FindByUUID(c, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Role == constants.Customer && order.UserID != user.UUID {
+		return nil, errOrder.ErrOrderNotFound
+	}
+
+	if !order.HoldsSchedules() {
+		return nil, errOrder.ErrOrderNotCheckInable
+	}
+
+	schedules, err := o.orderSchedules(c, order)
+	if err != nil {
+		return nil, err
+	}
+
+	opensAt, closesAt := checkInWindow(schedules)
+	return &dto.CheckInTokenResponse{
+		OrderCode: order.Code,
+		Token:     checkInToken(order.UUID),
+		OpensAt:   opensAt,
+		ClosesAt:  closesAt,
+	}, nil
+}
+
+// CheckIn records that the customer of the order showed up. The token is
+// accepted from checkInOpenMinutes before the first slot until the last one
+// ends.
+func (o *OrderService) CheckIn(c context.Context, req *dto.CheckInRequest) (*dto.OrderResponse, error) {
+	staff := c.Value(constants.User).(*clientUser.UserData)
+
+	orderUUID, err := parseCheckInToken(req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := o.repository.GetOrder().FindByUUID(c, orderUUID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if !order.HoldsSchedules() {
+		return nil, errOrder.ErrOrderNotCheckInable
+	}
+
+	schedules, err := o.orderSchedules(c, order)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	opensAt, closesAt := checkInWindow(schedules)
+	if now.Before(opensAt) {
+		return nil, errOrder.ErrCheckInNotOpen
+	}
+
+	if now.After(closesAt) {
+		return nil, errOrder.ErrCheckInClosed
+	}
+
+	err = o.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		locked, txErr := o.repository.GetOrder().FindByIDForUpdate(c, tx, order.ID)
+		if txErr != nil {
+			return txErr
+		}
+
+		if locked.CheckedInAt != nil {
+			return errOrder.ErrAlreadyCheckedIn
+		}
+
+		if locked.NoShowAt != nil {
+			return errOrder.ErrCheckInClosed
+		}
+
+		return o.updateOrder(c, tx, order, &models.Order{
+			CheckedInAt: &now,
+			CheckedInBy: &staff.UUID,
+		}, constants.CheckedInString)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return o.GetByUUID(c, order.UUID.String())
+}
+
+// MarkNoShows marks the paid orders whose last slot ended without a check-in
+// and returns how many it marked. Orders are read in batches of
+// noShowBatchSize; an order that fails is logged and left for the next run.
+func (o *OrderService) MarkNoShows(c context.Context) (int, error) {
+	now := time.Now()
+	marked := 0
+	var afterID uint
+	for {
+		orders, err := o.repository.GetOrder().FindAwaitingCheckIn(c, now, afterID, noShowBatchSize)
+		if err != nil {
+			return marked, err
+		}
+
+		if len(orders) == 0 {
+			return marked, nil
+		}
+
+		count, err := o.markNoShowBatch(c, orders, now)
+		marked += count
+		if err != nil {
+			return marked, err
+		}
+
+		if len(orders) < noShowBatchSize {
+			return marked, nil
+		}
+		afterID = orders[len(orders)-1].ID
+	}
+}
+
+// markNoShowBatch marks the orders of one batch whose last slot ended before
+// now.
+func (o *OrderService) markNoShowBatch(c context.Context, orders []models.Order, now time.Time) (int, error) {
+	orderIDs := make([]uint, 0, len(orders))
+	for _, order := range orders {
+		orderIDs = append(orderIDs, order.ID)
+	}
+
+	orderFields, err := o.repository.GetOrderField().FindByOrderIDs(c, orderIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	scheduleIDs := make([]string, 0, len(orderFields))
+	for _, item := range orderFields {
+		scheduleIDs = append(scheduleIDs, item.FieldScheduleID.String())
+	}
+
+	schedules, err := o.client.GetField().GetFieldSchedulesByUUIDs(c, scheduleIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	endAt := make(map[uuid.UUID]time.Time, len(schedules))
+	for _, schedule := range schedules {
+		endAt[schedule.UUID] = schedule.EndAt
+	}
+
+	lastEndAt := make(map[uint]time.Time, len(orders))
+	for _, item := range orderFields {
+		end, ok := endAt[item.FieldScheduleID]
+		if ok && end.After(lastEndAt[item.OrderID]) {
+			lastEndAt[item.OrderID] = end
+		}
+	}
+
+	marked := 0
+	for i := range orders {
+		end, ok := lastEndAt[orders[i].ID]
+		if !ok || end.After(now) {
+			continue
+		}
+
+		err = o.markNoShow(c, &orders[i], now)
+		if err != nil {
+			logrus.Errorf("no-show marker: order %s: %v", orders[i].Code, err)
+			continue
+		}
+		marked++
+	}
+
+	return marked, nil
+}
+
+// GetAttendance counts the check-ins and no-shows of a customer, for
+// policies such as requiring full prepayment from those who often do not
+// show up.
+func (o *OrderService) GetAttendance(c context.Context, userID string) (*dto.CustomerAttendanceResponse, error) {
+	parsed, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errOrder.ErrCustomerNotFound
+	}
+
+	return o.repository.GetOrder().CountAttendanceByUserID(c, parsed)
+}
+
+func (o *OrderService) markNoShow(c context.Context, order *models.Order, now time.Time) error {
+	return o.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		locked, err := o.repository.GetOrder().FindByIDForUpdate(c, tx, order.ID)
+		if err != nil {
+			return err
+		}
+
+		// The customer may have checked in since the orders were read.
+		if locked.CheckedInAt != nil || locked.NoShowAt != nil {
+			return nil
+		}
+
+		return o.updateOrder(c, tx, order, &models.Order{NoShowAt: &now}, constants.NoShowString)
+	})
+}
+
+func (o *OrderService) orderSchedules(c context.Context, order *models.Order) ([]clientField.FieldScheduleDetailData, error) {
+	orderFields, err := o.repository.GetOrderField().FindByOrderID(c, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduleIDs := make([]string, 0, len(orderFields))
+	for _, item := range orderFields {
+		scheduleIDs = append(scheduleIDs, item.FieldScheduleID.String())
+	}
+
+	return o.client.GetField().GetFieldSchedulesByUUIDs(c, scheduleIDs)
+}
+
+// checkInWindow opens checkInOpenMinutes before the first slot and closes
+// when the last one ends.
+func checkInWindow(schedules []clientField.FieldScheduleDetailData) (time.Time, time.Time) {
+	var opensAt, closesAt time.Time
+	for _, schedule := range schedules {
+		if opensAt.IsZero() || schedule.StartAt.Before(opensAt) {
+			opensAt = schedule.StartAt
+		}
+		if schedule.EndAt.After(closesAt) {
+			closesAt = schedule.EndAt
+		}
+	}
+
+	openMinutes := config.Cfg.CheckInOpenMinutes
+	if openMinutes <= 0 {
+		openMinutes = defaultCheckInOpenMinutes
+	}
+
+	return opensAt.Add(-time.Duration(openMinutes) * time.Minute), closesAt
+}
+
+// checkInToken signs the UUID of the order, so staff can trust a scanned code
+// without a lookup by the customer.
+func checkInToken(orderUUID uuid.UUID) string {
+	return fmt.Sprintf("%s.%s", orderUUID, signCheckIn(orderUUID))
+}
+
+func parseCheckInToken(token string) (uuid.UUID, error) {
+	id, mac, found := strings.Cut(token, ".")
+	if !found {
+		return uuid.Nil, errOrder.ErrInvalidCheckInToken
+	}
+
+	orderUUID, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, errOrder.ErrInvalidCheckInToken
+	}
+
+	if !hmac.Equal([]byte(mac), []byte(signCheckIn(orderUUID))) {
+		return uuid.Nil, errOrder.ErrInvalidCheckInToken
+	}
+
+	return orderUUID, nil
+}
+
+func signCheckIn(orderUUID uuid.UUID) string {
+	mac := hmac.New(sha256.New, []byte(config.Cfg.CheckInSecret))
+	mac.Write([]byte("check-in\n" + orderUUID.String()))
+	return hex.EncodeToString(mac.Sum(nil))
+}
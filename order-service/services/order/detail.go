@@ -0,0 +1,175 @@
+package services
+
+import (
+	"context"
+	clientField "order-service/clients/field"
+	clientPayment "order-service/clients/payment"
+	clientUser "order-service/clients/user"
+	"order-service/constants"
+	errOrder "order-service/constants/error/order"
+	"order-service/domain/dto"
+	"order-service/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// GetDetail returns the order with its schedules, add-ons, payment and
+// history. Each downstream service is called once, whatever the number of
+// schedules. Customers only see their own orders.
+func (o *OrderService) GetDetail(c context.Context, uuid string) (*dto.OrderDetailResponse, error) {
+	user := c.Value(constants.User).(*clientUser.UserData)
+
+	order, err := o.repository.GetOrder().FindByUUID(c, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Role == constants.Customer && order.UserID != user.UUID {
+		return nil, errOrder.ErrOrderNotFound
+	}
+
+	userName, err := o.customerName(c, order)
+	if err != nil {
+		return nil, err
+	}
+
+	orderFields, err := o.repository.GetOrderField().FindByOrderID(c, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduleIDs := make([]string, 0, len(orderFields))
+	for _, item := range orderFields {
+		scheduleIDs = append(scheduleIDs, item.FieldScheduleID.String())
+	}
+
+	schedules, err := o.client.GetField().GetFieldSchedulesByUUIDs(c, scheduleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	orderAddOns, err := o.repository.GetOrderAddOn().FindByOrderID(c, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	shares, err := o.repository.GetOrderShare().FindByOrderID(c, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	histories, err := o.repository.GetOrderHistory().FindByOrderID(c, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Orders taken at the venue are not paid through payment-service, and
+	// each share of a split order has its own payment.
+	var payment *clientPayment.PaymentData
+	if order.Channel != constants.VenueChannel && len(shares) == 0 {
+		payment, err = o.client.GetPayment().GetPaymentUUID(c, order.PaymentID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &dto.OrderDetailResponse{
+		UUID:          order.UUID,
+		Code:          order.Code,
+		UserName:      userName,
+		Amount:        order.Amount,
+		Deposit:       order.Deposit,
+		AmountPaid:    order.PaidAmount(),
+		Outstanding:   order.Outstanding(),
+		Status:        order.Status.GetStatusString(),
+		Channel:       order.Channel,
+		PaymentMethod: order.PaymentMethod,
+		CheckedInAt:   order.CheckedInAt,
+		NoShowAt:      order.NoShowAt,
+		Schedules:     toScheduleResponses(orderFields, schedules),
+		AddOns:        toAddOnResponses(orderAddOns),
+		Payment:       toPaymentResponse(payment),
+		Shares:        toShareResponses(shares),
+		Histories:     toHistoryResponses(histories),
+		OrderDate:     order.Date,
+		CreatedAt:     *order.CreatedAt,
+		UpdatedAt:     *order.UpdatedAt,
+	}, nil
+}
+
+// toScheduleResponses keeps the order of orderFields and leaves the details
+// of a schedule field-service did not return empty.
+func toScheduleResponses(orderFields []models.OrderField, schedules []clientField.FieldScheduleDetailData) []dto.OrderScheduleResponse {
+	schedulesByID := make(map[uuid.UUID]*clientField.FieldScheduleDetailData, len(schedules))
+	for i := range schedules {
+		schedulesByID[schedules[i].UUID] = &schedules[i]
+	}
+
+	responses := make([]dto.OrderScheduleResponse, 0, len(orderFields))
+	for _, item := range orderFields {
+		response := dto.OrderScheduleResponse{FieldScheduleID: item.FieldScheduleID}
+		if schedule, ok := schedulesByID[item.FieldScheduleID]; ok {
+			response.FieldID = &schedule.FieldUUID
+			response.FieldName = schedule.FieldName
+			response.Location = schedule.Location
+			response.Date = schedule.Date
+			response.StartTime = schedule.StartAt.Format("15:04")
+			response.EndTime = schedule.EndAt.Format("15:04")
+			response.Status = schedule.Status
+		}
+		responses = append(responses, response)
+	}
+
+	return responses
+}
+
+func toAddOnResponses(orderAddOns []models.OrderAddOn) []dto.OrderAddOnResponse {
+	responses := make([]dto.OrderAddOnResponse, 0, len(orderAddOns))
+	for _, item := range orderAddOns {
+		responses = append(responses, dto.OrderAddOnResponse{
+			AddOnID:  item.AddOnID,
+			Name:     item.Name,
+			Price:    item.Price,
+			Quantity: item.Quantity,
+			Amount:   item.Amount(),
+		})
+	}
+
+	return responses
+}
+
+func toPaymentResponse(payment *clientPayment.PaymentData) *dto.OrderPaymentResponse {
+	if payment == nil {
+		return nil
+	}
+
+	return &dto.OrderPaymentResponse{
+		UUID:          payment.UUID,
+		Amount:        payment.Amount,
+		OrderAmount:   payment.OrderAmount,
+		Balance:       payment.Balance,
+		Status:        payment.Status,
+		PaymentLink:   payment.PaymentLink,
+		InvoiceLink:   payment.InvoiceLink,
+		VANumber:      payment.VaNumber,
+		Bank:          payment.Bank,
+		Acquirer:      payment.Acquirer,
+		TransactionID: payment.TransactionID,
+		PaidAt:        payment.PaidAt,
+		BalancePaidAt: payment.BalancePaidAt,
+		ExpiredAt:     payment.ExpiredAt,
+	}
+}
+
+func toHistoryResponses(histories []models.OrderHistory) []dto.OrderHistoryResponse {
+	responses := make([]dto.OrderHistoryResponse, 0, len(histories))
+	for _, item := range histories {
+		response := dto.OrderHistoryResponse{Status: item.Status}
+		if item.CreatedAt != nil {
+			response.CreatedAt = *item.CreatedAt
+		}
+		responses = append(responses, response)
+	}
+
+	return responses
+}
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	clients "order-service/clients"
+	clientField "order-service/clients/field"
+	clientPayment "order-service/clients/payment"
+	"order-service/repositories"
+	repoOrder "order-service/repositories/order"
+	repoOrderAddOn "order-service/repositories/orderaddon"
+	repoOrderField "order-service/repositories/orderfield"
+	repoOrderHistory "order-service/repositories/orderhistory"
+	repoOrderShare "order-service/repositories/ordershare"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// fakeRepositoryRegistry hands out the fake repositories a test sets and
+// panics on any other, through the embedded nil interface.
+type fakeRepositoryRegistry struct {
+	repositories.IRepositoryRegistry
+	db           *gorm.DB
+	order        repoOrder.IOrderRepository
+	orderField   repoOrderField.IOrderFieldRepository
+	orderAddOn   repoOrderAddOn.IOrderAddOnRepository
+	orderHistory repoOrderHistory.IOrderHistoryRepository
+	share        repoOrderShare.IOrderShareRepository
+}
+
+func (f *fakeRepositoryRegistry) GetOrder() repoOrder.IOrderRepository { return f.order }
+func (f *fakeRepositoryRegistry) GetOrderField() repoOrderField.IOrderFieldRepository {
+	return f.orderField
+}
+func (f *fakeRepositoryRegistry) GetOrderAddOn() repoOrderAddOn.IOrderAddOnRepository {
+	return f.orderAddOn
+}
+func (f *fakeRepositoryRegistry) GetOrderHistory() repoOrderHistory.IOrderHistoryRepository {
+	return f.orderHistory
+}
+func (f *fakeRepositoryRegistry) GetOrderShare() repoOrderShare.IOrderShareRepository {
+	return f.share
+}
+func (f *fakeRepositoryRegistry) GetTx() *gorm.DB { return f.db }
+
+type fakeClientRegistry struct {
+	clients.IClientRegistry
+	field   clientField.IFieldClient
+	payment clientPayment.IPaymentClient
+}
+
+func (f *fakeClientRegistry) GetField() clientField.IFieldClient       { return f.field }
+func (f *fakeClientRegistry) GetPayment() clientPayment.IPaymentClient { return f.payment }
+
+// fakeConnPool lets gorm open and commit transactions without a database.
+// Every query fails, so repositories must be faked.
+type fakeConnPool struct{}
+
+var errNoDatabase = errors.New("no database in tests")
+
+func (fakeConnPool) PrepareContext(context.Context, string) (*sql.Stmt, error) {
+	return nil, errNoDatabase
+}
+func (fakeConnPool) ExecContext(context.Context, string, ...any) (sql.Result, error) {
+	return driver.RowsAffected(0), errNoDatabase
+}
+func (fakeConnPool) QueryContext(context.Context, string, ...any) (*sql.Rows, error) {
+	return nil, errNoDatabase
+}
+func (fakeConnPool) QueryRowContext(context.Context, string, ...any) *sql.Row { return nil }
+func (fakeConnPool) BeginTx(context.Context, *sql.TxOptions) (gorm.ConnPool, error) {
+	return &fakeTx{}, nil
+}
+
+// fakeTx is used as a pointer, as gorm checks it for nil through reflection.
+type fakeTx struct{ fakeConnPool }
+
+func (*fakeTx) Commit() error   { return nil }
+func (*fakeTx) Rollback() error { return nil }
+
+func newFakeDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(nil, &gorm.Config{ConnPool: fakeConnPool{}})
+	if err != nil {
+		t.Fatalf("open fake db: %v", err)
+	}
+	return db
+}
@@ -0,0 +1,164 @@
+package services
+
+import (
+	"context"
+	clientUser "order-service/clients/user"
+	"order-service/constants"
+	errOrder "order-service/constants/error/order"
+	"order-service/domain/dto"
+	"order-service/domain/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CreateManual takes an order at the venue for a customer with an account or
+// a guest. It is paid on the spot, so the schedules are booked at once and no
+// payment link is created. A complimentary order costs nothing.
+func (o *OrderService) CreateManual(c context.Context, req *dto.ManualOrderRequest) (*dto.OrderResponse, error) {
+	admin := c.Value(constants.User).(*clientUser.UserData)
+
+	customer := &models.Order{
+		CustomerName:  req.CustomerName,
+		CustomerPhone: req.CustomerPhone,
+	}
+	userName := req.CustomerName
+	if req.UserID != "" {
+		user, err := o.client.GetUser().GetUserByUUID(c, uuid.MustParse(req.UserID))
+		if err != nil {
+			return nil, err
+		}
+
+		// user-service answers an unknown user with empty data.
+		if user.UUID == uuid.Nil {
+			return nil, errOrder.ErrCustomerNotFound
+		}
+
+		customer.UserID = user.UUID
+		customer.CustomerName = user.Name
+		customer.CustomerPhone = user.PhoneNumber
+		userName = user.Username
+	}
+
+	fields, err := o.availableFields(c, req.FieldScheduleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var amount float64
+	venueIDs := make(map[uuid.UUID]bool)
+	for _, field := range fields {
+		amount += schedulePrice(field)
+		if field.VenueID != nil {
+			venueIDs[*field.VenueID] = true
+		}
+	}
+
+	orderAddOns, addOnAmount, err := o.prepareAddOns(c, req.AddOns, venueIDs)
+	if err != nil {
+		return nil, err
+	}
+	amount += addOnAmount
+
+	if req.PaymentMethod == constants.ComplimentaryPayment {
+		amount = 0
+	}
+
+	var order *models.Order
+	now := time.Now()
+	err = o.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		var txErr error
+		order, txErr = o.repository.GetOrder().Create(c, tx, &models.Order{
+			UserID:        customer.UserID,
+			Amount:        amount,
+			AmountPaid:    amount,
+			Date:          now,
+			Status:        constants.PaymentSuccess,
+			IsPaid:        true,
+			PaidAt:        &now,
+			Channel:       constants.VenueChannel,
+			PaymentMethod: req.PaymentMethod,
+			CreatedBy:     &admin.UUID,
+			CustomerName:  customer.CustomerName,
+			CustomerPhone: customer.CustomerPhone,
+		})
+		if txErr != nil {
+			return txErr
+		}
+
+		orderFieldSchedules := make([]models.OrderField, 0, len(req.FieldScheduleIDs))
+		for _, fieldID := range req.FieldScheduleIDs {
+			orderFieldSchedules = append(orderFieldSchedules, models.OrderField{
+				OrderID:         order.ID,
+				FieldScheduleID: uuid.MustParse(fieldID),
+			})
+		}
+
+		txErr = o.repository.GetOrderField().Create(c, tx, orderFieldSchedules)
+		if txErr != nil {
+			return txErr
+		}
+
+		for i := range orderAddOns {
+			orderAddOns[i].OrderID = order.ID
+		}
+		txErr = o.repository.GetOrderAddOn().Create(c, tx, orderAddOns)
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = o.repository.GetOrderHistory().Create(c, tx, &dto.OrderHistoryRequest{
+			Status:  constants.PaymentSuccessString,
+			OrderID: order.ID,
+		})
+		if txErr != nil {
+			return txErr
+		}
+
+		// field-service is called last so its failure rolls the order back.
+		return o.bookSchedules(c, order, req.FieldScheduleIDs, orderAddOns)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.OrderResponse{
+		UUID:          order.UUID,
+		Code:          order.Code,
+		UserName:      userName,
+		Amount:        order.Amount,
+		AmountPaid:    order.AmountPaid,
+		Status:        order.Status.GetStatusString(),
+		Channel:       order.Channel,
+		PaymentMethod: order.PaymentMethod,
+		OrderDate:     order.Date,
+		CreatedAt:     *order.CreatedAt,
+		UpdatedAt:     *order.UpdatedAt,
+	}, nil
+}
+
+// GetSummary reports what was paid for the orders placed in the given days,
+// keeping the orders taken at the venue apart from those paid online.
+func (o *OrderService) GetSummary(c context.Context, param *dto.OrderSummaryParam) (*dto.OrderSummaryResponse, error) {
+	from, _ := time.ParseInLocation(time.DateOnly, param.From, time.Local)
+	to, _ := time.ParseInLocation(time.DateOnly, param.To, time.Local)
+	if to.Before(from) {
+		return nil, errOrder.ErrInvalidDateRange
+	}
+
+	summaries, err := o.repository.GetOrder().SummarizePaid(c, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	if summaries == nil {
+		summaries = []dto.OrderChannelSummary{}
+	}
+
+	return &dto.OrderSummaryResponse{
+		From:     param.From,
+		To:       param.To,
+		Channels: summaries,
+	}, nil
+}
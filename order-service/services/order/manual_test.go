@@ -0,0 +1,154 @@
+package services
+
+import (
+	"context"
+	"errors"
+	clientField "order-service/clients/field"
+	clientUser "order-service/clients/user"
+	"order-service/constants"
+	"order-service/domain/dto"
+	"order-service/domain/models"
+	repoOrder "order-service/repositories/order"
+	repoOrderAddOn "order-service/repositories/orderaddon"
+	repoOrderField "order-service/repositories/orderfield"
+	repoOrderHistory "order-service/repositories/orderhistory"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type fakeFieldClient struct {
+	clientField.IFieldClient
+	booked    []*dto.UpdateFieldScheduleStatusRequest
+	bookError error
+}
+
+func (f *fakeFieldClient) GetFieldByUUID(_ context.Context, id uuid.UUID) (*clientField.FieldData, error) {
+	return &clientField.FieldData{
+		UUID:      id,
+		FieldName: "Pitch A",
+		Price:     150000,
+		Status:    constants.AvailableFieldStatus.String(),
+	}, nil
+}
+
+func (f *fakeFieldClient) UpdateStatus(request *dto.UpdateFieldScheduleStatusRequest) error {
+	f.booked = append(f.booked, request)
+	return f.bookError
+}
+
+type fakeOrderRepository struct {
+	repoOrder.IOrderRepository
+}
+
+func (f *fakeOrderRepository) Create(_ context.Context, _ *gorm.DB, order *models.Order) (*models.Order, error) {
+	now := time.Now()
+	order.ID = 1
+	order.UUID = uuid.New()
+	order.Code = "ORD-00001"
+	order.CreatedAt = &now
+	order.UpdatedAt = &now
+	return order, nil
+}
+
+// fakeOrderFieldRepository only keeps what was created, like a transaction
+// that has not committed: nothing can be read back.
+type fakeOrderFieldRepository struct {
+	repoOrderField.IOrderFieldRepository
+	created []models.OrderField
+}
+
+func (f *fakeOrderFieldRepository) Create(_ context.Context, _ *gorm.DB, orderFields []models.OrderField) error {
+	f.created = append(f.created, orderFields...)
+	return nil
+}
+
+func (f *fakeOrderFieldRepository) FindByOrderID(context.Context, uint) ([]models.OrderField, error) {
+	return nil, nil
+}
+
+type fakeOrderAddOnRepository struct {
+	repoOrderAddOn.IOrderAddOnRepository
+}
+
+func (f *fakeOrderAddOnRepository) Create(context.Context, *gorm.DB, []models.OrderAddOn) error {
+	return nil
+}
+
+func (f *fakeOrderAddOnRepository) FindByOrderID(context.Context, uint) ([]models.OrderAddOn, error) {
+	return nil, nil
+}
+
+type fakeOrderHistoryRepository struct {
+	repoOrderHistory.IOrderHistoryRepository
+}
+
+func (f *fakeOrderHistoryRepository) Create(context.Context, *gorm.DB, *dto.OrderHistoryRequest) error {
+	return nil
+}
+
+func newManualOrderService(t *testing.T, field *fakeFieldClient) *OrderService {
+	return &OrderService{
+		repository: &fakeRepositoryRegistry{
+			db:           newFakeDB(t),
+			order:        &fakeOrderRepository{},
+			orderField:   &fakeOrderFieldRepository{},
+			orderAddOn:   &fakeOrderAddOnRepository{},
+			orderHistory: &fakeOrderHistoryRepository{},
+		},
+		client: &fakeClientRegistry{field: field},
+	}
+}
+
+func adminContext() context.Context {
+	return context.WithValue(context.Background(), constants.User, &clientUser.UserData{UUID: uuid.New()})
+}
+
+func TestCreateManualBooksTheSchedules(t *testing.T) {
+	field := &fakeFieldClient{}
+	service := newManualOrderService(t, field)
+	scheduleIDs := []string{uuid.NewString(), uuid.NewString()}
+
+	order, err := service.CreateManual(adminContext(), &dto.ManualOrderRequest{
+		FieldScheduleIDs: scheduleIDs,
+		CustomerName:     "Walk-in",
+		PaymentMethod:    constants.CashPayment,
+	})
+	if err != nil {
+		t.Fatalf("CreateManual: %v", err)
+	}
+
+	if len(field.booked) != 1 {
+		t.Fatalf("field-service was asked to book %d times, want 1", len(field.booked))
+	}
+
+	request := field.booked[0]
+	if !slices.Equal(request.FieldScheduleIDs, scheduleIDs) {
+		t.Errorf("booked schedules %v, want %v", request.FieldScheduleIDs, scheduleIDs)
+	}
+
+	if request.OrderCode != order.Code {
+		t.Errorf("booked for order %q, want %q", request.OrderCode, order.Code)
+	}
+
+	if request.UserID != "" {
+		t.Errorf("guest order booked for user %q, want none", request.UserID)
+	}
+}
+
+func TestCreateManualFailsWhenBookingFails(t *testing.T) {
+	field := &fakeFieldClient{bookError: errors.New("schedule already booked")}
+	service := newManualOrderService(t, field)
+
+	_, err := service.CreateManual(adminContext(), &dto.ManualOrderRequest{
+		FieldScheduleIDs: []string{uuid.NewString()},
+		CustomerName:     "Walk-in",
+		PaymentMethod:    constants.CashPayment,
+	})
+	if !errors.Is(err, field.bookError) {
+		t.Fatalf("CreateManual error = %v, want %v", err, field.bookError)
+	}
+}
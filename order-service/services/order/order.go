@@ -13,9 +13,11 @@ import (
 	"order-service/domain/dto"
 	"order-service/domain/models"
 	"order-service/repositories"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
 
@@ -28,8 +30,20 @@ type IOrderService interface {
 	GetAllWithPagination(context.Context, *dto.OrderRequestParam) (*util.PaginationResult, error)
 	GetByUUID(context.Context, string) (*dto.OrderResponse, error)
 	GetOrderByUserId(context.Context) ([]dto.OrderByUserIDResponse, error)
+	GetCalendar(context.Context) ([]byte, error)
 	Create(context.Context, *dto.OrderRequest) (*dto.OrderResponse, error)
 	HandlePayment(context.Context, *dto.PaymentData) error
+	HandleFieldBlackout(context.Context, *dto.FieldBlackoutData) error
+	Reschedule(context.Context, string, *dto.RescheduleOrderRequest) (*dto.RescheduleOrderResponse, error)
+	RecordVenuePayment(context.Context, string, *dto.VenuePaymentRequest) (*dto.OrderResponse, error)
+	GetShares(context.Context, string) (*dto.OrderSharesResponse, error)
+	CreateManual(context.Context, *dto.ManualOrderRequest) (*dto.OrderResponse, error)
+	GetSummary(context.Context, *dto.OrderSummaryParam) (*dto.OrderSummaryResponse, error)
+	GetCheckInToken(context.Context, string) (*dto.CheckInTokenResponse, error)
+	CheckIn(context.Context, *dto.CheckInRequest) (*dto.OrderResponse, error)
+	MarkNoShows(context.Context) (int, error)
+	GetAttendance(context.Context, string) (*dto.CustomerAttendanceResponse, error)
+	GetDetail(context.Context, string) (*dto.OrderDetailResponse, error)
 }
 
 func NewOrderService(repository repositories.IRepositoryRegistry, client clients.IClientRegistry) IOrderService {
@@ -43,19 +57,24 @@ func (o *OrderService) GetAllWithPagination(c context.Context, param *dto.OrderR
 	}
 	orderResult := make([]dto.OrderResponse, 0, len(orders))
 	for _, v := range orders {
-		user, err := o.client.GetUser().GetUserByUUID(c, v.UserID)
+		userName, err := o.customerName(c, &v)
 		if err != nil {
 			return nil, err
 		}
 		orderResult = append(orderResult, dto.OrderResponse{
-			UUID:      v.UUID,
-			Code:      v.Code,
-			UserName:  user.Username,
-			Amount:    v.Amount,
-			Status:    v.Status.GetStatusString(),
-			OrderDate: v.Date,
-			CreatedAt: *v.CreatedAt,
-			UpdatedAt: *v.UpdatedAt,
+			UUID:          v.UUID,
+			Code:          v.Code,
+			UserName:      userName,
+			Amount:        v.Amount,
+			Deposit:       v.Deposit,
+			AmountPaid:    v.PaidAmount(),
+			Outstanding:   v.Outstanding(),
+			Status:        v.Status.GetStatusString(),
+			Channel:       v.Channel,
+			PaymentMethod: v.PaymentMethod,
+			OrderDate:     v.Date,
+			CreatedAt:     *v.CreatedAt,
+			UpdatedAt:     *v.UpdatedAt,
 		})
 	}
 
@@ -72,9 +91,9 @@ func (o *OrderService) GetAllWithPagination(c context.Context, param *dto.OrderR
 
 func (o *OrderService) GetByUUID(c context.Context, uuid string) (*dto.OrderResponse, error) {
 	var (
-		v    *models.Order
-		user *clientUser.UserData
-		err  error
+		v        *models.Order
+		userName string
+		err      error
 	)
 
 	v, err = o.repository.GetOrder().FindByUUID(c, uuid)
@@ -82,20 +101,27 @@ func (o *OrderService) GetByUUID(c context.Context, uuid string) (*dto.OrderResp
 		return nil, err
 	}
 
-	user, err = o.client.GetUser().GetUserByUUID(c, v.UserID)
+	userName, err = o.customerName(c, v)
 	if err != nil {
 		return nil, err
 	}
 
 	resp := dto.OrderResponse{
-		UUID:      v.UUID,
-		Code:      v.Code,
-		UserName:  user.Username,
-		Amount:    v.Amount,
-		Status:    v.Status.GetStatusString(),
-		OrderDate: v.Date,
-		CreatedAt: *v.CreatedAt,
-		UpdatedAt: *v.UpdatedAt,
+		UUID:          v.UUID,
+		Code:          v.Code,
+		UserName:      userName,
+		Amount:        v.Amount,
+		Deposit:       v.Deposit,
+		AmountPaid:    v.PaidAmount(),
+		Outstanding:   v.Outstanding(),
+		Status:        v.Status.GetStatusString(),
+		Channel:       v.Channel,
+		PaymentMethod: v.PaymentMethod,
+		CheckedInAt:   v.CheckedInAt,
+		NoShowAt:      v.NoShowAt,
+		OrderDate:     v.Date,
+		CreatedAt:     *v.CreatedAt,
+		UpdatedAt:     *v.UpdatedAt,
 	}
 
 	return &resp, nil
@@ -114,19 +140,26 @@ func (o *OrderService) GetOrderByUserId(c context.Context) ([]dto.OrderByUserIDR
 
 	orderLists := make([]dto.OrderByUserIDResponse, 0, len(order))
 	for _, item := range order {
-		payment, err := o.client.GetPayment().GetPaymentUUID(c, item.PaymentID)
-		if err != nil {
-			return nil, err
-		}
-
-		orderLists = append(orderLists, dto.OrderByUserIDResponse{
+		outstanding := item.Outstanding()
+		orderList := dto.OrderByUserIDResponse{
 			Code:        item.Code,
 			Amount:      fmt.Sprintf("%s", util.RupiahFormat(&item.Amount)),
+			Outstanding: util.RupiahFormat(&outstanding),
 			Status:      item.Status.GetStatusString(),
 			OrderDate:   item.Date.String(),
-			PaymentLink: payment.PaymentLink,
-			InvoiceLink: payment.InvoiceLink,
-		})
+		}
+
+		// Orders taken at the venue are not paid through payment-service.
+		if item.Channel != constants.VenueChannel {
+			payment, err := o.client.GetPayment().GetPaymentUUID(c, item.PaymentID)
+			if err != nil {
+				return nil, err
+			}
+			orderList.PaymentLink = payment.PaymentLink
+			orderList.InvoiceLink = payment.InvoiceLink
+		}
+
+		orderLists = append(orderLists, orderList)
 	}
 
 	return orderLists, nil
@@ -140,29 +173,53 @@ func (o *OrderService) Create(c context.Context, req *dto.OrderRequest) (*dto.Or
 		field               *clientField.FieldData
 		paymentResponse     *clientPayment.PaymentData
 		orderFieldSchedules = make([]models.OrderField, 0, len(req.FieldScheduleIDs))
+		orderAddOns         []models.OrderAddOn
+		shares              []models.OrderShare
+		fieldAmount         float64
+		addOnAmount         float64
 		totalAmount         float64
+		deposit             float64
+		venueIDs            = make(map[uuid.UUID]bool)
+		depositPercentages  = make([]int, 0, len(req.FieldScheduleIDs))
 	)
 
-	for _, fieldID := range req.FieldScheduleIDs {
-		uuidParsed := uuid.MustParse(fieldID)
-		field, err = o.client.GetField().GetFieldByUUID(c, uuidParsed)
-		if err != nil {
-			return nil, err
-		}
+	fields, err := o.availableFields(c, req.FieldScheduleIDs)
+	if err != nil {
+		return nil, err
+	}
 
-		totalAmount += field.PricePerHour
-		if field.Status == constants.BookedFieldStatus.String() {
-			return nil, errOrder.ErrAlreadyBooked
+	for _, field = range fields {
+		fieldAmount += schedulePrice(field)
+		if field.VenueID != nil {
+			venueIDs[*field.VenueID] = true
 		}
+		depositPercentages = append(depositPercentages, field.DepositPercentage)
+	}
+
+	orderAddOns, addOnAmount, err = o.prepareAddOns(c, req.AddOns, venueIDs)
+	if err != nil {
+		return nil, err
+	}
+	totalAmount = fieldAmount + addOnAmount
+
+	deposit, err = depositAmount(req.PaymentOption, totalAmount, depositPercentages)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.Shares) > 0 && deposit > 0 {
+		return nil, errOrder.ErrSplitWithDeposit
 	}
 
 	err = o.repository.GetTx().Transaction(func(tx *gorm.DB) error {
 		order, txErr = o.repository.GetOrder().Create(c, tx, &models.Order{
-			UserID: user.UUID,
-			Amount: totalAmount,
-			Date:   time.Now(),
-			Status: constants.Pending,
-			IsPaid: false,
+			UserID:  user.UUID,
+			Amount:  totalAmount,
+			Deposit: deposit,
+			Date:    time.Now(),
+			Status:  constants.Pending,
+			IsPaid:  false,
+			Channel: constants.OnlineChannel,
 		})
 		if txErr != nil {
 			return txErr
@@ -181,6 +238,14 @@ func (o *OrderService) Create(c context.Context, req *dto.OrderRequest) (*dto.Or
 			return txErr
 		}
 
+		for i := range orderAddOns {
+			orderAddOns[i].OrderID = order.ID
+		}
+		txErr = o.repository.GetOrderAddOn().Create(c, tx, orderAddOns)
+		if txErr != nil {
+			return txErr
+		}
+
 		txErr = o.repository.GetOrderHistory().Create(c, tx, &dto.OrderHistoryRequest{
 			Status:  constants.Pending.GetStatusString(),
 			OrderID: order.ID,
@@ -191,7 +256,34 @@ func (o *OrderService) Create(c context.Context, req *dto.OrderRequest) (*dto.Or
 
 		expiredAt := time.Now().Add(time.Hour * 1)
 		description := fmt.Sprintf("Payment Rent %s", field.FieldName)
-		paymentResponse, txErr = o.client.GetPayment().CreatePaymentLink(c, &dto.PaymentRequest{
+		if len(req.Shares) > 0 {
+			shares, txErr = o.createShares(c, tx, order, req.Shares, description, expiredAt)
+			if txErr != nil {
+				return txErr
+			}
+
+			return o.repository.GetOrder().Update(c, tx, &models.Order{
+				PaymentID: *shares[0].PaymentID,
+			}, order.UUID)
+		}
+
+		itemDetails := []dto.ItemDetails{
+			{
+				ID:       uuid.New(),
+				Name:     description,
+				Amount:   fieldAmount,
+				Quantity: 1,
+			},
+		}
+		for _, item := range orderAddOns {
+			itemDetails = append(itemDetails, dto.ItemDetails{
+				ID:       item.AddOnID,
+				Name:     item.Name,
+				Amount:   item.Price,
+				Quantity: item.Quantity,
+			})
+		}
+		paymentRequest := &dto.PaymentRequest{
 			OrderID:     order.UUID,
 			ExpiredAt:   expiredAt,
 			Amount:      totalAmount,
@@ -201,15 +293,13 @@ func (o *OrderService) Create(c context.Context, req *dto.OrderRequest) (*dto.Or
 				Email: user.Email,
 				Phone: user.PhoneNumber,
 			},
-			ItemDetails: []dto.ItemDetails{
-				{
-					ID:       uuid.New(),
-					Name:     description,
-					Amount:   totalAmount,
-					Quantity: 1,
-				},
-			},
-		})
+			ItemDetails: itemDetails,
+		}
+		if deposit > 0 {
+			paymentRequest.Amount = deposit
+			paymentRequest.OrderAmount = totalAmount
+		}
+		paymentResponse, txErr = o.client.GetPayment().CreatePaymentLink(c, paymentRequest)
 		if txErr != nil {
 			return txErr
 		}
@@ -227,22 +317,33 @@ func (o *OrderService) Create(c context.Context, req *dto.OrderRequest) (*dto.Or
 		return nil, err
 	}
 
+	o.announceSchedules(c, order, req.FieldScheduleIDs, constants.ReservedScheduleEvent)
+
 	response := dto.OrderResponse{
 		UUID:        order.UUID,
 		Code:        order.Code,
 		UserName:    user.Name,
 		Amount:      order.Amount,
+		Deposit:     order.Deposit,
+		Outstanding: order.Outstanding(),
 		Status:      order.Status.GetStatusString(),
 		OrderDate:   order.Date,
-		PaymentLink: paymentResponse.PaymentLink,
+		Shares:      toShareResponses(shares),
 		CreatedAt:   *order.CreatedAt,
 		UpdatedAt:   *order.UpdatedAt,
 	}
+	if paymentResponse != nil {
+		response.PaymentLink = paymentResponse.PaymentLink
+	} else if len(shares) > 0 {
+		response.PaymentLink = shares[0].PaymentLink
+	}
 
 	return &response, nil
 }
 
-func (o *OrderService) mapPaymentStatusToOrder(req *dto.PaymentData) (constants.OrderStatus, *models.Order) {
+// mapPaymentStatusToOrder returns the changes a payment status makes to
+// current. A paid deposit leaves the order partially paid.
+func (o *OrderService) mapPaymentStatusToOrder(req *dto.PaymentData, current *models.Order) (constants.OrderStatus, *models.Order) {
 
 	var (
 		status constants.OrderStatus
@@ -253,10 +354,19 @@ func (o *OrderService) mapPaymentStatusToOrder(req *dto.PaymentData) (constants.
 	case constants.SettlementPaymentStatus:
 		status = constants.PaymentSuccess
 		order = &models.Order{
-			IsPaid:    true,
-			PaymentID: req.PaymentID,
-			PaidAt:    req.PaidAt,
-			Status:    status,
+			IsPaid:     true,
+			PaymentID:  req.PaymentID,
+			PaidAt:     req.PaidAt,
+			Status:     status,
+			AmountPaid: current.Amount,
+		}
+		if current.Deposit > 0 {
+			status = constants.PartiallyPaid
+			order = &models.Order{
+				PaymentID:  req.PaymentID,
+				Status:     status,
+				AmountPaid: current.Deposit,
+			}
 		}
 	case constants.ExpiredPaymentStatus:
 		status = constants.Expired
@@ -280,19 +390,38 @@ func (o *OrderService) mapPaymentStatusToOrder(req *dto.PaymentData) (constants.
 
 func (o *OrderService) HandlePayment(c context.Context, req *dto.PaymentData) error {
 	var (
-		err, txErr          error
-		order               *models.Order
-		orderFieldSchedules []models.OrderField
+		err, txErr error
+		order      *models.Order
 	)
 
-	status, body := o.mapPaymentStatusToOrder(req)
+	// Reschedules are paid with their own payment link.
+	reschedule, err := o.repository.GetOrderReschedule().FindByUUID(c, req.OrderID.String())
+	if err != nil {
+		return err
+	}
+
+	if reschedule != nil {
+		return o.handleReschedulePayment(c, reschedule, req)
+	}
+
+	// So is every share of a split order.
+	share, err := o.repository.GetOrderShare().FindByUUID(c, req.OrderID.String())
+	if err != nil {
+		return err
+	}
+
+	if share != nil {
+		return o.handleSharePayment(c, share, req)
+	}
+
 	err = o.repository.GetTx().Transaction(func(tx *gorm.DB) error {
-		txErr = o.repository.GetOrder().Update(c, tx, body, req.OrderID)
+		order, txErr = o.repository.GetOrder().FindByUUID(c, req.OrderID.String())
 		if txErr != nil {
 			return txErr
 		}
 
-		order, txErr = o.repository.GetOrder().FindByUUID(c, req.OrderID.String())
+		status, body := o.mapPaymentStatusToOrder(req, order)
+		txErr = o.repository.GetOrder().Update(c, tx, body, req.OrderID)
 		if txErr != nil {
 			return txErr
 		}
@@ -306,29 +435,411 @@ func (o *OrderService) HandlePayment(c context.Context, req *dto.PaymentData) er
 		}
 
 		if req.Status == constants.SettlementPaymentStatus {
-			orderFieldSchedules, txErr = o.repository.GetOrderField().FindByOrderID(c, order.ID)
+			txErr = o.bookOrder(c, order)
 			if txErr != nil {
 				return txErr
 			}
+		}
 
-			fieldScheduleIDs := make([]string, 0, len(orderFieldSchedules))
-			for _, item := range orderFieldSchedules {
-				fieldScheduleIDs = append(fieldScheduleIDs, item.FieldScheduleID.String())
+		if req.Status == constants.ExpiredPaymentStatus {
+			txErr = o.returnAddOnStock(c, order)
+			if txErr != nil {
+				return txErr
 			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if req.Status == constants.ExpiredPaymentStatus {
+		o.announceOrderSchedules(c, order, constants.ReleasedScheduleEvent)
+	}
+
+	return nil
+}
+
+// HandleFieldBlackout cancels the paid orders holding a schedule that was
+// closed by a field blackout, frees their other schedules and refunds what
+// was paid online.
+func (o *OrderService) HandleFieldBlackout(c context.Context, req *dto.FieldBlackoutData) error {
+	if len(req.FieldScheduleIDs) == 0 {
+		return nil
+	}
+
+	orderFields, err := o.repository.GetOrderField().FindByFieldScheduleIDs(c, req.FieldScheduleIDs)
+	if err != nil {
+		return err
+	}
+
+	orderIDs := make([]uint, 0, len(orderFields))
+	seen := make(map[uint]bool, len(orderFields))
+	for _, item := range orderFields {
+		if !seen[item.OrderID] {
+			seen[item.OrderID] = true
+			orderIDs = append(orderIDs, item.OrderID)
+		}
+	}
 
-			txErr = o.client.GetField().UpdateStatus(&dto.UpdateFieldScheduleStatusRequest{
-				FieldScheduleIDs: fieldScheduleIDs,
+	if len(orderIDs) == 0 {
+		return nil
+	}
+
+	orders, err := o.repository.GetOrder().FindByIDs(c, orderIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, order := range orders {
+		if !order.HoldsSchedules() {
+			continue
+		}
+
+		var refunds []models.OrderShare
+		err = o.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+			txErr := o.repository.GetOrder().Update(c, tx, &models.Order{
+				Status: constants.Cancelled,
+			}, order.UUID)
+			if txErr != nil {
+				return txErr
+			}
+
+			txErr = o.repository.GetOrderHistory().Create(c, tx, &dto.OrderHistoryRequest{
+				Status:  constants.Cancelled.GetStatusString(),
+				OrderID: order.ID,
 			})
 			if txErr != nil {
 				return txErr
 			}
+
+			refunds, txErr = o.markSharesRefundDue(c, tx, &order)
+			return txErr
+		})
+		if err != nil {
+			return err
+		}
+
+		err = o.returnAddOnStock(c, &order)
+		if err != nil {
+			return err
+		}
+
+		reason := fmt.Sprintf("order %s cancelled by field blackout: %s", order.Code, req.Reason)
+		o.releaseSchedules(c, &order)
+		o.refundOrder(c, &order, refunds, reason)
+	}
+
+	return nil
+}
+
+// announceOrderSchedules announces event on the schedule streams of every
+// schedule of the order.
+func (o *OrderService) announceOrderSchedules(c context.Context, order *models.Order, event string) {
+	orderFields, err := o.repository.GetOrderField().FindByOrderID(c, order.ID)
+	if err != nil {
+		logrus.Errorf("failed to find the schedules of order %s: %v", order.Code, err)
+		return
+	}
+
+	fieldScheduleIDs := make([]string, 0, len(orderFields))
+	for _, item := range orderFields {
+		fieldScheduleIDs = append(fieldScheduleIDs, item.FieldScheduleID.String())
+	}
+
+	o.announceSchedules(c, order, fieldScheduleIDs, event)
+}
+
+// announceSchedules lets other customers watching the schedules see an order
+// awaiting payment take or give them back. The order does not depend on it,
+// so a failure is only logged.
+func (o *OrderService) announceSchedules(c context.Context, order *models.Order, fieldScheduleIDs []string, event string) {
+	if len(fieldScheduleIDs) == 0 {
+		return
+	}
+
+	err := o.client.GetField().Announce(c, &dto.AnnounceFieldScheduleRequest{
+		FieldScheduleIDs: fieldScheduleIDs,
+		Event:            event,
+	})
+	if err != nil {
+		logrus.Errorf("failed to announce %s schedules of order %s: %v", event, order.Code, err)
+	}
+}
+
+// markSharesRefundDue marks the paid shares of a cancelled split order
+// refund-due and returns them.
+func (o *OrderService) markSharesRefundDue(c context.Context, tx *gorm.DB, order *models.Order) ([]models.OrderShare, error) {
+	shares, err := o.repository.GetOrderShare().FindByOrderIDForUpdate(c, tx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	refunds := make([]models.OrderShare, 0, len(shares))
+	for _, item := range shares {
+		if item.Status != constants.SharePaid {
+			continue
+		}
+
+		item.Status = constants.ShareRefundDue
+		err = o.repository.GetOrderShare().Update(c, tx, item.ID, &models.OrderShare{
+			Status: item.Status,
+		})
+		if err != nil {
+			return nil, err
+		}
+		refunds = append(refunds, item)
+	}
+
+	return refunds, nil
+}
+
+// releaseSchedules frees the schedules a cancelled order still holds in
+// field-service. A failure is logged, as the order is already cancelled.
+func (o *OrderService) releaseSchedules(c context.Context, order *models.Order) {
+	orderFields, err := o.repository.GetOrderField().FindByOrderID(c, order.ID)
+	if err != nil {
+		logrus.Errorf("failed to find the schedules of cancelled order %s: %v", order.Code, err)
+		return
+	}
+
+	if len(orderFields) == 0 {
+		return
+	}
+
+	fieldScheduleIDs := make([]string, 0, len(orderFields))
+	for _, item := range orderFields {
+		fieldScheduleIDs = append(fieldScheduleIDs, item.FieldScheduleID.String())
+	}
+
+	err = o.client.GetField().Release(c, &dto.ReleaseFieldScheduleRequest{
+		FieldScheduleIDs: fieldScheduleIDs,
+		OrderCode:        order.Code,
+	})
+	if err != nil {
+		logrus.Errorf("failed to release the schedules of cancelled order %s: %v", order.Code, err)
+	}
+}
+
+// refundOrder gives back what was paid online for a cancelled order: the paid
+// shares of a split order, otherwise the payment of the order. Money taken at
+// the venue cannot be refunded through payment-service and is logged for the
+// venue to hand back.
+func (o *OrderService) refundOrder(c context.Context, order *models.Order, shares []models.OrderShare, reason string) {
+	if len(shares) > 0 {
+		o.refundShares(c, order, shares, reason)
+	} else if order.PaymentID != uuid.Nil {
+		err := o.client.GetPayment().Refund(c, order.PaymentID, &dto.RefundRequest{Reason: reason})
+		if err != nil {
+			paid := order.PaidAmount()
+			logrus.Errorf("failed to refund %s on payment %s of order %s: %v",
+				util.RupiahFormat(&paid), order.PaymentID, order.Code, err)
+		}
+	}
+
+	venuePayments, err := o.repository.GetOrderVenuePayment().FindByOrderID(c, order.ID)
+	if err != nil {
+		logrus.Errorf("failed to find the venue payments of order %s: %v", order.Code, err)
+		return
+	}
+
+	var paidAtVenue float64
+	for _, item := range venuePayments {
+		paidAtVenue += item.Amount
+	}
+
+	if paidAtVenue > 0 {
+		logrus.Infof("order %s was cancelled, hand back %s paid at the venue", order.Code, util.RupiahFormat(&paidAtVenue))
+	}
+}
+
+// prepareAddOns prices the add-ons of a new order from the catalogue. Each
+// must be active, offered at the venue of an ordered field and in stock.
+// Stock is only taken once the order is paid.
+func (o *OrderService) prepareAddOns(c context.Context, requests []dto.OrderAddOnRequest, venueIDs map[uuid.UUID]bool) ([]models.OrderAddOn, float64, error) {
+	if len(requests) == 0 {
+		return nil, 0, nil
+	}
+
+	addOnIDs := make([]string, 0, len(requests))
+	quantities := make(map[string]int, len(requests))
+	for _, item := range requests {
+		addOnID := uuid.MustParse(item.AddOnID).String()
+		if _, ok := quantities[addOnID]; !ok {
+			addOnIDs = append(addOnIDs, addOnID)
+		}
+		quantities[addOnID] += item.Quantity
+	}
+
+	addOns, err := o.client.GetField().GetAddOnsByUUIDs(c, addOnIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	byUUID := make(map[string]clientField.AddOnData, len(addOns))
+	for _, addOn := range addOns {
+		byUUID[addOn.UUID.String()] = addOn
+	}
+
+	var amount float64
+	orderAddOns := make([]models.OrderAddOn, 0, len(addOnIDs))
+	for _, addOnID := range addOnIDs {
+		addOn, ok := byUUID[addOnID]
+		if !ok {
+			return nil, 0, errOrder.ErrAddOnNotFound
+		}
+
+		if !addOn.IsActive || !venueIDs[addOn.VenueID] {
+			return nil, 0, errOrder.ErrAddOnUnavailable
+		}
+
+		quantity := quantities[addOnID]
+		if addOn.Stock < quantity {
+			return nil, 0, errOrder.ErrAddOnOutOfStock
+		}
+
+		orderAddOn := models.OrderAddOn{
+			AddOnID:  addOn.UUID,
+			Name:     addOn.Name,
+			Price:    addOn.Price,
+			Quantity: quantity,
+		}
+		amount += orderAddOn.Amount()
+		orderAddOns = append(orderAddOns, orderAddOn)
+	}
+
+	return orderAddOns, amount, nil
+}
+
+// customerName is the username of the customer of the order, or the name a
+// guest gave at the venue.
+func (o *OrderService) customerName(c context.Context, order *models.Order) (string, error) {
+	if order.IsGuest() {
+		return order.CustomerName, nil
+	}
+
+	user, err := o.client.GetUser().GetUserByUUID(c, order.UserID)
+	if err != nil {
+		return "", err
+	}
+
+	return user.Username, nil
+}
+
+// availableFields looks the schedules up and fails unless all of them are
+// available.
+func (o *OrderService) availableFields(c context.Context, fieldScheduleIDs []string) ([]*clientField.FieldData, error) {
+	fields := make([]*clientField.FieldData, 0, len(fieldScheduleIDs))
+	for _, fieldID := range fieldScheduleIDs {
+		field, err := o.client.GetField().GetFieldByUUID(c, uuid.MustParse(fieldID))
+		if err != nil {
+			return nil, err
+		}
+
+		// field-service also reports slots closed for a blackout or blocked by
+		// a booking of the linked full pitch or half, so only an available
+		// slot can be ordered.
+		if !strings.EqualFold(field.Status, constants.AvailableFieldStatus.String()) {
+			return nil, errOrder.ErrAlreadyBooked
 		}
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+// updateOrderStatus saves the changes of the order and records its new status
+// in the history.
+func (o *OrderService) updateOrderStatus(c context.Context, tx *gorm.DB, order *models.Order, changes *models.Order) error {
+	return o.updateOrder(c, tx, order, changes, changes.Status.GetStatusString())
+}
+
+// updateOrder saves the changes of the order and records history for them.
+func (o *OrderService) updateOrder(c context.Context, tx *gorm.DB, order *models.Order, changes *models.Order, history constants.OrderStatusString) error {
+	err := o.repository.GetOrder().Update(c, tx, changes, order.UUID)
+	if err != nil {
+		return err
+	}
+
+	return o.repository.GetOrderHistory().Create(c, tx, &dto.OrderHistoryRequest{
+		Status:  history,
+		OrderID: order.ID,
+	})
+}
+
+// bookOrder books the schedules of a paid order and takes its add-ons.
+func (o *OrderService) bookOrder(c context.Context, order *models.Order) error {
+	orderFieldSchedules, err := o.repository.GetOrderField().FindByOrderID(c, order.ID)
+	if err != nil {
+		return err
+	}
+
+	fieldScheduleIDs := make([]string, 0, len(orderFieldSchedules))
+	for _, item := range orderFieldSchedules {
+		fieldScheduleIDs = append(fieldScheduleIDs, item.FieldScheduleID.String())
+	}
+
+	orderAddOns, err := o.repository.GetOrderAddOn().FindByOrderID(c, order.ID)
+	if err != nil {
+		return err
+	}
+
+	return o.bookSchedules(c, order, fieldScheduleIDs, orderAddOns)
+}
+
+// bookSchedules books the schedules for the order and takes its add-ons. A
+// caller still inside the transaction that created the order passes what it
+// inserted, as the repositories cannot read it back before the commit.
+func (o *OrderService) bookSchedules(c context.Context, order *models.Order, fieldScheduleIDs []string, orderAddOns []models.OrderAddOn) error {
+	request := &dto.UpdateFieldScheduleStatusRequest{
+		FieldScheduleIDs: fieldScheduleIDs,
+		OrderCode:        order.Code,
+	}
+	if !order.IsGuest() {
+		request.UserID = order.UserID.String()
+	}
+
+	err := o.client.GetField().UpdateStatus(request)
+	if err != nil {
+		return err
+	}
+
+	return o.takeAddOnStock(c, order, orderAddOns)
+}
+
+func (o *OrderService) takeAddOnStock(c context.Context, order *models.Order, orderAddOns []models.OrderAddOn) error {
+	if len(orderAddOns) == 0 {
 		return nil
+	}
+
+	items := make([]dto.AddOnStockItem, 0, len(orderAddOns))
+	for _, item := range orderAddOns {
+		items = append(items, dto.AddOnStockItem{
+			AddOnID:  item.AddOnID.String(),
+			Quantity: item.Quantity,
+		})
+	}
+
+	return o.client.GetField().TakeAddOnStock(c, &dto.TakeAddOnStockRequest{
+		OrderID: order.UUID.String(),
+		Items:   items,
 	})
+}
 
+// returnAddOnStock gives back the add-ons of an expired or cancelled order.
+// field-service only returns what the order actually took.
+func (o *OrderService) returnAddOnStock(c context.Context, order *models.Order) error {
+	orderAddOns, err := o.repository.GetOrderAddOn().FindByOrderID(c, order.ID)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	if len(orderAddOns) == 0 {
+		return nil
+	}
+
+	return o.client.GetField().ReturnAddOnStock(c, &dto.ReturnAddOnStockRequest{
+		OrderID: order.UUID.String(),
+	})
 }
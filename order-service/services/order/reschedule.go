@@ -0,0 +1,427 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	clientField "order-service/clients/field"
+	clientPayment "order-service/clients/payment"
+	clientUser "order-service/clients/user"
+	"order-service/common/util"
+	"order-service/config"
+	"order-service/constants"
+	errOrder "order-service/constants/error/order"
+	"order-service/domain/dto"
+	"order-service/domain/models"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const defaultRescheduleWindowHours = 24
+
+// Reschedule moves schedules of a paid order to others of equal or higher
+// price. Both the old and the new schedules must start after the reschedule
+// window. Without a price difference the schedules move at once; otherwise
+// the new schedules are held for the order and it moves when the difference
+// is paid through the returned payment link.
+func (o *OrderService) Reschedule(c context.Context, orderUUID string, req *dto.RescheduleOrderRequest) (*dto.RescheduleOrderResponse, error) {
+	user := c.Value(constants.User).(*clientUser.UserData)
+
+	order, err := o.repository.GetOrder().FindByUUID(c, orderUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if order.UserID != user.UUID {
+		return nil, errOrder.ErrOrderNotFound
+	}
+
+	if !order.HoldsSchedules() {
+		return nil, errOrder.ErrOrderNotReschedulable
+	}
+
+	pending, err := o.repository.GetOrderReschedule().FindPendingByOrderID(c, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if pending != nil {
+		return nil, errOrder.ErrReschedulePending
+	}
+
+	items, err := o.rescheduleItems(c, order, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		amount      float64
+		itemDetails = make([]dto.ItemDetails, 0, len(items))
+	)
+	for _, item := range items {
+		from, err := o.client.GetField().GetFieldByUUID(c, item.FromFieldScheduleID)
+		if err != nil {
+			return nil, err
+		}
+
+		to, err := o.client.GetField().GetFieldByUUID(c, item.ToFieldScheduleID)
+		if err != nil {
+			return nil, err
+		}
+
+		if !strings.EqualFold(to.Status, constants.AvailableFieldStatus.String()) {
+			return nil, errOrder.ErrAlreadyBooked
+		}
+
+		difference := schedulePrice(to) - schedulePrice(from)
+		if difference < 0 {
+			return nil, errOrder.ErrRescheduleCheaper
+		}
+
+		if difference > 0 {
+			amount += difference
+			itemDetails = append(itemDetails, dto.ItemDetails{
+				ID:       uuid.New(),
+				Name:     fmt.Sprintf("Reschedule %s", to.FieldName),
+				Amount:   difference,
+				Quantity: 1,
+			})
+		}
+	}
+
+	reschedule := &models.OrderReschedule{
+		OrderID: order.ID,
+		Amount:  amount,
+		Status:  constants.RescheduleCompleted,
+		Items:   items,
+	}
+
+	if amount == 0 {
+		err = o.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+			txErr := o.lockForReschedule(c, tx, order.ID)
+			if txErr != nil {
+				return txErr
+			}
+
+			txErr = o.repository.GetOrderReschedule().Create(c, tx, reschedule)
+			if txErr != nil {
+				return txErr
+			}
+
+			return o.applyReschedule(c, tx, order, reschedule)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return toRescheduleResponse(order, reschedule, nil), nil
+	}
+
+	var paymentResponse *clientPayment.PaymentData
+	reschedule.Status = constants.ReschedulePending
+	err = o.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		txErr := o.lockForReschedule(c, tx, order.ID)
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = o.repository.GetOrderReschedule().Create(c, tx, reschedule)
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = o.repository.GetOrderHistory().Create(c, tx, &dto.OrderHistoryRequest{
+			Status:  constants.ReschedulePendingString,
+			OrderID: order.ID,
+		})
+		if txErr != nil {
+			return txErr
+		}
+
+		paymentResponse, txErr = o.client.GetPayment().CreatePaymentLink(c, &dto.PaymentRequest{
+			OrderID:     reschedule.UUID,
+			ExpiredAt:   time.Now().Add(time.Hour * 1),
+			Amount:      amount,
+			Description: fmt.Sprintf("Reschedule %s", order.Code),
+			CustomerDetail: dto.CustomerDetail{
+				Name:  user.Name,
+				Email: user.Email,
+				Phone: user.PhoneNumber,
+			},
+			ItemDetails: itemDetails,
+		})
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = o.repository.GetOrderReschedule().Update(c, tx, reschedule.ID, &models.OrderReschedule{
+			PaymentID: &paymentResponse.UUID,
+		})
+		if txErr != nil {
+			return txErr
+		}
+
+		// Without old schedules field-service only takes the new ones,
+		// checking under its lock that they are still available.
+		return o.client.GetField().Reschedule(c, &dto.RescheduleFieldScheduleRequest{
+			ToFieldScheduleIDs: rescheduleTargets(reschedule),
+			OrderCode:          order.Code,
+			UserID:             order.UserID.String(),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toRescheduleResponse(order, reschedule, paymentResponse), nil
+}
+
+// lockForReschedule locks the order and checks again under the lock that it
+// can be rescheduled, so two reschedules of one order cannot interleave.
+func (o *OrderService) lockForReschedule(c context.Context, tx *gorm.DB, orderID uint) error {
+	order, err := o.repository.GetOrder().FindByIDForUpdate(c, tx, orderID)
+	if err != nil {
+		return err
+	}
+
+	if !order.HoldsSchedules() {
+		return errOrder.ErrOrderNotReschedulable
+	}
+
+	pending, err := o.repository.GetOrderReschedule().FindPendingByOrderID(c, order.ID)
+	if err != nil {
+		return err
+	}
+
+	if pending != nil {
+		return errOrder.ErrReschedulePending
+	}
+
+	return nil
+}
+
+// rescheduleItems checks that every old schedule belongs to the order, that
+// no schedule is listed twice and that all of them start after the
+// reschedule window.
+func (o *OrderService) rescheduleItems(c context.Context, order *models.Order, req *dto.RescheduleOrderRequest) ([]models.OrderRescheduleItem, error) {
+	orderFields, err := o.repository.GetOrderField().FindByOrderID(c, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	inOrder := make(map[uuid.UUID]bool, len(orderFields))
+	for _, item := range orderFields {
+		inOrder[item.FieldScheduleID] = true
+	}
+
+	items := make([]models.OrderRescheduleItem, 0, len(req.Schedules))
+	scheduleIDs := make([]string, 0, len(req.Schedules)*2)
+	seen := make(map[uuid.UUID]bool, len(req.Schedules)*2)
+	for _, schedule := range req.Schedules {
+		from := uuid.MustParse(schedule.FromFieldScheduleID)
+		to := uuid.MustParse(schedule.ToFieldScheduleID)
+		if !inOrder[from] {
+			return nil, errOrder.ErrScheduleNotInOrder
+		}
+
+		if seen[from] || seen[to] || inOrder[to] {
+			return nil, errOrder.ErrDuplicateSchedule
+		}
+		seen[from] = true
+		seen[to] = true
+
+		items = append(items, models.OrderRescheduleItem{
+			FromFieldScheduleID: from,
+			ToFieldScheduleID:   to,
+		})
+		scheduleIDs = append(scheduleIDs, from.String(), to.String())
+	}
+
+	schedules, err := o.client.GetField().GetFieldSchedulesByUUIDs(c, scheduleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// field-service leaves unknown schedules out of the lookup.
+	if len(schedules) != len(scheduleIDs) {
+		return nil, errOrder.ErrScheduleNotInOrder
+	}
+
+	deadline := time.Now().Add(time.Duration(rescheduleWindowHours()) * time.Hour)
+	for _, schedule := range schedules {
+		if schedule.StartAt.Before(deadline) {
+			return nil, errOrder.ErrRescheduleWindowClosed
+		}
+	}
+
+	return items, nil
+}
+
+// applyReschedule points the order at the new schedules and has
+// field-service move the bookings. A reschedule with a price difference
+// already holds its new schedules, so only the old ones are released.
+// field-service is called last so its failure rolls the order changes back.
+func (o *OrderService) applyReschedule(c context.Context, tx *gorm.DB, order *models.Order, reschedule *models.OrderReschedule) error {
+	request := &dto.RescheduleFieldScheduleRequest{
+		FromFieldScheduleIDs: make([]string, 0, len(reschedule.Items)),
+		ToFieldScheduleIDs:   make([]string, 0, len(reschedule.Items)),
+		OrderCode:            order.Code,
+		UserID:               order.UserID.String(),
+	}
+	for _, item := range reschedule.Items {
+		err := o.repository.GetOrderField().UpdateFieldScheduleID(c, tx, order.ID, item.FromFieldScheduleID, item.ToFieldScheduleID)
+		if err != nil {
+			return err
+		}
+
+		request.FromFieldScheduleIDs = append(request.FromFieldScheduleIDs, item.FromFieldScheduleID.String())
+		request.ToFieldScheduleIDs = append(request.ToFieldScheduleIDs, item.ToFieldScheduleID.String())
+	}
+
+	err := o.repository.GetOrderHistory().Create(c, tx, &dto.OrderHistoryRequest{
+		Status:  constants.RescheduledString,
+		OrderID: order.ID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if reschedule.Amount > 0 {
+		return o.client.GetField().Release(c, &dto.ReleaseFieldScheduleRequest{
+			FieldScheduleIDs: request.FromFieldScheduleIDs,
+			OrderCode:        order.Code,
+		})
+	}
+
+	return o.client.GetField().Reschedule(c, request)
+}
+
+// handleReschedulePayment settles or expires the payment of a reschedule.
+// When the order cannot be moved once paid, the reschedule fails and its
+// payment is refunded. Either way the held schedules are released.
+func (o *OrderService) handleReschedulePayment(c context.Context, reschedule *models.OrderReschedule, req *dto.PaymentData) error {
+	if reschedule.Status != constants.ReschedulePending {
+		return nil
+	}
+
+	order := &reschedule.Order
+	switch req.Status {
+	case constants.SettlementPaymentStatus:
+		err := o.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+			txErr := o.repository.GetOrderReschedule().Update(c, tx, reschedule.ID, &models.OrderReschedule{
+				Status: constants.RescheduleCompleted,
+				PaidAt: req.PaidAt,
+			})
+			if txErr != nil {
+				return txErr
+			}
+
+			txErr = o.repository.GetOrder().Update(c, tx, &models.Order{
+				Amount:     order.Amount + reschedule.Amount,
+				AmountPaid: order.PaidAmount() + reschedule.Amount,
+			}, order.UUID)
+			if txErr != nil {
+				return txErr
+			}
+
+			return o.applyReschedule(c, tx, order, reschedule)
+		})
+		if err == nil {
+			return nil
+		}
+
+		logrus.Errorf("failed to reschedule order %s: %v", order.Code, err)
+		err = o.closeReschedule(c, reschedule, constants.RescheduleFailed, constants.RescheduleFailedString)
+		if err != nil {
+			return err
+		}
+
+		o.releaseHeldSchedules(c, order, reschedule)
+		err = o.client.GetPayment().Refund(c, req.PaymentID, &dto.RefundRequest{
+			Reason: fmt.Sprintf("reschedule of order %s failed", order.Code),
+		})
+		if err != nil {
+			logrus.Errorf("failed to refund %s on payment %s of reschedule %s: %v",
+				util.RupiahFormat(&reschedule.Amount), req.PaymentID, reschedule.UUID, err)
+		}
+		return nil
+	case constants.ExpiredPaymentStatus:
+		err := o.closeReschedule(c, reschedule, constants.RescheduleExpired, constants.RescheduleExpiredString)
+		if err != nil {
+			return err
+		}
+
+		o.releaseHeldSchedules(c, order, reschedule)
+		return nil
+	}
+
+	return nil
+}
+
+// releaseHeldSchedules frees the new schedules held for a reschedule that
+// did not go through. A failure is logged, as the reschedule is closed.
+func (o *OrderService) releaseHeldSchedules(c context.Context, order *models.Order, reschedule *models.OrderReschedule) {
+	err := o.client.GetField().Release(c, &dto.ReleaseFieldScheduleRequest{
+		FieldScheduleIDs: rescheduleTargets(reschedule),
+		OrderCode:        order.Code,
+	})
+	if err != nil {
+		logrus.Errorf("failed to release the schedules held for reschedule %s of order %s: %v", reschedule.UUID, order.Code, err)
+	}
+}
+
+func rescheduleTargets(reschedule *models.OrderReschedule) []string {
+	ids := make([]string, 0, len(reschedule.Items))
+	for _, item := range reschedule.Items {
+		ids = append(ids, item.ToFieldScheduleID.String())
+	}
+	return ids
+}
+
+func (o *OrderService) closeReschedule(c context.Context, reschedule *models.OrderReschedule, status constants.RescheduleStatus, history constants.OrderStatusString) error {
+	return o.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		err := o.repository.GetOrderReschedule().Update(c, tx, reschedule.ID, &models.OrderReschedule{
+			Status: status,
+		})
+		if err != nil {
+			return err
+		}
+
+		return o.repository.GetOrderHistory().Create(c, tx, &dto.OrderHistoryRequest{
+			Status:  history,
+			OrderID: reschedule.OrderID,
+		})
+	})
+}
+
+func rescheduleWindowHours() int {
+	if config.Cfg.RescheduleWindowHours > 0 {
+		return config.Cfg.RescheduleWindowHours
+	}
+	return defaultRescheduleWindowHours
+}
+
+// schedulePrice is the price of a slot. Slots may be longer than an hour;
+// older field-service responses only carry the hourly price.
+func schedulePrice(field *clientField.FieldData) float64 {
+	if field.Price > 0 {
+		return field.Price
+	}
+	return field.PricePerHour
+}
+
+func toRescheduleResponse(order *models.Order, reschedule *models.OrderReschedule, payment *clientPayment.PaymentData) *dto.RescheduleOrderResponse {
+	response := &dto.RescheduleOrderResponse{
+		UUID:      reschedule.UUID,
+		OrderCode: order.Code,
+		Amount:    reschedule.Amount,
+		Status:    reschedule.Status,
+	}
+	if payment != nil {
+		response.PaymentLink = payment.PaymentLink
+	}
+	return response
+}
@@ -0,0 +1,296 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	clientUser "order-service/clients/user"
+	"order-service/common/util"
+	"order-service/constants"
+	errOrder "order-service/constants/error/order"
+	"order-service/domain/dto"
+	"order-service/domain/models"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// GetShares shows the organiser of a split order who has paid their share.
+func (o *OrderService) GetShares(c context.Context, uuid string) (*dto.OrderSharesResponse, error) {
+	user := c.Value(constants.User).(*clientUser.UserData)
+
+	order, err := o.repository.GetOrder().FindByUUID(c, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if order.UserID != user.UUID {
+		return nil, errOrder.ErrOrderNotFound
+	}
+
+	shares, err := o.repository.GetOrderShare().FindByOrderID(c, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(shares) == 0 {
+		return nil, errOrder.ErrOrderNotSplit
+	}
+
+	return &dto.OrderSharesResponse{
+		OrderCode:  order.Code,
+		Status:     order.Status.GetStatusString(),
+		Amount:     order.Amount,
+		AmountPaid: order.PaidAmount(),
+		Shares:     toShareResponses(shares),
+	}, nil
+}
+
+// createShares divides the order into equal shares, the first one taking
+// what is left over by rounding, and opens a payment link for each. Every
+// link expires with the hold of the order.
+func (o *OrderService) createShares(c context.Context, tx *gorm.DB, order *models.Order, requests []dto.OrderShareRequest, description string, expiredAt time.Time) ([]models.OrderShare, error) {
+	count := float64(len(requests))
+	amount := math.Floor(order.Amount / count)
+
+	shares := make([]models.OrderShare, 0, len(requests))
+	for _, item := range requests {
+		shares = append(shares, models.OrderShare{
+			OrderID: order.ID,
+			Name:    item.Name,
+			Email:   item.Email,
+			Phone:   item.Phone,
+			Amount:  amount,
+			Status:  constants.SharePending,
+		})
+	}
+	shares[0].Amount = order.Amount - amount*(count-1)
+
+	err := o.repository.GetOrderShare().Create(c, tx, shares)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range shares {
+		name := fmt.Sprintf("Share %d/%d", i+1, len(shares))
+		payment, err := o.client.GetPayment().CreatePaymentLink(c, &dto.PaymentRequest{
+			OrderID:     shares[i].UUID,
+			ExpiredAt:   expiredAt,
+			Amount:      shares[i].Amount,
+			Description: fmt.Sprintf("%s (%s)", description, name),
+			CustomerDetail: dto.CustomerDetail{
+				Name:  shares[i].Name,
+				Email: shares[i].Email,
+				Phone: shares[i].Phone,
+			},
+			ItemDetails: []dto.ItemDetails{
+				{
+					ID:       shares[i].UUID,
+					Name:     name,
+					Amount:   shares[i].Amount,
+					Quantity: 1,
+				},
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		shares[i].PaymentID = &payment.UUID
+		shares[i].PaymentLink = payment.PaymentLink
+		err = o.repository.GetOrderShare().Update(c, tx, shares[i].ID, &models.OrderShare{
+			PaymentID:   shares[i].PaymentID,
+			PaymentLink: shares[i].PaymentLink,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return shares, nil
+}
+
+// handleSharePayment applies the payment status of one share to its split
+// order. The order is only paid, and its schedules booked, once every share
+// is settled. When a share expires first the order expires, and the shares
+// already settled, or settled later, are logged for the payment team to
+// refund.
+func (o *OrderService) handleSharePayment(c context.Context, share *models.OrderShare, req *dto.PaymentData) error {
+	var (
+		order   *models.Order
+		refunds []models.OrderShare
+		expired bool
+	)
+
+	err := o.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		var txErr error
+		order, txErr = o.repository.GetOrder().FindByIDForUpdate(c, tx, share.OrderID)
+		if txErr != nil {
+			return txErr
+		}
+
+		shares, txErr := o.repository.GetOrderShare().FindByOrderIDForUpdate(c, tx, order.ID)
+		if txErr != nil {
+			return txErr
+		}
+
+		var current *models.OrderShare
+		for i := range shares {
+			if shares[i].ID == share.ID {
+				current = &shares[i]
+			}
+		}
+
+		switch req.Status {
+		case constants.PendingPaymentStatus:
+			if order.Status != constants.Pending {
+				return nil
+			}
+			return o.updateOrderStatus(c, tx, order, &models.Order{Status: constants.PendingPayment})
+		case constants.SettlementPaymentStatus:
+			if current.Status != constants.SharePending {
+				return nil
+			}
+
+			current.PaidAt = req.PaidAt
+			if order.Status == constants.Expired || order.Status == constants.Cancelled {
+				current.Status = constants.ShareRefundDue
+				refunds = append(refunds, *current)
+				return o.repository.GetOrderShare().Update(c, tx, current.ID, &models.OrderShare{
+					Status: current.Status,
+					PaidAt: current.PaidAt,
+				})
+			}
+
+			current.Status = constants.SharePaid
+			txErr = o.repository.GetOrderShare().Update(c, tx, current.ID, &models.OrderShare{
+				Status: current.Status,
+				PaidAt: current.PaidAt,
+			})
+			if txErr != nil {
+				return txErr
+			}
+
+			var paid float64
+			settled := true
+			for _, item := range shares {
+				if item.Status == constants.SharePaid {
+					paid += item.Amount
+				} else {
+					settled = false
+				}
+			}
+
+			if !settled {
+				return o.repository.GetOrder().Update(c, tx, &models.Order{AmountPaid: paid}, order.UUID)
+			}
+
+			txErr = o.updateOrderStatus(c, tx, order, &models.Order{
+				Status:     constants.PaymentSuccess,
+				IsPaid:     true,
+				PaidAt:     req.PaidAt,
+				AmountPaid: order.Amount,
+			})
+			if txErr != nil {
+				return txErr
+			}
+
+			return o.bookOrder(c, order)
+		case constants.ExpiredPaymentStatus:
+			if current.Status == constants.SharePending {
+				txErr = o.repository.GetOrderShare().Update(c, tx, current.ID, &models.OrderShare{
+					Status: constants.ShareExpired,
+				})
+				if txErr != nil {
+					return txErr
+				}
+			}
+
+			if order.Status != constants.Pending && order.Status != constants.PendingPayment {
+				return nil
+			}
+
+			txErr = o.updateOrderStatus(c, tx, order, &models.Order{Status: constants.Expired})
+			if txErr != nil {
+				return txErr
+			}
+			expired = true
+
+			for _, item := range shares {
+				if item.Status != constants.SharePaid {
+					continue
+				}
+
+				item.Status = constants.ShareRefundDue
+				txErr = o.repository.GetOrderShare().Update(c, tx, item.ID, &models.OrderShare{
+					Status: item.Status,
+				})
+				if txErr != nil {
+					return txErr
+				}
+				refunds = append(refunds, item)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if expired {
+		o.announceOrderSchedules(c, order, constants.ReleasedScheduleEvent)
+	}
+
+	o.refundShares(c, order, refunds, fmt.Sprintf("split order %s expired before every share was paid", order.Code))
+	return nil
+}
+
+// refundShares gives every paid share of a split order that did not complete
+// back to its payer. A share the refund fails for stays refund-due, so it can
+// be found and refunded by hand.
+func (o *OrderService) refundShares(c context.Context, order *models.Order, shares []models.OrderShare, reason string) {
+	for _, item := range shares {
+		if item.PaymentID == nil {
+			continue
+		}
+
+		err := o.client.GetPayment().Refund(c, *item.PaymentID, &dto.RefundRequest{Reason: reason})
+		if err != nil {
+			logrus.Errorf("failed to refund %s to %s on payment %s of order %s: %v",
+				util.RupiahFormat(&item.Amount), item.Name, item.PaymentID, order.Code, err)
+			continue
+		}
+
+		err = o.repository.GetOrderShare().Update(c, o.repository.GetTx(), item.ID, &models.OrderShare{
+			Status: constants.ShareRefunded,
+		})
+		if err != nil {
+			logrus.Errorf("failed to mark share %s of order %s refunded: %v", item.UUID, order.Code, err)
+		}
+	}
+}
+
+func toShareResponses(shares []models.OrderShare) []dto.OrderShareResponse {
+	if len(shares) == 0 {
+		return nil
+	}
+
+	responses := make([]dto.OrderShareResponse, 0, len(shares))
+	for _, share := range shares {
+		response := dto.OrderShareResponse{
+			UUID:   share.UUID,
+			Name:   share.Name,
+			Amount: share.Amount,
+			Status: share.Status,
+			PaidAt: share.PaidAt,
+		}
+		if share.Status == constants.SharePending {
+			response.PaymentLink = share.PaymentLink
+		}
+		responses = append(responses, response)
+	}
+
+	return responses
+}
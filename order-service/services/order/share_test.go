@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"errors"
+	clientPayment "order-service/clients/payment"
+	"order-service/constants"
+	"order-service/domain/dto"
+	"order-service/domain/models"
+	repoOrderShare "order-service/repositories/ordershare"
+	"testing"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type fakePaymentClient struct {
+	clientPayment.IPaymentClient
+	failFor  uuid.UUID
+	refunded []uuid.UUID
+}
+
+func (f *fakePaymentClient) Refund(_ context.Context, paymentID uuid.UUID, _ *dto.RefundRequest) error {
+	if paymentID == f.failFor {
+		return errors.New("refund refused")
+	}
+	f.refunded = append(f.refunded, paymentID)
+	return nil
+}
+
+type fakeOrderShareRepository struct {
+	repoOrderShare.IOrderShareRepository
+	statuses map[uint]constants.ShareStatus
+}
+
+func (f *fakeOrderShareRepository) Update(_ context.Context, _ *gorm.DB, id uint, share *models.OrderShare) error {
+	f.statuses[id] = share.Status
+	return nil
+}
+
+func TestRefundShares(t *testing.T) {
+	paid, failed := uuid.New(), uuid.New()
+	payment := &fakePaymentClient{failFor: failed}
+	share := &fakeOrderShareRepository{statuses: map[uint]constants.ShareStatus{}}
+	service := &OrderService{
+		repository: &fakeRepositoryRegistry{share: share},
+		client:     &fakeClientRegistry{payment: payment},
+	}
+
+	service.refundShares(context.Background(), &models.Order{Code: "ORD-1"}, []models.OrderShare{
+		{ID: 1, Name: "paid", Amount: 100000, Status: constants.ShareRefundDue, PaymentID: &paid},
+		{ID: 2, Name: "failed", Amount: 100000, Status: constants.ShareRefundDue, PaymentID: &failed},
+		{ID: 3, Name: "unpaid", Amount: 100000, Status: constants.ShareRefundDue},
+	}, "order expired")
+
+	if len(payment.refunded) != 1 || payment.refunded[0] != paid {
+		t.Fatalf("refunded %v, want only %s", payment.refunded, paid)
+	}
+
+	if share.statuses[1] != constants.ShareRefunded {
+		t.Errorf("share 1 status = %q, want %q", share.statuses[1], constants.ShareRefunded)
+	}
+
+	if _, ok := share.statuses[2]; ok {
+		t.Errorf("share 2 with a failed refund was updated to %q, want it left refund-due", share.statuses[2])
+	}
+
+	if _, ok := share.statuses[3]; ok {
+		t.Errorf("share 3 without a payment was updated to %q", share.statuses[3])
+	}
+}
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	"math"
+	clientUser "order-service/clients/user"
+	"order-service/constants"
+	errOrder "order-service/constants/error/order"
+	"order-service/domain/dto"
+	"order-service/domain/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// RecordVenuePayment records a payment of the balance taken at the venue.
+// Once nothing is outstanding the order is paid and payment-service reissues
+// the invoice.
+func (o *OrderService) RecordVenuePayment(c context.Context, uuid string, req *dto.VenuePaymentRequest) (*dto.OrderResponse, error) {
+	admin := c.Value(constants.User).(*clientUser.UserData)
+
+	found, err := o.repository.GetOrder().FindByUUID(c, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	// The order is read again under a lock so two payments taken at once
+	// cannot both count against the same outstanding balance.
+	err = o.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		order, txErr := o.repository.GetOrder().FindByIDForUpdate(c, tx, found.ID)
+		if txErr != nil {
+			return txErr
+		}
+
+		if order.Status != constants.PartiallyPaid {
+			return errOrder.ErrNothingOutstanding
+		}
+
+		if req.Amount > order.Outstanding() {
+			return errOrder.ErrAmountExceedsOutstanding
+		}
+
+		update := &models.Order{AmountPaid: order.PaidAmount() + req.Amount}
+		settled := update.AmountPaid >= order.Amount
+		if settled {
+			now := time.Now()
+			update.Status = constants.PaymentSuccess
+			update.IsPaid = true
+			update.PaidAt = &now
+		}
+
+		txErr = o.repository.GetOrderVenuePayment().Create(c, tx, &models.OrderVenuePayment{
+			OrderID:    order.ID,
+			Amount:     req.Amount,
+			Method:     req.Method,
+			ReceivedBy: admin.UUID,
+			Note:       req.Note,
+		})
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = o.repository.GetOrder().Update(c, tx, update, order.UUID)
+		if txErr != nil {
+			return txErr
+		}
+
+		if !settled {
+			return nil
+		}
+
+		txErr = o.repository.GetOrderHistory().Create(c, tx, &dto.OrderHistoryRequest{
+			Status:  constants.PaymentSuccessString,
+			OrderID: order.ID,
+		})
+		if txErr != nil {
+			return txErr
+		}
+
+		return o.client.GetPayment().SettleBalance(c, order.PaymentID, &dto.SettleBalanceRequest{
+			Method: req.Method,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return o.GetByUUID(c, uuid)
+}
+
+// depositAmount is the deposit a new order pays online, or zero when it is
+// paid in full. Every venue of the order must take deposits; the highest of
+// their percentages applies.
+func depositAmount(option constants.PaymentOption, amount float64, percentages []int) (float64, error) {
+	if option != constants.DepositPayment {
+		return 0, nil
+	}
+
+	percentage := 0
+	for _, item := range percentages {
+		if item == 0 {
+			return 0, errOrder.ErrDepositNotOffered
+		}
+		percentage = max(percentage, item)
+	}
+
+	return math.Ceil(amount * float64(percentage) / 100), nil
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/midtrans/midtrans-go"
+	"github.com/midtrans/midtrans-go/coreapi"
 	"github.com/midtrans/midtrans-go/snap"
 	"github.com/sirupsen/logrus"
 )
@@ -17,6 +18,7 @@ type MidtransClient struct {
 
 type IMidtransClient interface {
 	CreatePaymentLink(*dto.PaymentRequest) (*MidtransData, error)
+	Refund(orderID, refundKey string, amount int64, reason string) error
 }
 
 func NewMidtransClient(serverKey string, isProduction bool) *MidtransClient {
@@ -55,6 +57,29 @@ func (c *MidtransClient) CreatePaymentLink(request *dto.PaymentRequest) (*Midtra
 		isProduction = midtrans.Production
 	}
 
+	items := make([]midtrans.ItemDetails, 0, len(request.ItemDetails))
+	for _, item := range request.ItemDetails {
+		items = append(items, midtrans.ItemDetails{
+			ID:    item.ID,
+			Name:  item.Name,
+			Price: int64(item.Amount),
+			Qty:   int32(item.Quantity),
+		})
+	}
+
+	// Midtrans wants the items to add up to the gross amount, so a deposit
+	// is sent as one line; the items are still kept for the invoice.
+	if request.OrderAmount > request.Amount {
+		items = []midtrans.ItemDetails{
+			{
+				ID:    request.OrderId,
+				Name:  "Down payment",
+				Price: int64(request.Amount),
+				Qty:   1,
+			},
+		}
+	}
+
 	snapClient.New(c.ServerKey, isProduction)
 	req := &snap.Request{
 		TransactionDetails: midtrans.TransactionDetails{
@@ -66,14 +91,7 @@ func (c *MidtransClient) CreatePaymentLink(request *dto.PaymentRequest) (*Midtra
 			Email: request.CustomerDetail.Email,
 			Phone: request.CustomerDetail.Phone,
 		},
-		Items: &[]midtrans.ItemDetails{
-			{
-				ID:    request.ItemDetails[0].ID,
-				Name:  request.ItemDetails[0].Name,
-				Price: int64(request.ItemDetails[0].Amount),
-				Qty:   int32(request.ItemDetails[0].Quantity),
-			},
-		},
+		Items: &items,
 		Expiry: &snap.ExpiryDetails{
 			Unit:     expiryUnit,
 			Duration: expiryDuration,
@@ -92,3 +110,28 @@ func (c *MidtransClient) CreatePaymentLink(request *dto.PaymentRequest) (*Midtra
 	}, nil
 
 }
+
+// Refund returns the amount to the customer through the original payment
+// method. Midtrans ignores a second request with the same refund key, so a
+// retry cannot refund twice.
+func (c *MidtransClient) Refund(orderID, refundKey string, amount int64, reason string) error {
+	var coreClient coreapi.Client
+
+	env := midtrans.Sandbox
+	if c.IsProduction {
+		env = midtrans.Production
+	}
+
+	coreClient.New(c.ServerKey, env)
+	_, err := coreClient.RefundTransaction(orderID, &coreapi.RefundReq{
+		RefundKey: refundKey,
+		Amount:    amount,
+		Reason:    reason,
+	})
+	if err != nil {
+		logrus.Errorf("Error refund transaction midtrans: %v", err.GetMessage())
+		return errPayment.ErrRefundFailed
+	}
+
+	return nil
+}
@@ -1,11 +1,12 @@
 package cmd
 
 import (
+	"common/signature"
+	"common/storage"
 	"fmt"
 	"net/http"
 	"payment-service/clients"
 	midtransCLient "payment-service/clients/midtrans"
-	"payment-service/common/gcs"
 	"payment-service/common/response"
 	"payment-service/config"
 	"payment-service/constants"
@@ -45,16 +46,18 @@ var command = &cobra.Command{
 		err = db.AutoMigrate(
 			&models.Payment{},
 			&models.PaymentHistory{},
+			&models.PaymentItem{},
+			&signature.Nonce{},
 		)
 		if err != nil {
 			panic(err)
 		}
-		gcs := initGCS()
+		storageClient := initStorage()
 		kafka := kafka.NewKafkaRegistry(config.Cfg.Kafka.Brokers)
 		midtrans := midtransCLient.NewMidtransClient(config.Cfg.Midtrans.ServerKey, config.Cfg.Midtrans.IsProduction)
 		client := clients.NewClientRegistry()
 		repositories := repositories.NewRepositoryRegistry(db)
-		service := services.NewServiceRegistry(repositories, gcs, kafka, midtrans)
+		service := services.NewServiceRegistry(repositories, storageClient, kafka, midtrans)
 		controller := controllers.NewControllerRegistry(service)
 
 		router := gin.Default()
@@ -90,8 +93,14 @@ var command = &cobra.Command{
 			})
 		router.Use(middlewares.RateLimiter(lmt))
 
+		// Files of the local storage driver are served by the service itself.
+		if servable, ok := storageClient.(storage.IServableStorage); ok {
+			router.GET("/storage/*name", servable.Handler())
+		}
+
 		group := router.Group("/api/v1")
-		route := routes.NewRouteRegistry(controller, group, client)
+		internalGroup := router.Group("/internal/v1", middlewares.AuthenticateInternal(signature.NewPostgresNonceStore(db)))
+		route := routes.NewRouteRegistry(controller, group, internalGroup, client)
 		route.Serve()
 
 		port := fmt.Sprintf(":%d", config.Cfg.Port)
@@ -106,14 +115,14 @@ func Run() {
 	}
 }
 
-func initGCS() gcs.IGCSClient {
+func initStorage() storage.IStorageClient {
 	// decode, err := base64.StdEncoding.DecodeString(config.Cfg.GcsPrivateKey)
 	// if err != nil {
 	// 	panic(err)
 	// }
 
 	// stringPrivateKey := string(decode)
-	gcsServiceAccount := gcs.ServiceAccountKeyJSON{
+	gcsServiceAccount := storage.ServiceAccountKeyJSON{
 		Type:                    config.Cfg.GcsType,
 		ProjectID:               config.Cfg.GcsProjectID,
 		PrivateKeyID:            config.Cfg.GcsPrivateKeyID,
@@ -126,9 +135,28 @@ func initGCS() gcs.IGCSClient {
 		ClientX509CertURL:       config.Cfg.GcsClientX509CertURL,
 		UniverseDomain:          config.Cfg.GcsUniverseDomain,
 	}
-	gcsClient := gcs.NewGCSClient(
-		gcsServiceAccount,
-		config.Cfg.GcsBucketName,
-	)
-	return gcsClient
+	storageClient, err := storage.NewStorageClient(storage.Config{
+		Driver:          config.Cfg.Storage.Driver,
+		SignedURLExpiry: time.Duration(config.Cfg.Storage.SignedURLExpiryInMinute) * time.Minute,
+		GCS: storage.GCSConfig{
+			ServiceAccountKeyJSON: gcsServiceAccount,
+			BucketName:            config.Cfg.GcsBucketName,
+		},
+		Local: storage.LocalConfig{
+			Dir:        config.Cfg.Storage.Local.Dir,
+			BaseURL:    config.Cfg.Storage.Local.BaseURL,
+			SigningKey: config.Cfg.Storage.Local.SigningKey,
+		},
+		S3: storage.S3Config{
+			Endpoint:  config.Cfg.Storage.S3.Endpoint,
+			Region:    config.Cfg.Storage.S3.Region,
+			Bucket:    config.Cfg.Storage.S3.Bucket,
+			AccessKey: config.Cfg.Storage.S3.AccessKey,
+			SecretKey: config.Cfg.Storage.S3.SecretKey,
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+	return storageClient
 }
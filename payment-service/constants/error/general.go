@@ -11,6 +11,9 @@ var (
 	ErrInvalidUploadFile   = errors.New("invalid upload file")
 	ErrForbidden           = errors.New("forbidden")
 	ErrSizetooBig          = errors.New("upload file size too big")
+	ErrInvalidSignature    = errors.New("invalid request signature")
+	ErrRequestExpired      = errors.New("request expired")
+	ErrRequestReplayed     = errors.New("request already processed")
 )
 
 var GeneralErrors = []error{
@@ -18,4 +21,5 @@ var GeneralErrors = []error{
 	ErrSQLError,
 	ErrToManyRequests,
 	ErrUnauthorized, ErrInvalidToken, ErrForbidden,
+	ErrInvalidSignature, ErrRequestExpired, ErrRequestReplayed,
 }
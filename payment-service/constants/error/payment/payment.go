@@ -6,9 +6,17 @@ var (
 	ErrPaymentNotFound = errors.New("Payment not found")
 	ErrExpireAtInvalid = errors.New("expired time must be greater than current time")
 	ErrPaymentExists   = errors.New("Payment already exist")
+	ErrNoBalance       = errors.New("payment has no balance to settle")
+	ErrNotRefundable   = errors.New("payment is not settled and cannot be refunded")
+	ErrRefundTooLarge  = errors.New("refund amount is more than the amount paid")
+	ErrRefundFailed    = errors.New("payment provider refused the refund")
 )
 
 var PaymentErrors = []error{
 	ErrPaymentNotFound,
 	ErrPaymentExists,
+	ErrNoBalance,
+	ErrNotRefundable,
+	ErrRefundTooLarge,
+	ErrRefundFailed,
 }
@@ -6,5 +6,7 @@ var (
 	XserviceName  = textproto.CanonicalMIMEHeaderKey("x-service-name")
 	XApiKey       = textproto.CanonicalMIMEHeaderKey("x-api-key")
 	XrequestAt    = textproto.CanonicalMIMEHeaderKey("x-request-at")
+	XNonce        = textproto.CanonicalMIMEHeaderKey("x-nonce")
+	XSignature    = textproto.CanonicalMIMEHeaderKey("x-signature")
 	Authorization = textproto.CanonicalMIMEHeaderKey("authorization")
 )
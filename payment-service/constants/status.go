@@ -8,11 +8,13 @@ const (
 	Pending    PaymentStatus = 100
 	Settlement PaymentStatus = 200
 	Expire     PaymentStatus = 300
+	Refund     PaymentStatus = 400
 
 	InitialString    PaymentStatusString = "initial"
 	PendingString    PaymentStatusString = "pending"
 	SettlementString PaymentStatusString = "settlement"
 	ExpireString     PaymentStatusString = "expire"
+	RefundString     PaymentStatusString = "refund"
 )
 
 var mapStatusStringToInt = map[PaymentStatusString]PaymentStatus{
@@ -20,6 +22,7 @@ var mapStatusStringToInt = map[PaymentStatusString]PaymentStatus{
 	PendingString:    Pending,
 	SettlementString: Settlement,
 	ExpireString:     Expire,
+	RefundString:     Refund,
 }
 
 var mapStatusIntToString = map[PaymentStatus]PaymentStatusString{
@@ -27,6 +30,7 @@ var mapStatusIntToString = map[PaymentStatus]PaymentStatusString{
 	Pending:    PendingString,
 	Settlement: SettlementString,
 	Expire:     ExpireString,
+	Refund:     RefundString,
 }
 
 func (p PaymentStatus) String() string {
@@ -9,6 +9,10 @@ type InvoiceData struct {
 	PaymentDetail InvoicePaymentDetail `json:"paymentDetail"`
 	Items         []InvoiceItem        `json:"items"`
 	Total         string               `json:"total"`
+	// Deposit and Balance are set on the invoice of a deposit payment.
+	Deposit       string `json:"deposit,omitempty"`
+	Balance       string `json:"balance,omitempty"`
+	BalanceMethod string `json:"balanceMethod,omitempty"`
 }
 
 type InvoicePaymentDetail struct {
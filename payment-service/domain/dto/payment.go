@@ -8,10 +8,13 @@ import (
 )
 
 type PaymentRequest struct {
-	PaymentLink    string          `json:"paymentLink"`
-	OrderId        string          `json:"orderID"`
-	ExpiredAt      time.Time       `json:"expiredAt"`
-	Amount         float64         `json:"amount"`
+	PaymentLink string    `json:"paymentLink"`
+	OrderId     string    `json:"orderID"`
+	ExpiredAt   time.Time `json:"expiredAt"`
+	Amount      float64   `json:"amount"`
+	// OrderAmount is the total of the order when Amount only pays a deposit
+	// of it. It is zero when Amount pays the order in full.
+	OrderAmount    float64         `json:"orderAmount"`
 	Description    *string         `json:"description"`
 	CustomerDetail *CustomerDetail `json:"customerDetail"`
 	ItemDetails    []ItemDetail    `json:"itemDetails"`
@@ -33,8 +36,8 @@ type ItemDetail struct {
 type PaymentRequestParam struct {
 	Page       int     `form:"page" binding:"required"`
 	Limit      int     `form:"limit" binding:"required"`
-	SortColumn *string `form:"sortColumn"`
-	SortOrder  *string `form:"sortOrder"`
+	SortColumn *string `form:"sortColumn" validate:"omitempty,oneof=amount status created_at"`
+	SortOrder  *string `form:"sortOrder" validate:"omitempty,oneof=asc desc"`
 }
 
 type UpdatePaymentRequest struct {
@@ -45,12 +48,33 @@ type UpdatePaymentRequest struct {
 	Bank          *string                  `json:"bank"`
 	InvoiceLink   *string                  `json:"invoiceLin,omitempty"`
 	Acquirer      *string                  `json:"acquirer"`
+	PaymentType   *string                  `json:"paymentType"`
+	BalancePaidAt *time.Time               `json:"balancePaidAt"`
+	BalanceMethod *string                  `json:"balanceMethod"`
+	RefundAmount  float64                  `json:"refundAmount"`
+	RefundReason  *string                  `json:"refundReason"`
+	RefundedAt    *time.Time               `json:"refundedAt"`
+}
+
+type SettleBalanceRequest struct {
+	Method string `json:"method" validate:"required,oneof=cash qris"`
+}
+
+// RefundRequest refunds the whole payment unless a smaller Amount is given.
+type RefundRequest struct {
+	Amount float64 `json:"amount" validate:"omitempty,gt=0"`
+	Reason string  `json:"reason" validate:"required"`
 }
 
 type PaymentResponse struct {
 	UUID          uuid.UUID                     `json:"uuid"`
 	OrderID       uuid.UUID                     `json:"orderID"`
 	Amount        float64                       `json:"amount"`
+	OrderAmount   float64                       `json:"orderAmount,omitempty"`
+	Balance       float64                       `json:"balance,omitempty"`
+	BalancePaidAt *time.Time                    `json:"balancePaidAt,omitempty"`
+	RefundAmount  float64                       `json:"refundAmount,omitempty"`
+	RefundedAt    *time.Time                    `json:"refundedAt,omitempty"`
 	Status        constants.PaymentStatusString `json:"status"`
 	PaymentLink   string                        `json:"paymentLink"`
 	InvoiceLink   *string                       `json:"invoiceLink,omitempty"`
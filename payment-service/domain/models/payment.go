@@ -12,6 +12,7 @@ type Payment struct {
 	UUID             uuid.UUID                `gorm:"type:uuid;not null"`
 	OrderID          uuid.UUID                `gorm:"type:uuid;not null"`
 	Amount           float64                  `gorm:"not null"`
+	OrderAmount      float64                  `gorm:"type:decimal(10,2);not null;default:0"`
 	Status           *constants.PaymentStatus `gorm:"not null"`
 	PaymentLink      string                   `gorm:"type:varchar(255);not null"`
 	InvoiceLink      *string                  `gorm:"type:varchar(255);default:null"`
@@ -20,9 +21,30 @@ type Payment struct {
 	Acquirer         *string                  `gorm:"type:varchar(100);default:null"`
 	TransactionID    *string                  `gorm:"type:varchar(100);default:null"`
 	Description      *string                  `gorm:"type:text;default:null"`
+	PaymentType      *string                  `gorm:"type:varchar(50);default:null"`
+	BalanceMethod    *string                  `gorm:"type:varchar(20);default:null"`
+	BalancePaidAt    *time.Time
+	RefundAmount     float64 `gorm:"type:decimal(10,2);not null;default:0"`
+	RefundReason     *string `gorm:"type:text;default:null"`
+	RefundedAt       *time.Time
 	PaidAt           *time.Time
 	ExpiredAt        time.Time
 	CreatedAt        time.Time
 	UpdatedAt        time.Time
 	PaymentHistories []PaymentHistory `gorm:"foreignKey:payment_id;references:id;constraint:onUpdate:CASCADE,onDelete:CASCADE;"`
+	PaymentItems     []PaymentItem    `gorm:"foreignKey:payment_id;references:id;constraint:onUpdate:CASCADE,onDelete:CASCADE;"`
+}
+
+// IsDeposit reports whether the payment only covers a deposit of the order,
+// the balance being paid at the venue.
+func (p *Payment) IsDeposit() bool {
+	return p.OrderAmount > p.Amount
+}
+
+// Balance is the part of the order left to pay at the venue.
+func (p *Payment) Balance() float64 {
+	if !p.IsDeposit() || p.BalancePaidAt != nil {
+		return 0
+	}
+	return p.OrderAmount - p.Amount
 }
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// PaymentItem is a line of the payment link, kept to itemise the invoice.
+type PaymentItem struct {
+	ID        uint    `gorm:"primaryKey;autoIncrement"`
+	PaymentID uint    `gorm:"type:bigint;not null;index"`
+	ItemID    string  `gorm:"type:varchar(100);not null"`
+	Name      string  `gorm:"type:varchar(255);not null"`
+	Amount    float64 `gorm:"type:decimal(10,2);not null"`
+	Quantity  int     `gorm:"type:int;not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (p *PaymentItem) Total() float64 {
+	return p.Amount * float64(p.Quantity)
+}
@@ -167,3 +167,7 @@ require (
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 	moul.io/http2curl v1.0.0 // indirect
 )
+
+require common v0.0.0
+
+replace common => ../common
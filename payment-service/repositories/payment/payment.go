@@ -41,7 +41,11 @@ func (p *PaymentRepository) FindAllWithPagination(c context.Context, param *dto.
 	)
 
 	if param.SortColumn != nil {
-		sort = fmt.Sprintf("%s %s", *param.SortColumn, *&param.SortOrder)
+		order := "asc"
+		if param.SortOrder != nil {
+			order = *param.SortOrder
+		}
+		sort = fmt.Sprintf("%s %s", *param.SortColumn, order)
 	} else {
 		sort = "created_at desc"
 	}
@@ -68,7 +72,7 @@ func (p *PaymentRepository) FindByUUID(c context.Context, uuid string) (*models.
 		payment models.Payment
 	)
 
-	err := p.db.WithContext(c).Where("uuid = ?", uuid).First(&payment).Error
+	err := p.db.WithContext(c).Preload("PaymentItems").Where("uuid = ?", uuid).First(&payment).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errWrap.WrapError(errPayment.ErrPaymentNotFound)
@@ -85,7 +89,9 @@ func (p *PaymentRepository) FindByOrderID(c context.Context, orderID string) (*m
 		payment models.Payment
 	)
 
-	err := p.db.WithContext(c).Where("order_id = ?", orderID).First(&payment).Error
+	err := p.db.WithContext(c).Preload("PaymentItems", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id asc")
+	}).Where("order_id = ?", orderID).First(&payment).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errWrap.WrapError(errPayment.ErrPaymentNotFound)
@@ -104,11 +110,20 @@ func (p *PaymentRepository) Create(c context.Context, tx *gorm.DB, req *dto.Paym
 		UUID:        uuid.New(),
 		OrderID:     orderID,
 		Amount:      req.Amount,
+		OrderAmount: req.OrderAmount,
 		PaymentLink: req.PaymentLink,
 		ExpiredAt:   req.ExpiredAt,
 		Description: req.Description,
 		Status:      &status,
 	}
+	for _, item := range req.ItemDetails {
+		payment.PaymentItems = append(payment.PaymentItems, models.PaymentItem{
+			ItemID:   item.ID,
+			Name:     item.Name,
+			Amount:   item.Amount,
+			Quantity: item.Quantity,
+		})
+	}
 
 	err := tx.WithContext(c).Create(&payment).Error
 	if err != nil {
@@ -129,6 +144,12 @@ func (p *PaymentRepository) Update(c context.Context, tx *gorm.DB, orderId strin
 		VANumber:      req.VANumber,
 		Bank:          req.Bank,
 		Acquirer:      req.Acquirer,
+		PaymentType:   req.PaymentType,
+		BalancePaidAt: req.BalancePaidAt,
+		BalanceMethod: req.BalanceMethod,
+		RefundAmount:  req.RefundAmount,
+		RefundReason:  req.RefundReason,
+		RefundedAt:    req.RefundedAt,
 	}
 
 	err := tx.WithContext(c).Where("order_id = ?", orderId).Updates(&payment).Error
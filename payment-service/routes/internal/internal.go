@@ -0,0 +1,33 @@
+package routes
+
+import (
+	controllers "payment-service/controllers/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type InternalRoute struct {
+	controller controllers.IControllerRegistry
+	group      *gin.RouterGroup
+}
+
+type IInternalRoute interface {
+	Run()
+}
+
+// NewInternalRoute registers endpoints that are only called by other
+// services. The group is expected to require middlewares.AuthenticateInternal.
+func NewInternalRoute(controller controllers.IControllerRegistry, group *gin.RouterGroup) IInternalRoute {
+	return &InternalRoute{
+		controller: controller,
+		group:      group,
+	}
+}
+
+func (i *InternalRoute) Run() {
+	group := i.group.Group("/payment")
+	group.GET("/:uuid", i.controller.GetPayment().GetByUUID)
+	group.POST("", i.controller.GetPayment().Create)
+	group.POST("/:uuid/balance", i.controller.GetPayment().SettleBalance)
+	group.POST("/:uuid/refund", i.controller.GetPayment().Refund)
+}
@@ -33,5 +33,4 @@ func (f *PaymentRoute) Run() {
 	group.Use(middlewares.Authenticate())
 	group.GET("", middlewares.CheckRole([]string{constants.Admin, constants.Customer}, f.client), f.controller.GetPayment().GetAllWithPagination)
 	group.GET("/:uuid", middlewares.CheckRole([]string{constants.Admin, constants.Customer}, f.client), f.controller.GetPayment().GetByUUID)
-	group.POST("", middlewares.CheckRole([]string{constants.Customer}, f.client), f.controller.GetPayment().Create)
 }
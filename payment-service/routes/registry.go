@@ -3,26 +3,29 @@ package routes
 import (
 	"payment-service/clients"
 	controllers "payment-service/controllers/http"
+	routesI "payment-service/routes/internal"
 	routesF "payment-service/routes/payment"
 
 	"github.com/gin-gonic/gin"
 )
 
 type Registry struct {
-	controller controllers.IControllerRegistry
-	group      *gin.RouterGroup
-	client     clients.IClientRegistry
+	controller    controllers.IControllerRegistry
+	group         *gin.RouterGroup
+	internalGroup *gin.RouterGroup
+	client        clients.IClientRegistry
 }
 
 type IRegistry interface {
 	Serve()
 }
 
-func NewRouteRegistry(controller controllers.IControllerRegistry, group *gin.RouterGroup, client clients.IClientRegistry) IRegistry {
+func NewRouteRegistry(controller controllers.IControllerRegistry, group, internalGroup *gin.RouterGroup, client clients.IClientRegistry) IRegistry {
 	return &Registry{
-		controller: controller,
-		group:      group,
-		client:     client,
+		controller:    controller,
+		group:         group,
+		internalGroup: internalGroup,
+		client:        client,
 	}
 }
 
@@ -30,6 +33,11 @@ func (r *Registry) PaymentRoute() routesF.IPaymentRoute {
 	return routesF.NewPaymentRoute(r.controller, r.group, r.client)
 }
 
+func (r *Registry) InternalRoute() routesI.IInternalRoute {
+	return routesI.NewInternalRoute(r.controller, r.internalGroup)
+}
+
 func (r *Registry) Serve() {
 	r.PaymentRoute().Run()
+	r.InternalRoute().Run()
 }
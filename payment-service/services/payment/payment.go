@@ -1,14 +1,16 @@
 package services
 
 import (
+	"bytes"
+	"common/storage"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"html/template"
 	"math/rand"
 	"os"
 	clients "payment-service/clients/midtrans"
-	"payment-service/common/gcs"
 	"payment-service/common/util"
 	"payment-service/config"
 	"payment-service/constants"
@@ -20,12 +22,13 @@ import (
 	"strings"
 	"time"
 
+	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
 
 type PaymentService struct {
 	repository repositories.IRepositoryRegistry
-	gcs        gcs.IGCSClient
+	storage    storage.IStorageClient
 	kafka      kafka.IKafkaRegistry
 	midtrans   clients.IMidtransClient
 }
@@ -35,12 +38,14 @@ type IPaymentService interface {
 	GetByUUID(context.Context, string) (*dto.PaymentResponse, error)
 	Create(context.Context, *dto.PaymentRequest) (*dto.PaymentResponse, error)
 	Webhook(context.Context, *dto.Webhook) error
+	SettleBalance(context.Context, string, *dto.SettleBalanceRequest) (*dto.PaymentResponse, error)
+	Refund(context.Context, string, *dto.RefundRequest) (*dto.PaymentResponse, error)
 }
 
-func NewPaymentService(repository repositories.IRepositoryRegistry, gcs gcs.IGCSClient, kafka kafka.IKafkaRegistry, midtrans clients.IMidtransClient) IPaymentService {
+func NewPaymentService(repository repositories.IRepositoryRegistry, storage storage.IStorageClient, kafka kafka.IKafkaRegistry, midtrans clients.IMidtransClient) IPaymentService {
 	return &PaymentService{
 		repository: repository,
-		gcs:        gcs,
+		storage:    storage,
 		kafka:      kafka,
 		midtrans:   midtrans,
 	}
@@ -60,7 +65,7 @@ func (p *PaymentService) GetAllWithPagination(c context.Context, param *dto.Paym
 			Amount:        v.Amount,
 			Status:        v.Status.GetStatusString(),
 			PaymentLink:   v.PaymentLink,
-			InvoiceLink:   v.InvoiceLink,
+			InvoiceLink:   p.signInvoiceLink(c, v.InvoiceLink),
 			VANumber:      v.VANumber,
 			Bank:          v.Bank,
 			Description:   v.Description,
@@ -92,9 +97,14 @@ func (p *PaymentService) GetByUUID(c context.Context, uuid string) (*dto.Payment
 		TransactionId: payment.TransactionID,
 		OrderID:       payment.OrderID,
 		Amount:        payment.Amount,
+		OrderAmount:   payment.OrderAmount,
+		Balance:       payment.Balance(),
+		BalancePaidAt: payment.BalancePaidAt,
+		RefundAmount:  payment.RefundAmount,
+		RefundedAt:    payment.RefundedAt,
 		Status:        payment.Status.GetStatusString(),
 		PaymentLink:   payment.PaymentLink,
-		InvoiceLink:   payment.InvoiceLink,
+		InvoiceLink:   p.signInvoiceLink(c, payment.InvoiceLink),
 		VANumber:      payment.VANumber,
 		Bank:          payment.Bank,
 		Description:   payment.Description,
@@ -124,9 +134,11 @@ func (p *PaymentService) Create(c context.Context, req *dto.PaymentRequest) (*dt
 		paymentRequest := dto.PaymentRequest{
 			OrderId:     req.OrderId,
 			Amount:      req.Amount,
+			OrderAmount: req.OrderAmount,
 			Description: req.Description,
 			ExpiredAt:   req.ExpiredAt,
 			PaymentLink: midtrans.RedirectURL,
+			ItemDetails: req.ItemDetails,
 		}
 		payment, txErr = p.repository.GetPayment().Create(c, tx, &paymentRequest)
 		if txErr != nil {
@@ -184,7 +196,29 @@ func (p *PaymentService) generatePDF(req *dto.InvoiceRequest) ([]byte, error) {
 		return nil, err
 	}
 
-	pdf, err := util.GeneratePDFfromHTML(string(htmpTemp))
+	invoiceTemp, err := template.New("invoice").Parse(string(htmpTemp))
+	if err != nil {
+		return nil, err
+	}
+
+	// The template addresses the invoice by its JSON field names.
+	var data map[string]any
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(body, &data)
+	if err != nil {
+		return nil, err
+	}
+
+	html := new(bytes.Buffer)
+	err = invoiceTemp.Execute(html, data)
+	if err != nil {
+		return nil, err
+	}
+
+	pdf, err := util.GeneratePDFfromHTML(html.String())
 	if err != nil {
 		return nil, err
 	}
@@ -192,15 +226,210 @@ func (p *PaymentService) generatePDF(req *dto.InvoiceRequest) ([]byte, error) {
 	return pdf, nil
 }
 
-func (p *PaymentService) uploadToGCS(c context.Context, invoice string, pdf []byte) (string, error) {
+// invoiceData fills an invoice of the payment. The invoice of a deposit shows
+// the order total with the deposit and the balance, and is only marked paid
+// once the balance is settled at the venue.
+func (p *PaymentService) invoiceData(payment *models.Payment, paidAt time.Time) dto.InvoiceData {
+	var paymentMethod, bank, vaNumber string
+	if payment.PaymentType != nil {
+		paymentMethod = *payment.PaymentType
+	}
+	if payment.Bank != nil {
+		bank = strings.ToUpper(*payment.Bank)
+	}
+	if payment.VANumber != nil {
+		vaNumber = *payment.VANumber
+	}
+
+	total := util.RupiahFormat(&payment.Amount)
+	data := dto.InvoiceData{
+		PaymentDetail: dto.InvoicePaymentDetail{
+			PaymentMethod: paymentMethod,
+			BankName:      bank,
+			VANumber:      vaNumber,
+			Date:          fmt.Sprintf("%s %s %s", paidAt.Format("02"), p.ConvertToIndonesianMonth(paidAt.Format("January")), paidAt.Format("2006")),
+			IsPaid:        true,
+		},
+		Total: total,
+	}
+
+	if payment.IsDeposit() {
+		balance := payment.OrderAmount - payment.Amount
+		data.Total = util.RupiahFormat(&payment.OrderAmount)
+		data.Deposit = total
+		data.Balance = util.RupiahFormat(&balance)
+		data.PaymentDetail.IsPaid = payment.BalancePaidAt != nil
+		if payment.BalanceMethod != nil {
+			data.BalanceMethod = strings.ToUpper(*payment.BalanceMethod)
+		}
+	}
+
+	data.Items = p.invoiceItems(payment, data.Total)
+	return data
+}
+
+// SettleBalance records that the balance of a deposit payment was paid at the
+// venue and reissues its invoice as paid in full.
+func (p *PaymentService) SettleBalance(c context.Context, uuid string, req *dto.SettleBalanceRequest) (*dto.PaymentResponse, error) {
+	payment, err := p.repository.GetPayment().FindByUUID(c, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if *payment.Status != constants.Settlement || payment.Balance() == 0 {
+		return nil, errPayment.ErrNoBalance
+	}
+
+	now := time.Now()
+	err = p.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		orderID := payment.OrderID.String()
+		_, txErr := p.repository.GetPayment().Update(c, tx, orderID, &dto.UpdatePaymentRequest{
+			BalancePaidAt: &now,
+			BalanceMethod: &req.Method,
+		})
+		if txErr != nil {
+			return txErr
+		}
+
+		payment.BalancePaidAt = &now
+		payment.BalanceMethod = &req.Method
+		invoiceNum := fmt.Sprintf("INV/%s/ORD/%d", now.Format(time.DateOnly), p.RandomNum())
+		pdf, txErr := p.generatePDF(&dto.InvoiceRequest{
+			InvoiceNumber: invoiceNum,
+			Data:          p.invoiceData(payment, now),
+		})
+		if txErr != nil {
+			return txErr
+		}
+
+		invoiceLink, txErr := p.uploadInvoice(c, invoiceNum, pdf)
+		if txErr != nil {
+			return txErr
+		}
+
+		_, txErr = p.repository.GetPayment().Update(c, tx, orderID, &dto.UpdatePaymentRequest{
+			InvoiceLink: &invoiceLink,
+		})
+		return txErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return p.GetByUUID(c, uuid)
+}
+
+// Refund gives a settled payment back to the customer, for example when the
+// order it paid for was cancelled. A payment already refunded is returned as
+// it is, so the caller can retry safely.
+func (p *PaymentService) Refund(c context.Context, uuid string, req *dto.RefundRequest) (*dto.PaymentResponse, error) {
+	payment, err := p.repository.GetPayment().FindByUUID(c, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if *payment.Status == constants.Refund {
+		return p.GetByUUID(c, uuid)
+	}
+
+	if *payment.Status != constants.Settlement {
+		return nil, errPayment.ErrNotRefundable
+	}
+
+	amount := req.Amount
+	if amount == 0 {
+		amount = payment.Amount
+	}
+
+	if amount > payment.Amount {
+		return nil, errPayment.ErrRefundTooLarge
+	}
+
+	err = p.midtrans.Refund(payment.OrderID.String(), fmt.Sprintf("refund-%s", payment.UUID), int64(amount), req.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	status := constants.Refund
+	err = p.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		_, txErr := p.repository.GetPayment().Update(c, tx, payment.OrderID.String(), &dto.UpdatePaymentRequest{
+			Status:       &status,
+			RefundAmount: amount,
+			RefundReason: &req.Reason,
+			RefundedAt:   &now,
+		})
+		if txErr != nil {
+			return txErr
+		}
+
+		return p.repository.GetPaymentHistory().Create(c, tx, &dto.PaymentHistoryRequest{
+			PaymentID: payment.ID,
+			Status:    status.GetStatusString(),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return p.GetByUUID(c, uuid)
+}
+
+// invoiceItems lists every item of the payment link, such as the field rental
+// and its add-ons. Payments created before their items were kept get a single
+// line for the whole amount.
+func (p *PaymentService) invoiceItems(payment *models.Payment, total string) []dto.InvoiceItem {
+	if len(payment.PaymentItems) == 0 {
+		return []dto.InvoiceItem{
+			{
+				Description: *payment.Description,
+				Price:       total,
+			},
+		}
+	}
+
+	items := make([]dto.InvoiceItem, 0, len(payment.PaymentItems))
+	for _, item := range payment.PaymentItems {
+		description := item.Name
+		if item.Quantity > 1 {
+			description = fmt.Sprintf("%s x%d", item.Name, item.Quantity)
+		}
+
+		amount := item.Total()
+		items = append(items, dto.InvoiceItem{
+			Description: description,
+			Price:       util.RupiahFormat(&amount),
+		})
+	}
+
+	return items
+}
+
+func (p *PaymentService) uploadInvoice(c context.Context, invoice string, pdf []byte) (string, error) {
 	invoiceNumReplace := strings.ToLower(strings.ReplaceAll(invoice, "/", "-"))
 	fileName := fmt.Sprintf("%s.pdf", invoiceNumReplace)
-	url, err := p.gcs.UploadFile(c, fileName, pdf)
+	name, err := p.storage.UploadFile(c, fileName, pdf)
 	if err != nil {
 		return "", err
 	}
 
-	return url, nil
+	return name, nil
+}
+
+// signInvoiceLink turns the stored invoice object name into a signed download
+// URL.
+func (p *PaymentService) signInvoiceLink(c context.Context, invoiceLink *string) *string {
+	if invoiceLink == nil {
+		return nil
+	}
+
+	url, err := p.storage.SignedURL(c, *invoiceLink)
+	if err != nil {
+		logrus.Errorf("failed to sign invoice link: %v", err)
+		return nil
+	}
+
+	return &url
 }
 
 func (p *PaymentService) RandomNum() int {
@@ -290,6 +519,7 @@ func (p *PaymentService) Webhook(c context.Context, req *dto.Webhook) error {
 			VANumber:      &vaNumber,
 			Bank:          &bank,
 			Acquirer:      req.Acquirer,
+			PaymentType:   &req.PaymentType,
 		})
 		if txErr != nil {
 			return txErr
@@ -306,36 +536,17 @@ func (p *PaymentService) Webhook(c context.Context, req *dto.Webhook) error {
 		})
 
 		if req.TransactionStatus == constants.SettlementString {
-			paidDay := paidAt.Format("02")
-			paidMonth := p.ConvertToIndonesianMonth(paidAt.Format("January"))
-			paidYear := paidAt.Format("2006")
 			invoiceNum := fmt.Sprintf("INV/%s/ORD/%d", time.Now().Format(time.DateOnly), p.RandomNum())
-			total := util.RupiahFormat(&paymentAfterUpdate.Amount)
 			invoiceReq := dto.InvoiceRequest{
 				InvoiceNumber: invoiceNum,
-				Data: dto.InvoiceData{
-					PaymentDetail: dto.InvoicePaymentDetail{
-						PaymentMethod: req.PaymentType,
-						BankName:      strings.ToUpper(*paymentAfterUpdate.Bank),
-						VANumber:      *paymentAfterUpdate.VANumber,
-						Date:          fmt.Sprintf("%s %s %s", paidDay, paidMonth, paidYear),
-						IsPaid:        true,
-					},
-					Items: []dto.InvoiceItem{
-						{
-							Description: *paymentAfterUpdate.Description,
-							Price:       total,
-						},
-					},
-					Total: total,
-				},
+				Data:          p.invoiceData(paymentAfterUpdate, *paidAt),
 			}
 			pdf, txErr = p.generatePDF(&invoiceReq)
 			if txErr != nil {
 				return txErr
 			}
 
-			invoiceLink, txErr = p.uploadToGCS(c, invoiceNum, pdf)
+			invoiceLink, txErr = p.uploadInvoice(c, invoiceNum, pdf)
 			if txErr != nil {
 				return txErr
 			}
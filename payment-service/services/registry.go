@@ -1,8 +1,8 @@
 package services
 
 import (
+	"common/storage"
 	clients "payment-service/clients/midtrans"
-	"payment-service/common/gcs"
 	"payment-service/controllers/kafka"
 	"payment-service/repositories"
 	services "payment-service/services/payment"
@@ -10,7 +10,7 @@ import (
 
 type Registry struct {
 	repository repositories.IRepositoryRegistry
-	gcs        gcs.IGCSClient
+	storage    storage.IStorageClient
 	kafka      kafka.IKafkaRegistry
 	midtrans   clients.IMidtransClient
 }
@@ -19,15 +19,15 @@ type IServiceRegistry interface {
 	GetPayment() services.IPaymentService
 }
 
-func NewServiceRegistry(repository repositories.IRepositoryRegistry, gcs gcs.IGCSClient, kafka kafka.IKafkaRegistry, midtrans clients.IMidtransClient) IServiceRegistry {
+func NewServiceRegistry(repository repositories.IRepositoryRegistry, storage storage.IStorageClient, kafka kafka.IKafkaRegistry, midtrans clients.IMidtransClient) IServiceRegistry {
 	return &Registry{
 		repository: repository,
-		gcs:        gcs,
+		storage:    storage,
 		kafka:      kafka,
 		midtrans:   midtrans,
 	}
 }
 
 func (r *Registry) GetPayment() services.IPaymentService {
-	return services.NewPaymentService(r.repository, r.gcs, r.kafka, r.midtrans)
+	return services.NewPaymentService(r.repository, r.storage, r.kafka, r.midtrans)
 }
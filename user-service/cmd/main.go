@@ -1,10 +1,15 @@
 package cmd
 
 import (
+	"common/signature"
+	"context"
 	"fmt"
 	"net/http"
+	"net/url"
 	"time"
+	"user-service/common/oidc"
 	"user-service/common/response"
+	"user-service/common/sms"
 	"user-service/config"
 	"user-service/constants"
 	"user-service/controllers"
@@ -19,6 +24,7 @@ import (
 	"github.com/didip/tollbooth/limiter"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
@@ -42,6 +48,12 @@ var command = &cobra.Command{
 		err = db.AutoMigrate(
 			&models.Role{},
 			&models.User{},
+			&models.RecoveryCode{},
+			&models.UserIdentity{},
+			&models.OIDCState{},
+			&models.PhoneOTP{},
+			&models.ChallengeAttempt{},
+			&signature.Nonce{},
 		)
 		if err != nil {
 			panic(err)
@@ -49,7 +61,7 @@ var command = &cobra.Command{
 
 		seeders.NewSeederRegistry(db).Run()
 		repositories := repositories.NewRepositoryRegistry(db)
-		service := services.NewServiceRegistry(repositories)
+		service := services.NewServiceRegistry(repositories, initOIDC(), initSMSSender())
 		controller := controllers.NewControllerREgistry(service)
 
 		router := gin.Default()
@@ -67,8 +79,23 @@ var command = &cobra.Command{
 			})
 		})
 
+		// The frontend that social logins return to is allowed to send
+		// cookies, so the callback receives the state cookie.
+		frontendOrigin := ""
+		redirectURL, err := url.Parse(config.Cfg.OIDC.RedirectURL)
+		if err == nil && redirectURL.Host != "" {
+			frontendOrigin = fmt.Sprintf("%s://%s", redirectURL.Scheme, redirectURL.Host)
+		}
+
 		router.Use(func(c *gin.Context) {
-			c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+			origin := c.GetHeader("Origin")
+			if frontendOrigin != "" && origin == frontendOrigin {
+				c.Writer.Header().Set("Access-Control-Allow-Origin", origin)
+				c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+				c.Writer.Header().Set("Vary", "Origin")
+			} else {
+				c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+			}
 			c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, PATCH, OPTIONS")
 			c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, x-service-name, x-request-at, x-api-key")
 			if c.Request.Method == "OPTIONS" {
@@ -86,7 +113,8 @@ var command = &cobra.Command{
 		router.Use(middlewares.RateLimiter(lmt))
 
 		group := router.Group("/api/v1")
-		route := routes.NewRouteRegistry(controller, group)
+		internalGroup := router.Group("/internal/v1", middlewares.AuthenticateInternal(signature.NewPostgresNonceStore(db)))
+		route := routes.NewRouteRegistry(controller, service, group, internalGroup)
 		route.Serve()
 
 		port := fmt.Sprintf(":%d", config.Cfg.Port)
@@ -100,3 +128,39 @@ func Run() {
 		panic(err)
 	}
 }
+
+// initOIDC returns nil when social login is not configured or the provider
+// cannot be discovered, in which case the OIDC endpoints answer with an error
+// and password login keeps working.
+func initOIDC() oidc.IOIDCClient {
+	if config.Cfg.OIDC.IssuerURL == "" {
+		return nil
+	}
+
+	client, err := oidc.NewOIDCClient(context.Background(), oidc.Config{
+		Provider:     config.Cfg.OIDC.Provider,
+		IssuerURL:    config.Cfg.OIDC.IssuerURL,
+		ClientID:     config.Cfg.OIDC.ClientID,
+		ClientSecret: config.Cfg.OIDC.ClientSecret,
+		RedirectURL:  config.Cfg.OIDC.RedirectURL,
+		Scopes:       config.Cfg.OIDC.Scopes,
+	})
+	if err != nil {
+		logrus.Errorf("social login disabled: %v", err)
+		return nil
+	}
+
+	return client
+}
+
+// initSMSSender picks the gateway used for OTP messages. Only the console
+// stand-in ships with the service; gateways plug in by implementing
+// sms.ISender.
+func initSMSSender() sms.ISender {
+	switch config.Cfg.OTP.Sender {
+	case "", "console":
+		return sms.NewConsoleSender()
+	default:
+		panic(fmt.Sprintf("unknown otp sender %q", config.Cfg.OTP.Sender))
+	}
+}
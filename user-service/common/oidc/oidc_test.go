@@ -0,0 +1,208 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"golang.org/x/oauth2"
+)
+
+const (
+	testClientID = "mini-soccer"
+	testCode     = "authorization-code"
+	testKeyID    = "test-key"
+)
+
+// mockProvider is a minimal OpenID provider. It issues an ID token for
+// testCode only when the PKCE verifier matches the challenge of the
+// authorization request.
+type mockProvider struct {
+	server    *httptest.Server
+	key       *rsa.PrivateKey
+	challenge string
+	nonce     string
+}
+
+func newMockProvider(t *testing.T) *mockProvider {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	p := &mockProvider{key: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", p.discovery)
+	mux.HandleFunc("/jwks", p.jwks)
+	mux.HandleFunc("/token", p.token)
+	p.server = httptest.NewServer(mux)
+	t.Cleanup(p.server.Close)
+
+	return p
+}
+
+func (p *mockProvider) discovery(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"issuer":                                p.server.URL,
+		"authorization_endpoint":                p.server.URL + "/authorize",
+		"token_endpoint":                        p.server.URL + "/token",
+		"jwks_uri":                              p.server.URL + "/jwks",
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+	})
+}
+
+func (p *mockProvider) jwks(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"keys": []map[string]string{
+			{
+				"kty": "RSA",
+				"kid": testKeyID,
+				"alg": "RS256",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(p.key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(p.key.E)).Bytes()),
+			},
+		},
+	})
+}
+
+func (p *mockProvider) token(w http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm()
+	if err != nil || r.PostForm.Get("grant_type") != "authorization_code" || r.PostForm.Get("code") != testCode {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	hash := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if base64.RawURLEncoding.EncodeToString(hash[:]) != p.challenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	now := time.Now()
+	idToken := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":            p.server.URL,
+		"sub":            "provider-subject",
+		"aud":            testClientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Hour).Unix(),
+		"nonce":          p.nonce,
+		"email":          "player@example.com",
+		"email_verified": true,
+		"name":           "Player One",
+	})
+	idToken.Header["kid"] = testKeyID
+
+	signed, err := idToken.SignedString(p.key)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": "access-token",
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     signed,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// authorize starts a login and records what the provider would have received
+// from the browser.
+func authorize(t *testing.T, provider *mockProvider, client IOIDCClient, nonce, codeVerifier string) {
+	t.Helper()
+
+	authURL, err := url.Parse(client.AuthCodeURL("state", nonce, codeVerifier))
+	if err != nil {
+		t.Fatalf("parse authorization url: %v", err)
+	}
+
+	query := authURL.Query()
+	if query.Get("code_challenge_method") != "S256" {
+		t.Fatalf("code_challenge_method = %q, want S256", query.Get("code_challenge_method"))
+	}
+
+	provider.challenge = query.Get("code_challenge")
+	provider.nonce = query.Get("nonce")
+}
+
+func newTestClient(t *testing.T, provider *mockProvider) IOIDCClient {
+	t.Helper()
+
+	client, err := NewOIDCClient(context.Background(), Config{
+		Provider:     "mock",
+		IssuerURL:    provider.server.URL,
+		ClientID:     testClientID,
+		ClientSecret: "secret",
+		RedirectURL:  "http://localhost:3000/auth/callback",
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	return client
+}
+
+func TestExchange(t *testing.T) {
+	provider := newMockProvider(t)
+	client := newTestClient(t, provider)
+
+	codeVerifier := oauth2.GenerateVerifier()
+	authorize(t, provider, client, "nonce", codeVerifier)
+
+	identity, err := client.Exchange(context.Background(), testCode, codeVerifier, "nonce")
+	if err != nil {
+		t.Fatalf("exchange: %v", err)
+	}
+
+	if identity.Provider != "mock" || identity.Subject != "provider-subject" {
+		t.Errorf("identity = %s/%s, want mock/provider-subject", identity.Provider, identity.Subject)
+	}
+
+	if identity.Email != "player@example.com" || !identity.EmailVerified || identity.Name != "Player One" {
+		t.Errorf("unexpected claims: %+v", identity)
+	}
+}
+
+func TestExchangeRejectsWrongVerifier(t *testing.T) {
+	provider := newMockProvider(t)
+	client := newTestClient(t, provider)
+
+	authorize(t, provider, client, "nonce", oauth2.GenerateVerifier())
+
+	_, err := client.Exchange(context.Background(), testCode, oauth2.GenerateVerifier(), "nonce")
+	if err == nil {
+		t.Fatal("exchange succeeded with a verifier that does not match the challenge")
+	}
+}
+
+func TestExchangeRejectsNonceMismatch(t *testing.T) {
+	provider := newMockProvider(t)
+	client := newTestClient(t, provider)
+
+	codeVerifier := oauth2.GenerateVerifier()
+	authorize(t, provider, client, "nonce", codeVerifier)
+
+	_, err := client.Exchange(context.Background(), testCode, codeVerifier, "another-nonce")
+	if err == nil {
+		t.Fatal("exchange succeeded with a nonce from another login")
+	}
+}
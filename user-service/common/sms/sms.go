@@ -0,0 +1,25 @@
+package sms
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ISender delivers short text messages to a phone number. Implementations
+// wrap an SMS or WhatsApp gateway; ConsoleSender is used for local
+// development.
+type ISender interface {
+	Send(ctx context.Context, phoneNumber, message string) error
+}
+
+type ConsoleSender struct{}
+
+func NewConsoleSender() ISender {
+	return &ConsoleSender{}
+}
+
+func (c *ConsoleSender) Send(_ context.Context, phoneNumber, message string) error {
+	logrus.Infof("sms to %s: %s", phoneNumber, message)
+	return nil
+}
@@ -11,15 +11,38 @@ import (
 var Cfg AppConfig
 
 type AppConfig struct {
-	Port                  int      `json:"port"`
-	AppName               string   `json:"appName"`
-	AppEnv                string   `json:"appEnv"`
-	SignatureKey          string   `json:"signatureKey"`
-	Database              Database `json:"database"`
-	RateLimiterMaxRequest int      `json:"rateLimiterMaxRequest"`
-	RateLimiterTimeSecond int      `json:"rateLimiterTimeSecond"`
-	JWTSecretKey          string   `json:"jwtSecretKey"`
-	JWTExpirationTime     int      `json:"jwtExpirationTime"` // in minutes
+	Port                   int               `json:"port"`
+	AppName                string            `json:"appName"`
+	AppEnv                 string            `json:"appEnv"`
+	SignatureKey           string            `json:"signatureKey"`
+	Database               Database          `json:"database"`
+	RateLimiterMaxRequest  int               `json:"rateLimiterMaxRequest"`
+	RateLimiterTimeSecond  int               `json:"rateLimiterTimeSecond"`
+	JWTSecretKey           string            `json:"jwtSecretKey"`
+	JWTExpirationTime      int               `json:"jwtExpirationTime"` // in minutes
+	TOTPIssuer             string            `json:"totpIssuer"`
+	MFATokenExpiration     int               `json:"mfaTokenExpiration"` // in minutes
+	OIDC                   OIDC              `json:"oidc"`
+	OTP                    OTP               `json:"otp"`
+	InternalCallers        map[string]string `json:"internalCallers"`
+	SignatureMaxSkewSecond int               `json:"signatureMaxSkewSecond"`
+}
+
+type OIDC struct {
+	Provider        string   `json:"provider"`
+	IssuerURL       string   `json:"issuerURL"`
+	ClientID        string   `json:"clientID"`
+	ClientSecret    string   `json:"clientSecret"`
+	RedirectURL     string   `json:"redirectURL"`
+	Scopes          []string `json:"scopes"`
+	StateExpiration int      `json:"stateExpiration"` // in minutes
+}
+
+type OTP struct {
+	Sender         string `json:"sender"`
+	Expiration     int    `json:"expiration"` // in minutes
+	MaxAttempts    int    `json:"maxAttempts"`
+	ResendInterval int    `json:"resendInterval"` // in seconds
 }
 
 type Database struct {
@@ -4,3 +4,7 @@ const (
 	UserLogin = "user_login"
 	Token     = "token"
 )
+
+// OIDCStateCookie ties the state of a social login to the browser that
+// started it, so a callback cannot be replayed in another browser.
+const OIDCStateCookie = "oidc_state"
@@ -2,7 +2,11 @@ package error
 
 func ErrMapping(err error) bool {
 	allErrors := make([]error, 0)
-	allErrors = append(append(GeneralErrors[:], UserErrors[:]...))
+	allErrors = append(allErrors, GeneralErrors...)
+	allErrors = append(allErrors, UserErrors...)
+	allErrors = append(allErrors, MFAErrors...)
+	allErrors = append(allErrors, OIDCErrors...)
+	allErrors = append(allErrors, OTPErrors...)
 
 	for _, item := range allErrors {
 		if err.Error() == item.Error() {
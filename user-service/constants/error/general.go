@@ -9,6 +9,9 @@ var (
 	ErrUnauthorized        = errors.New("unauthorized")
 	ErrInvalidToken        = errors.New("invalid token")
 	ErrForbidden           = errors.New("forbidden")
+	ErrInvalidSignature    = errors.New("invalid request signature")
+	ErrRequestExpired      = errors.New("request expired")
+	ErrRequestReplayed     = errors.New("request already processed")
 )
 
 var GeneralErrors = []error{
@@ -16,4 +19,5 @@ var GeneralErrors = []error{
 	ErrSQLError,
 	ErrToManyRequests,
 	ErrUnauthorized, ErrInvalidToken, ErrForbidden,
+	ErrInvalidSignature, ErrRequestExpired, ErrRequestReplayed,
 }
@@ -0,0 +1,25 @@
+package error
+
+import "errors"
+
+var (
+	ErrInvalidOTPCode      = errors.New("invalid verification code")
+	ErrInvalidMFAToken     = errors.New("invalid two-factor token")
+	ErrMFANotEnrolled      = errors.New("two-factor authentication is not enrolled")
+	ErrMFAAlreadyEnabled   = errors.New("two-factor authentication already enabled")
+	ErrMFASetupNotStarted  = errors.New("two-factor setup has not been started")
+	ErrMFARequiredForAdmin = errors.New("two-factor authentication is mandatory for admin")
+	ErrMFATooManyAttempts  = errors.New("too many invalid two-factor attempts, please log in again")
+	ErrMFALocked           = errors.New("two-factor login is locked after too many invalid attempts, please try again later")
+)
+
+var MFAErrors = []error{
+	ErrInvalidOTPCode,
+	ErrInvalidMFAToken,
+	ErrMFANotEnrolled,
+	ErrMFAAlreadyEnabled,
+	ErrMFASetupNotStarted,
+	ErrMFARequiredForAdmin,
+	ErrMFATooManyAttempts,
+	ErrMFALocked,
+}
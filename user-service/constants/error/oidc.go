@@ -0,0 +1,17 @@
+package error
+
+import "errors"
+
+var (
+	ErrOIDCNotConfigured   = errors.New("social login is not configured")
+	ErrInvalidOIDCState    = errors.New("invalid or expired login state")
+	ErrOIDCLoginFailed     = errors.New("failed to sign in with provider")
+	ErrOIDCEmailUnverified = errors.New("provider email is not verified")
+)
+
+var OIDCErrors = []error{
+	ErrOIDCNotConfigured,
+	ErrInvalidOIDCState,
+	ErrOIDCLoginFailed,
+	ErrOIDCEmailUnverified,
+}
@@ -0,0 +1,21 @@
+package error
+
+import "errors"
+
+var (
+	ErrOTPNotFound          = errors.New("verification code expired or not requested")
+	ErrOTPTooManyAttempts   = errors.New("too many invalid verification attempts")
+	ErrOTPResendTooSoon     = errors.New("please wait before requesting another code")
+	ErrPhoneNotVerified     = errors.New("phone number is not verified")
+	ErrPhoneExists          = errors.New("phone number already exist")
+	ErrPhoneAlreadyVerified = errors.New("phone number already verified")
+)
+
+var OTPErrors = []error{
+	ErrOTPNotFound,
+	ErrOTPTooManyAttempts,
+	ErrOTPResendTooSoon,
+	ErrPhoneNotVerified,
+	ErrPhoneExists,
+	ErrPhoneAlreadyVerified,
+}
@@ -8,6 +8,11 @@ var (
 	ErrUsernameExists       = errors.New("username already exist")
 	ErrEmailExists          = errors.New("email already exist")
 	ErrPasswordDoesNotMatch = errors.New("password does not match")
+	ErrUserInactive         = errors.New("user is inactive")
+	ErrRoleNotFound         = errors.New("role not found")
+	ErrCannotModifySelf     = errors.New("cannot change your own account")
+	ErrInvalidResetToken    = errors.New("invalid password reset token")
+	ErrInvalidFeedToken     = errors.New("invalid calendar feed token")
 )
 
 var UserErrors = []error{
@@ -16,4 +21,9 @@ var UserErrors = []error{
 	ErrPasswordIncorrect,
 	ErrUsernameExists,
 	ErrEmailExists,
+	ErrUserInactive,
+	ErrRoleNotFound,
+	ErrCannotModifySelf,
+	ErrInvalidResetToken,
+	ErrInvalidFeedToken,
 }
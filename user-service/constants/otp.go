@@ -0,0 +1,7 @@
+package constants
+
+const (
+	OTPPurposeLogin       = "login"
+	OTPPurposeVerifyPhone = "verify_phone"
+	OTPPurposeChangePhone = "change_phone"
+)
@@ -3,4 +3,7 @@ package constants
 const (
 	Admin    = 1
 	Customer = 2
+
+	AdminCode    = "admin"
+	CustomerCode = "customer"
 )
@@ -0,0 +1,162 @@
+package controllers
+
+import (
+	"net/http"
+	errWrap "user-service/common/error"
+	"user-service/common/response"
+	"user-service/domain/dto"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+func (h *UserController) GetAllUsers(ctx *gin.Context) {
+	var params dto.UserRequestParam
+	err := ctx.ShouldBindQuery(&params)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(params)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResp := errWrap.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:    http.StatusUnprocessableEntity,
+			Message: &errMessage,
+			Data:    errResp,
+			Err:     err,
+			Gin:     ctx,
+		})
+		return
+	}
+
+	result, err := h.service.GetUser().GetAllUsers(ctx.Request.Context(), &params)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  ctx,
+	})
+}
+
+func (h *UserController) GetUserDetail(ctx *gin.Context) {
+	result, err := h.service.GetUser().GetUserDetail(ctx.Request.Context(), ctx.Param("uuid"))
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  ctx,
+	})
+}
+
+func (h *UserController) ActivateUser(ctx *gin.Context) {
+	h.updateUserStatus(ctx, true)
+}
+
+func (h *UserController) DeactivateUser(ctx *gin.Context) {
+	h.updateUserStatus(ctx, false)
+}
+
+func (h *UserController) updateUserStatus(ctx *gin.Context, isActive bool) {
+	result, err := h.service.GetUser().UpdateUserStatus(ctx.Request.Context(), ctx.Param("uuid"), isActive)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  ctx,
+	})
+}
+
+func (h *UserController) UpdateUserRole(ctx *gin.Context) {
+	request := &dto.UpdateUserRoleRequest{}
+
+	err := ctx.ShouldBindJSON(request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResp := errWrap.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:    http.StatusUnprocessableEntity,
+			Message: &errMessage,
+			Data:    errResp,
+			Err:     err,
+			Gin:     ctx,
+		})
+		return
+	}
+
+	result, err := h.service.GetUser().UpdateUserRole(ctx.Request.Context(), ctx.Param("uuid"), request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  ctx,
+	})
+}
+
+func (h *UserController) ResetUserPassword(ctx *gin.Context) {
+	result, err := h.service.GetUser().ResetUserPassword(ctx.Request.Context(), ctx.Param("uuid"))
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  ctx,
+	})
+}
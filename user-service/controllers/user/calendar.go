@@ -0,0 +1,91 @@
+package controllers
+
+import (
+	"net/http"
+	errWrap "user-service/common/error"
+	"user-service/common/response"
+	"user-service/domain/dto"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+func (h *UserController) RotateCalendarFeedToken(ctx *gin.Context) {
+	result, err := h.service.GetUser().RotateCalendarFeedToken(ctx.Request.Context())
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  ctx,
+	})
+}
+
+func (h *UserController) RevokeCalendarFeedToken(ctx *gin.Context) {
+	err := h.service.GetUser().RevokeCalendarFeedToken(ctx.Request.Context())
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Gin:  ctx,
+	})
+}
+
+func (h *UserController) GetUserByCalendarFeedToken(ctx *gin.Context) {
+	request := &dto.CalendarFeedTokenRequest{}
+
+	err := ctx.ShouldBindJSON(request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResp := errWrap.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:    http.StatusUnprocessableEntity,
+			Message: &errMessage,
+			Data:    errResp,
+			Err:     err,
+			Gin:     ctx,
+		})
+		return
+	}
+
+	result, err := h.service.GetUser().GetUserByCalendarFeedToken(ctx.Request.Context(), request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusUnauthorized,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  ctx,
+	})
+}
@@ -0,0 +1,84 @@
+package controllers
+
+import (
+	"net/http"
+	errWrap "user-service/common/error"
+	"user-service/common/response"
+	"user-service/config"
+	"user-service/constants"
+	"user-service/domain/dto"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+func (h *UserController) OIDCLogin(ctx *gin.Context) {
+	result, err := h.service.GetUser().OIDCLogin(ctx)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	setOIDCStateCookie(ctx, result.State, config.Cfg.OIDC.StateExpiration*60)
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  ctx,
+	})
+}
+
+func (h *UserController) OIDCCallback(ctx *gin.Context) {
+	request := &dto.OIDCCallbackRequest{}
+
+	err := ctx.ShouldBindJSON(request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResp := errWrap.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:    http.StatusUnprocessableEntity,
+			Message: &errMessage,
+			Data:    errResp,
+			Err:     err,
+			Gin:     ctx,
+		})
+		return
+	}
+
+	// The state is single use, so the cookie is cleared whatever the outcome.
+	request.BrowserState, _ = ctx.Cookie(constants.OIDCStateCookie)
+	setOIDCStateCookie(ctx, "", -1)
+
+	login, err := h.service.GetUser().OIDCCallback(ctx, request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusUnauthorized,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	respondLogin(ctx, login)
+}
+
+// setOIDCStateCookie keeps the state of a social login in an HttpOnly cookie
+// for the callback to compare against. A negative maxAge removes it.
+func setOIDCStateCookie(ctx *gin.Context, state string, maxAge int) {
+	ctx.SetSameSite(http.SameSiteLaxMode)
+	ctx.SetCookie(constants.OIDCStateCookie, state, maxAge, "/api/v1/auth/oidc", "", config.Cfg.AppEnv != "local", true)
+}
@@ -21,6 +21,29 @@ type IUserController interface {
 	Update(*gin.Context)
 	GetUserLogin(*gin.Context)
 	GetUserByUUID(*gin.Context)
+	SetupMFAWithToken(*gin.Context)
+	VerifyMFALogin(*gin.Context)
+	SetupMFA(*gin.Context)
+	ConfirmMFA(*gin.Context)
+	RegenerateRecoveryCodes(*gin.Context)
+	DisableMFA(*gin.Context)
+	CompletePasswordReset(*gin.Context)
+	GetAllUsers(*gin.Context)
+	GetUserDetail(*gin.Context)
+	ActivateUser(*gin.Context)
+	DeactivateUser(*gin.Context)
+	UpdateUserRole(*gin.Context)
+	ResetUserPassword(*gin.Context)
+	OIDCLogin(*gin.Context)
+	OIDCCallback(*gin.Context)
+	RequestLoginOTP(*gin.Context)
+	VerifyLoginOTP(*gin.Context)
+	RequestPhoneVerification(*gin.Context)
+	ConfirmPhoneVerification(*gin.Context)
+	ConfirmPhoneChange(*gin.Context)
+	RotateCalendarFeedToken(*gin.Context)
+	RevokeCalendarFeedToken(*gin.Context)
+	GetUserByCalendarFeedToken(*gin.Context)
 }
 
 func NewUserController(service services.IServiceRegistry) IUserController {
@@ -65,14 +88,79 @@ func (h *UserController) Login(ctx *gin.Context) {
 		return
 	}
 
+	respondLogin(ctx, Login)
+}
+
+// respondLogin answers with the pending challenge when the login is not
+// complete yet, and with the user and access token otherwise.
+func respondLogin(ctx *gin.Context, login *dto.LoginResponse) {
+	if login.PasswordReset != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusOK,
+			Data: login.PasswordReset,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	if login.MFA != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusOK,
+			Data: login.MFA,
+			Gin:  ctx,
+		})
+		return
+	}
+
 	response.HttpResponse(response.ParamHTTPResp{
 		Code:  http.StatusOK,
-		Data:  Login.User,
-		Token: &Login.Token,
+		Data:  login.User,
+		Token: &login.Token,
 		Gin:   ctx,
 	})
 }
 
+func (h *UserController) CompletePasswordReset(ctx *gin.Context) {
+	request := &dto.CompletePasswordResetRequest{}
+
+	err := ctx.ShouldBindJSON(request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResp := errWrap.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:    http.StatusUnprocessableEntity,
+			Message: &errMessage,
+			Data:    errResp,
+			Err:     err,
+			Gin:     ctx,
+		})
+		return
+	}
+
+	login, err := h.service.GetUser().CompletePasswordReset(ctx, request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusUnauthorized,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	respondLogin(ctx, login)
+}
+
 func (h *UserController) Register(ctx *gin.Context) {
 	request := &dto.RegisterRequest{}
 
@@ -24,7 +24,7 @@ func RunUserSeeder(db *gorm.DB) {
 
 	err := db.FirstOrCreate(&user, models.User{Username: user.Username}).Error
 	if err != nil {
-		logrus.Errorf("failed to seed user: %w", err)
+		logrus.Errorf("failed to seed user: %v", err)
 		panic(err)
 	}
 	logrus.Infof("user %s successfully seeded", user.Username)
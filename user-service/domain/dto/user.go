@@ -1,6 +1,10 @@
 package dto
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type LoginRequest struct {
 	Username string `json:"username" validate:"required"`
@@ -8,17 +12,20 @@ type LoginRequest struct {
 }
 
 type UserResponse struct {
-	UUID        uuid.UUID `json:"uuid"`
-	Name        string    `json:"name"`
-	Username    string    `json:"username"`
-	Email       string    `json:"email"`
-	Role        string    `json:"role,omitempty"`
-	PhoneNumber string    `json:"phoneNumber"`
+	UUID               uuid.UUID `json:"uuid"`
+	Name               string    `json:"name"`
+	Username           string    `json:"username"`
+	Email              string    `json:"email"`
+	Role               string    `json:"role,omitempty"`
+	PhoneNumber        string    `json:"phoneNumber"`
+	PendingPhoneNumber *string   `json:"pendingPhoneNumber,omitempty"`
 }
 
 type LoginResponse struct {
-	User  UserResponse `json:"user"`
-	Token string       `json:"token"`
+	User          UserResponse                    `json:"user"`
+	Token         string                          `json:"token"`
+	MFA           *MFAChallengeResponse           `json:"mfa,omitempty"`
+	PasswordReset *PasswordResetChallengeResponse `json:"passwordReset,omitempty"`
 }
 
 type RegisterRequest struct {
@@ -42,5 +49,116 @@ type UpdateRequest struct {
 	ConfirmPass string `json:"confirmPass,omitempty"`
 	Email       string `json:"email" validate:"required,email"`
 	PhoneNumber string `json:"phoneNumber" validate:"required"`
-	RoleID      uint
+}
+
+type MFAChallengeResponse struct {
+	MFAToken           string `json:"mfaToken"`
+	EnrollmentRequired bool   `json:"enrollmentRequired"`
+}
+
+type MFATokenRequest struct {
+	MFAToken string `json:"mfaToken" validate:"required"`
+}
+
+type MFAVerifyRequest struct {
+	MFAToken string `json:"mfaToken" validate:"required"`
+	Code     string `json:"code" validate:"required"`
+}
+
+type MFACodeRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+type MFASetupResponse struct {
+	Secret     string `json:"secret"`
+	OtpauthURI string `json:"otpauthURI"`
+}
+
+type MFARecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+type MFALoginResponse struct {
+	User          UserResponse `json:"user"`
+	RecoveryCodes []string     `json:"recoveryCodes,omitempty"`
+	Token         string       `json:"-"`
+}
+
+type UserRequestParam struct {
+	Page       int     `form:"page" validate:"required"`
+	Limit      int     `form:"limit" validate:"required"`
+	Search     *string `form:"search"`
+	Role       *string `form:"role" validate:"omitempty,oneof=admin customer"`
+	IsActive   *bool   `form:"isActive"`
+	SortColumn *string `form:"sortColumn" validate:"omitempty,oneof=name username email created_at"`
+	SortOrder  *string `form:"sortOrder" validate:"omitempty,oneof=asc desc"`
+}
+
+type UserDetailResponse struct {
+	UUID                  uuid.UUID  `json:"uuid"`
+	Name                  string     `json:"name"`
+	Username              string     `json:"username"`
+	Email                 string     `json:"email"`
+	PhoneNumber           string     `json:"phoneNumber"`
+	Role                  string     `json:"role"`
+	IsActive              bool       `json:"isActive"`
+	MFAEnabled            bool       `json:"mfaEnabled"`
+	PasswordResetRequired bool       `json:"passwordResetRequired"`
+	CreatedAt             *time.Time `json:"createdAt"`
+	UpdatedAt             *time.Time `json:"updatedAt"`
+}
+
+type UpdateUserRoleRequest struct {
+	Role string `json:"role" validate:"required,oneof=admin customer"`
+}
+
+type ResetPasswordResponse struct {
+	TemporaryPassword string `json:"temporaryPassword"`
+}
+
+type PasswordResetChallengeResponse struct {
+	ResetToken string `json:"resetToken"`
+}
+
+type CompletePasswordResetRequest struct {
+	ResetToken  string `json:"resetToken" validate:"required"`
+	Password    string `json:"password" validate:"required"`
+	ConfirmPass string `json:"confirmPass" validate:"required"`
+}
+
+type OIDCLoginResponse struct {
+	AuthorizationURL string `json:"authorizationURL"`
+	State            string `json:"-"`
+}
+
+type OIDCCallbackRequest struct {
+	Code         string `json:"code" validate:"required"`
+	State        string `json:"state" validate:"required"`
+	BrowserState string `json:"-"`
+}
+
+type OTPLoginRequest struct {
+	PhoneNumber string `json:"phoneNumber" validate:"required"`
+}
+
+type OTPLoginVerifyRequest struct {
+	PhoneNumber string `json:"phoneNumber" validate:"required"`
+	Code        string `json:"code" validate:"required"`
+}
+
+type PhoneCodeRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+type CalendarFeedTokenRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type CalendarFeedTokenResponse struct {
+	Token string `json:"token"`
+}
+
+type OTPSentResponse struct {
+	PhoneNumber string    `json:"phoneNumber"`
+	ExpiresAt   time.Time `json:"expiresAt"`
 }
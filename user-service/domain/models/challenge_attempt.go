@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// ChallengeAttempt counts the codes tried with a challenge token, so a token
+// cannot be used to guess codes for its whole lifetime.
+type ChallengeAttempt struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement"`
+	TokenID   string    `gorm:"type:varchar(36);not null;uniqueIndex"`
+	Attempts  int       `gorm:"type:int;not null;default:0"`
+	ExpiresAt time.Time `gorm:"not null"`
+	CreatedAt *time.Time
+	UpdatedAt *time.Time
+}
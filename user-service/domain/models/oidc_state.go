@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// OIDCState holds the values generated when a social login starts, until the
+// provider redirects back with the authorization code.
+type OIDCState struct {
+	ID           uint      `gorm:"primaryKey;autoIncrement"`
+	State        string    `gorm:"type:varchar(64);not null;uniqueIndex"`
+	Nonce        string    `gorm:"type:varchar(64);not null"`
+	CodeVerifier string    `gorm:"type:varchar(128);not null"`
+	ExpiresAt    time.Time `gorm:"not null"`
+	CreatedAt    *time.Time
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+type PhoneOTP struct {
+	ID          uint      `gorm:"primaryKey;autoIncrement"`
+	UserID      uint      `gorm:"type:bigint;not null;index"`
+	Purpose     string    `gorm:"type:varchar(20);not null"`
+	PhoneNumber string    `gorm:"type:varchar(15);not null"`
+	CodeHash    string    `gorm:"type:varchar(64);not null"`
+	Attempts    int       `gorm:"type:int;not null;default:0"`
+	ExpiresAt   time.Time `gorm:"not null"`
+	ConsumedAt  *time.Time
+	CreatedAt   *time.Time
+	UpdatedAt   *time.Time
+	User        User `gorm:"foreignKey:user_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+type RecoveryCode struct {
+	ID        uint   `gorm:"primaryKey;autoIncrement"`
+	UserID    uint   `gorm:"type:bigint;not null;index"`
+	CodeHash  string `gorm:"type:varchar(64);not null"`
+	UsedAt    *time.Time
+	CreatedAt *time.Time
+	UpdatedAt *time.Time
+	User      User `gorm:"foreignKey:user_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
@@ -7,15 +7,25 @@ import (
 )
 
 type User struct {
-	ID          uint      `gorm:"primaryKey;autoIncrement"`
-	UUID        uuid.UUID `gorm:"type:uuid;not null"`
-	Name        string    `gorm:"type:varchar(100);not null"`
-	Username    string    `gorm:"type:varchar(15);not null"`
-	Password    string    `gorm:"type:varchar(255);not null"`
-	PhoneNumber string    `gorm:"type:varchar(15);not null"`
-	Email       string    `gorm:"type:varchar(100);not null"`
-	RoleID      uint      `gorm:"type:uint;not null"`
-	CreatedAt   *time.Time
-	UpdatdeAt   *time.Time
-	Role        Role `gorm:"foreignKey:role_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	ID                    uint       `gorm:"primaryKey;autoIncrement"`
+	UUID                  uuid.UUID  `gorm:"type:uuid;not null"`
+	Name                  string     `gorm:"type:varchar(100);not null"`
+	Username              string     `gorm:"type:varchar(15);not null"`
+	Password              string     `gorm:"type:varchar(255);not null"`
+	PhoneNumber           string     `gorm:"type:varchar(15);not null"`
+	Email                 string     `gorm:"type:varchar(100);not null"`
+	RoleID                uint       `gorm:"type:uint;not null"`
+	TOTPSecret            *string    `gorm:"column:totp_secret;type:varchar(64);default:null"`
+	TOTPEnabled           bool       `gorm:"column:totp_enabled;type:boolean;not null;default:false"`
+	TOTPLastStep          int64      `gorm:"column:totp_last_step;type:bigint;not null;default:0"`
+	MFAFailedAttempts     int        `gorm:"column:mfa_failed_attempts;type:int;not null;default:0"`
+	MFALockedUntil        *time.Time `gorm:"column:mfa_locked_until"`
+	IsActive              bool       `gorm:"type:boolean;not null;default:true"`
+	PasswordResetRequired bool       `gorm:"type:boolean;not null;default:false"`
+	PasswordChangedAt     *time.Time
+	PhoneVerifiedAt       *time.Time
+	CalendarFeedToken     *string `gorm:"type:varchar(64);uniqueIndex;default:null"`
+	CreatedAt             *time.Time
+	UpdatdeAt             *time.Time
+	Role                  Role `gorm:"foreignKey:role_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 }
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+type UserIdentity struct {
+	ID        uint   `gorm:"primaryKey;autoIncrement"`
+	UserID    uint   `gorm:"type:bigint;not null;index"`
+	Provider  string `gorm:"type:varchar(30);not null;uniqueIndex:idx_user_identity_provider_subject"`
+	Subject   string `gorm:"type:varchar(255);not null;uniqueIndex:idx_user_identity_provider_subject"`
+	Email     string `gorm:"type:varchar(100)"`
+	CreatedAt *time.Time
+	UpdatedAt *time.Time
+	User      User `gorm:"foreignKey:user_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
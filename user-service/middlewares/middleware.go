@@ -1,17 +1,24 @@
 package middlewares
 
 import (
+	"bytes"
+	"common/signature"
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"io"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 	"user-service/common/response"
 	"user-service/config"
 	"user-service/constants"
 	errConstant "user-service/constants/error"
-	services "user-service/services/user"
+	"user-service/domain/dto"
+	"user-service/services"
+	serviceUser "user-service/services/user"
 
 	"github.com/didip/tollbooth"
 	"github.com/didip/tollbooth/limiter"
@@ -84,7 +91,7 @@ func validateAPIKEY(c *gin.Context) error {
 	return nil
 }
 
-func ValidateBearerToken(c *gin.Context, token string) error {
+func ValidateBearerToken(c *gin.Context, token string, service services.IServiceRegistry) error {
 	if !strings.Contains(token, "Bearer") {
 		return errConstant.ErrUnauthorized
 	}
@@ -94,7 +101,7 @@ func ValidateBearerToken(c *gin.Context, token string) error {
 		return errConstant.ErrUnauthorized
 	}
 
-	claims := &services.Claims{}
+	claims := &serviceUser.Claims{}
 	tokenJwt, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
 		_, ok := t.Method.(*jwt.SigningMethodHMAC)
 		if !ok {
@@ -103,17 +110,45 @@ func ValidateBearerToken(c *gin.Context, token string) error {
 		jwtSecret := []byte(config.Cfg.JWTSecretKey)
 		return jwtSecret, nil
 	})
-	if err != nil || !tokenJwt.Valid {
+	if err != nil || !tokenJwt.Valid || claims.User == nil {
 		return errConstant.ErrUnauthorized
 	}
 
-	userLogin := c.Request.WithContext(context.WithValue(c.Request.Context(), constants.UserLogin, claims.User))
+	user, err := service.GetUser().ValidateSession(c.Request.Context(), claims)
+	if err != nil {
+		return errConstant.ErrUnauthorized
+	}
+
+	userLogin := c.Request.WithContext(context.WithValue(c.Request.Context(), constants.UserLogin, user))
 	c.Request = userLogin
 	c.Set(constants.Token, token)
 	return nil
 }
 
-func Authenticate() gin.HandlerFunc {
+func contains(roles []string, role string) bool {
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CheckRole must run after Authenticate, which puts the current user into the
+// request context.
+func CheckRole(roles []string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		user, ok := ctx.Request.Context().Value(constants.UserLogin).(*dto.UserResponse)
+		if !ok || !contains(roles, user.Role) {
+			responseUnauthorized(ctx, errConstant.ErrUnauthorized.Error())
+			return
+		}
+		ctx.Next()
+	}
+}
+
+func Authenticate(service services.IServiceRegistry) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		token := ctx.GetHeader(constants.Authorization)
 		if token == "" {
@@ -121,7 +156,7 @@ func Authenticate() gin.HandlerFunc {
 			return
 		}
 
-		err := ValidateBearerToken(ctx, token)
+		err := ValidateBearerToken(ctx, token, service)
 		if err != nil {
 			responseUnauthorized(ctx, err.Error())
 			return
@@ -135,3 +170,88 @@ func Authenticate() gin.HandlerFunc {
 		ctx.Next()
 	}
 }
+
+// AuthenticateToken only checks the access token. It is used on internal
+// routes, where AuthenticateInternal has already verified the caller.
+func AuthenticateToken(service services.IServiceRegistry) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		token := ctx.GetHeader(constants.Authorization)
+		if token == "" {
+			responseUnauthorized(ctx, errConstant.ErrUnauthorized.Error())
+			return
+		}
+
+		err := ValidateBearerToken(ctx, token, service)
+		if err != nil {
+			responseUnauthorized(ctx, err.Error())
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+const defaultSignatureMaxSkew = 5 * time.Minute
+
+// validateSignature checks a request signed with signature.Sign by one of the
+// callers listed in internalCallers. The timestamp must be within the skew
+// window and the nonce may only be used once inside that window.
+func validateSignature(c *gin.Context, nonces signature.INonceStore) error {
+	serviceName := c.GetHeader(constants.XserviceName)
+	requestAt := c.GetHeader(constants.XrequestAt)
+	nonce := c.GetHeader(constants.XNonce)
+	sign := c.GetHeader(constants.XSignature)
+
+	key, ok := config.Cfg.InternalCallers[serviceName]
+	if !ok || key == "" || nonce == "" || sign == "" {
+		return errConstant.ErrUnauthorized
+	}
+
+	unixTime, err := strconv.ParseInt(requestAt, 10, 64)
+	if err != nil {
+		return errConstant.ErrInvalidSignature
+	}
+
+	maxSkew := defaultSignatureMaxSkew
+	if config.Cfg.SignatureMaxSkewSecond > 0 {
+		maxSkew = time.Duration(config.Cfg.SignatureMaxSkewSecond) * time.Second
+	}
+
+	skew := time.Since(time.Unix(unixTime, 0))
+	if skew > maxSkew || skew < -maxSkew {
+		return errConstant.ErrRequestExpired
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return errConstant.ErrInvalidSignature
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	if !signature.Verify(key, serviceName, c.Request.Method, c.Request.URL.RequestURI(), body, requestAt, nonce, sign) {
+		return errConstant.ErrInvalidSignature
+	}
+
+	fresh, err := nonces.Use(c, fmt.Sprintf("%s:%s", serviceName, nonce), 2*maxSkew)
+	if err != nil {
+		return errConstant.ErrSQLError
+	}
+
+	if !fresh {
+		return errConstant.ErrRequestReplayed
+	}
+
+	return nil
+}
+
+func AuthenticateInternal(nonces signature.INonceStore) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		err := validateSignature(ctx, nonces)
+		if err != nil {
+			responseUnauthorized(ctx, err.Error())
+			return
+		}
+
+		ctx.Next()
+	}
+}
@@ -0,0 +1,62 @@
+package repositories
+
+import (
+	"context"
+	"time"
+	errWrap "user-service/common/error"
+	errConstant "user-service/constants/error"
+	"user-service/domain/models"
+
+	"gorm.io/gorm"
+)
+
+type ChallengeAttemptRepository struct {
+	db *gorm.DB
+}
+
+type IChallengeAttemptRepository interface {
+	Take(context.Context, string, time.Time, int) error
+	Exhaust(context.Context, string, int) error
+}
+
+func NewChallengeAttemptRepository(db *gorm.DB) IChallengeAttemptRepository {
+	return &ChallengeAttemptRepository{db: db}
+}
+
+// Take counts an attempt against the token in one statement, so concurrent
+// guesses cannot go past max. It fails once max attempts were taken.
+func (r *ChallengeAttemptRepository) Take(ctx context.Context, tokenID string, expiresAt time.Time, max int) error {
+	err := r.db.WithContext(ctx).Where("expires_at < ?", time.Now()).Delete(&models.ChallengeAttempt{}).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	var attempts []int
+	err = r.db.WithContext(ctx).Raw(`INSERT INTO challenge_attempts (token_id, attempts, expires_at, created_at, updated_at)
+		VALUES (?, 1, ?, now(), now())
+		ON CONFLICT (token_id) DO UPDATE
+		SET attempts = challenge_attempts.attempts + 1, updated_at = now()
+		WHERE challenge_attempts.attempts < ?
+		RETURNING attempts`, tokenID, expiresAt, max).Scan(&attempts).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	if len(attempts) == 0 {
+		return errWrap.WrapError(errConstant.ErrMFATooManyAttempts)
+	}
+
+	return nil
+}
+
+// Exhaust uses up the token once it has served its purpose.
+func (r *ChallengeAttemptRepository) Exhaust(ctx context.Context, tokenID string, max int) error {
+	err := r.db.WithContext(ctx).Model(&models.ChallengeAttempt{}).
+		Where("token_id = ?", tokenID).
+		Update("attempts", max).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package repositories
+
+import (
+	"context"
+	"time"
+	errWrap "user-service/common/error"
+	errConstant "user-service/constants/error"
+	"user-service/domain/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OIDCStateRepository struct {
+	db *gorm.DB
+}
+
+type IOIDCStateRepository interface {
+	Create(context.Context, *models.OIDCState) error
+	Consume(context.Context, string) (*models.OIDCState, error)
+}
+
+func NewOIDCStateRepository(db *gorm.DB) IOIDCStateRepository {
+	return &OIDCStateRepository{db: db}
+}
+
+func (r *OIDCStateRepository) Create(ctx context.Context, state *models.OIDCState) error {
+	err := r.db.WithContext(ctx).Where("expires_at < ?", time.Now()).Delete(&models.OIDCState{}).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	err = r.db.WithContext(ctx).Create(state).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+// Consume deletes the state and returns it in one statement, so a state can
+// only complete a single login.
+func (r *OIDCStateRepository) Consume(ctx context.Context, state string) (*models.OIDCState, error) {
+	var states []models.OIDCState
+	err := r.db.WithContext(ctx).Clauses(clause.Returning{}).
+		Where("state = ? AND expires_at > ?", state, time.Now()).
+		Delete(&states).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	if len(states) == 0 {
+		return nil, errWrap.WrapError(errConstant.ErrInvalidOIDCState)
+	}
+
+	return &states[0], nil
+}
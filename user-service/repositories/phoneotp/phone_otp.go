@@ -0,0 +1,97 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+	errWrap "user-service/common/error"
+	errConstant "user-service/constants/error"
+	"user-service/domain/models"
+
+	"gorm.io/gorm"
+)
+
+type PhoneOTPRepository struct {
+	db *gorm.DB
+}
+
+type IPhoneOTPRepository interface {
+	Create(context.Context, *models.PhoneOTP) error
+	FindActive(context.Context, uint, string) (*models.PhoneOTP, error)
+	TakeAttempt(context.Context, uint, int) error
+	Consume(context.Context, uint) error
+}
+
+func NewPhoneOTPRepository(db *gorm.DB) IPhoneOTPRepository {
+	return &PhoneOTPRepository{db: db}
+}
+
+// Create stores a new code and retires any code still pending for the same
+// user and purpose, so only the latest code sent can be used.
+func (r *PhoneOTPRepository) Create(ctx context.Context, otp *models.PhoneOTP) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.PhoneOTP{}).
+			Where("user_id = ? AND purpose = ? AND consumed_at IS NULL", otp.UserID, otp.Purpose).
+			Update("consumed_at", time.Now()).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Create(otp).Error
+	})
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+func (r *PhoneOTPRepository) FindActive(ctx context.Context, userID uint, purpose string) (*models.PhoneOTP, error) {
+	var otp models.PhoneOTP
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND purpose = ? AND consumed_at IS NULL AND expires_at > ?", userID, purpose, time.Now()).
+		Order("created_at desc").
+		First(&otp).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapError(errConstant.ErrOTPNotFound)
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &otp, nil
+}
+
+// TakeAttempt counts a guess against the code in one conditional update, so
+// concurrent guesses cannot go past max. It fails once max guesses were made.
+func (r *PhoneOTPRepository) TakeAttempt(ctx context.Context, id uint, max int) error {
+	result := r.db.WithContext(ctx).Model(&models.PhoneOTP{}).
+		Where("id = ? AND attempts < ?", id, max).
+		Update("attempts", gorm.Expr("attempts + 1"))
+	if result.Error != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	if result.RowsAffected == 0 {
+		return errWrap.WrapError(errConstant.ErrOTPTooManyAttempts)
+	}
+
+	return nil
+}
+
+// Consume is conditional on consumed_at being null so a code can never be
+// redeemed twice, even by concurrent requests.
+func (r *PhoneOTPRepository) Consume(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Model(&models.PhoneOTP{}).
+		Where("id = ? AND consumed_at IS NULL", id).
+		Update("consumed_at", time.Now())
+	if result.Error != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	if result.RowsAffected == 0 {
+		return errWrap.WrapError(errConstant.ErrOTPNotFound)
+	}
+
+	return nil
+}
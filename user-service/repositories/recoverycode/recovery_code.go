@@ -0,0 +1,85 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+	errWrap "user-service/common/error"
+	errConstant "user-service/constants/error"
+	"user-service/domain/models"
+
+	"gorm.io/gorm"
+)
+
+type RecoveryCodeRepository struct {
+	db *gorm.DB
+}
+
+type IRecoveryCodeRepository interface {
+	Replace(context.Context, uint, []string) error
+	Use(context.Context, uint, string) error
+	DeleteByUserID(context.Context, uint) error
+}
+
+func NewRecoveryCodeRepository(db *gorm.DB) IRecoveryCodeRepository {
+	return &RecoveryCodeRepository{db: db}
+}
+
+func (r *RecoveryCodeRepository) Replace(ctx context.Context, userID uint, hashes []string) error {
+	codes := make([]models.RecoveryCode, 0, len(hashes))
+	for _, hash := range hashes {
+		codes = append(codes, models.RecoveryCode{
+			UserID:   userID,
+			CodeHash: hash,
+		})
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Create(&codes).Error
+	})
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+// Use marks an unused recovery code as consumed. The update is conditional on
+// used_at being null so a code can never be redeemed twice.
+func (r *RecoveryCodeRepository) Use(ctx context.Context, userID uint, hash string) error {
+	var code models.RecoveryCode
+	err := r.db.WithContext(ctx).Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, hash).First(&code).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errWrap.WrapError(errConstant.ErrInvalidOTPCode)
+		}
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	result := r.db.WithContext(ctx).Model(&models.RecoveryCode{}).
+		Where("id = ? AND used_at IS NULL", code.ID).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	if result.RowsAffected == 0 {
+		return errWrap.WrapError(errConstant.ErrInvalidOTPCode)
+	}
+
+	return nil
+}
+
+func (r *RecoveryCodeRepository) DeleteByUserID(ctx context.Context, userID uint) error {
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
@@ -1,7 +1,13 @@
 package repositories
 
 import (
-	repositories "user-service/repositories/user"
+	repoChallengeAttempt "user-service/repositories/challengeattempt"
+	repoOIDCState "user-service/repositories/oidcstate"
+	repoPhoneOTP "user-service/repositories/phoneotp"
+	repoRecoveryCode "user-service/repositories/recoverycode"
+	repoRole "user-service/repositories/role"
+	repoUser "user-service/repositories/user"
+	repoUserIdentity "user-service/repositories/useridentity"
 
 	"gorm.io/gorm"
 )
@@ -11,13 +17,43 @@ type Registry struct {
 }
 
 type IRegistryRepository interface {
-	GetUser() repositories.IUserRepository
+	GetUser() repoUser.IUserRepository
+	GetRecoveryCode() repoRecoveryCode.IRecoveryCodeRepository
+	GetRole() repoRole.IRoleRepository
+	GetUserIdentity() repoUserIdentity.IUserIdentityRepository
+	GetOIDCState() repoOIDCState.IOIDCStateRepository
+	GetPhoneOTP() repoPhoneOTP.IPhoneOTPRepository
+	GetChallengeAttempt() repoChallengeAttempt.IChallengeAttemptRepository
 }
 
 func NewRepositoryRegistry(db *gorm.DB) IRegistryRepository {
 	return &Registry{db: db}
 }
 
-func (r *Registry) GetUser() repositories.IUserRepository {
-	return repositories.NewUserRepository(r.db)
+func (r *Registry) GetUser() repoUser.IUserRepository {
+	return repoUser.NewUserRepository(r.db)
+}
+
+func (r *Registry) GetRecoveryCode() repoRecoveryCode.IRecoveryCodeRepository {
+	return repoRecoveryCode.NewRecoveryCodeRepository(r.db)
+}
+
+func (r *Registry) GetRole() repoRole.IRoleRepository {
+	return repoRole.NewRoleRepository(r.db)
+}
+
+func (r *Registry) GetUserIdentity() repoUserIdentity.IUserIdentityRepository {
+	return repoUserIdentity.NewUserIdentityRepository(r.db)
+}
+
+func (r *Registry) GetOIDCState() repoOIDCState.IOIDCStateRepository {
+	return repoOIDCState.NewOIDCStateRepository(r.db)
+}
+
+func (r *Registry) GetPhoneOTP() repoPhoneOTP.IPhoneOTPRepository {
+	return repoPhoneOTP.NewPhoneOTPRepository(r.db)
+}
+
+func (r *Registry) GetChallengeAttempt() repoChallengeAttempt.IChallengeAttemptRepository {
+	return repoChallengeAttempt.NewChallengeAttemptRepository(r.db)
 }
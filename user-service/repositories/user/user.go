@@ -3,6 +3,9 @@ package repositories
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 	errWrap "user-service/common/error"
 	errConstant "user-service/constants/error"
 	"user-service/domain/dto"
@@ -22,6 +25,18 @@ type IUserRepository interface {
 	FindByEmail(context.Context, string) (*models.User, error)
 	FindByUsername(context.Context, string) (*models.User, error)
 	FindByUUID(context.Context, string) (*models.User, error)
+	UpdateTOTP(context.Context, uint, *string, bool) error
+	UpdateTOTPLastStep(context.Context, uint, int64) error
+	TakeMFAAttempt(context.Context, uint, int, time.Duration) error
+	ResetMFAAttempts(context.Context, uint) error
+	FindAllWithPagination(context.Context, *dto.UserRequestParam) ([]models.User, int64, error)
+	UpdateStatus(context.Context, uint, bool) error
+	UpdateRole(context.Context, uint, uint) error
+	UpdatePassword(context.Context, uint, string, bool) error
+	FindByVerifiedPhoneNumber(context.Context, string) (*models.User, error)
+	UpdatePhoneNumber(context.Context, uint, string) error
+	FindByCalendarFeedToken(context.Context, string) (*models.User, error)
+	UpdateCalendarFeedToken(context.Context, uint, *string) error
 }
 
 func NewUserRepository(db *gorm.DB) IUserRepository {
@@ -101,3 +116,206 @@ func (r *UserRepository) FindByUUID(ctx context.Context, uuid string) (*models.U
 
 	return &user, nil
 }
+
+func (r *UserRepository) UpdateTOTP(ctx context.Context, id uint, secret *string, enabled bool) error {
+	err := r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", id).
+		Select("totp_secret", "totp_enabled", "totp_last_step").
+		Updates(&models.User{TOTPSecret: secret, TOTPEnabled: enabled, TOTPLastStep: 0}).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+func (r *UserRepository) UpdateTOTPLastStep(ctx context.Context, id uint, step int64) error {
+	result := r.db.WithContext(ctx).Model(&models.User{}).
+		Where("id = ? AND totp_last_step < ?", id, step).
+		Update("totp_last_step", step)
+	if result.Error != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	if result.RowsAffected == 0 {
+		return errWrap.WrapError(errConstant.ErrInvalidOTPCode)
+	}
+
+	return nil
+}
+
+// TakeMFAAttempt counts a second factor tried by the user in one statement,
+// so concurrent guesses cannot go past max. The attempt that reaches max
+// locks the user out for lockout; the count starts over once that passes.
+func (r *UserRepository) TakeMFAAttempt(ctx context.Context, id uint, max int, lockout time.Duration) error {
+	attempts := "CASE WHEN mfa_locked_until IS NULL THEN mfa_failed_attempts + 1 ELSE 1 END"
+	result := r.db.WithContext(ctx).Model(&models.User{}).
+		Where("id = ? AND (mfa_locked_until IS NULL OR mfa_locked_until <= now())", id).
+		Updates(map[string]any{
+			"mfa_failed_attempts": gorm.Expr(attempts),
+			"mfa_locked_until": gorm.Expr("CASE WHEN "+attempts+" >= ? THEN now() + ? * interval '1 second' END",
+				max, int64(lockout.Seconds())),
+		})
+	if result.Error != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	if result.RowsAffected == 0 {
+		return errWrap.WrapError(errConstant.ErrMFALocked)
+	}
+
+	return nil
+}
+
+// ResetMFAAttempts clears the count once the user got the second factor
+// right.
+func (r *UserRepository) ResetMFAAttempts(ctx context.Context, id uint) error {
+	err := r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", id).
+		Updates(map[string]any{"mfa_failed_attempts": 0, "mfa_locked_until": nil}).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+func (r *UserRepository) FindAllWithPagination(ctx context.Context, param *dto.UserRequestParam) ([]models.User, int64, error) {
+	var (
+		users []models.User
+		sort  string
+		total int64
+	)
+
+	if param.SortColumn != nil {
+		order := "asc"
+		if param.SortOrder != nil {
+			order = *param.SortOrder
+		}
+		sort = fmt.Sprintf("users.%s %s", *param.SortColumn, order)
+	} else {
+		sort = "users.created_at desc"
+	}
+
+	filter := func(db *gorm.DB) *gorm.DB {
+		if param.Search != nil && *param.Search != "" {
+			search := fmt.Sprintf("%%%s%%", *param.Search)
+			db = db.Where(
+				"(users.name ILIKE ? OR users.username ILIKE ? OR users.email ILIKE ? OR users.phone_number ILIKE ?)",
+				search, search, search, search,
+			)
+		}
+		if param.Role != nil {
+			db = db.Joins("JOIN roles ON roles.id = users.role_id").
+				Where("roles.code = ?", strings.ToUpper(*param.Role))
+		}
+		if param.IsActive != nil {
+			db = db.Where("users.is_active = ?", *param.IsActive)
+		}
+		return db
+	}
+
+	limit := param.Limit
+	offset := (param.Page - 1) * limit
+
+	err := r.db.WithContext(ctx).Scopes(filter).Preload("Role").
+		Limit(limit).Offset(offset).Order(sort).Find(&users).Error
+	if err != nil {
+		return nil, 0, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	err = r.db.WithContext(ctx).Model(&models.User{}).Scopes(filter).Count(&total).Error
+	if err != nil {
+		return nil, 0, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return users, total, nil
+}
+
+func (r *UserRepository) UpdateStatus(ctx context.Context, id uint, isActive bool) error {
+	err := r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", id).
+		Update("is_active", isActive).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+func (r *UserRepository) UpdateRole(ctx context.Context, id uint, roleID uint) error {
+	err := r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", id).
+		Update("role_id", roleID).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+// UpdatePassword also stamps password_changed_at, which invalidates every
+// access token issued before the change.
+func (r *UserRepository) UpdatePassword(ctx context.Context, id uint, password string, resetRequired bool) error {
+	now := time.Now()
+	err := r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", id).
+		Select("password", "password_reset_required", "password_changed_at").
+		Updates(&models.User{Password: password, PasswordResetRequired: resetRequired, PasswordChangedAt: &now}).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+func (r *UserRepository) FindByVerifiedPhoneNumber(ctx context.Context, phoneNumber string) (*models.User, error) {
+	var user models.User
+	err := r.db.WithContext(ctx).Preload("Role").
+		Where("phone_number = ? AND phone_verified_at IS NOT NULL", phoneNumber).
+		First(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapError(errConstant.ErrUserNotFound)
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &user, nil
+}
+
+// UpdatePhoneNumber stores a phone number that has just been confirmed with
+// an OTP, and marks it verified.
+func (r *UserRepository) UpdatePhoneNumber(ctx context.Context, id uint, phoneNumber string) error {
+	now := time.Now()
+	err := r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", id).
+		Select("phone_number", "phone_verified_at").
+		Updates(&models.User{PhoneNumber: phoneNumber, PhoneVerifiedAt: &now}).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+func (r *UserRepository) FindByCalendarFeedToken(ctx context.Context, token string) (*models.User, error) {
+	var user models.User
+	err := r.db.WithContext(ctx).Preload("Role").
+		Where("calendar_feed_token = ?", token).
+		First(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapError(errConstant.ErrUserNotFound)
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &user, nil
+}
+
+// UpdateCalendarFeedToken stores the hash of a new feed token, or clears it
+// when token is nil.
+func (r *UserRepository) UpdateCalendarFeedToken(ctx context.Context, id uint, token *string) error {
+	err := r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", id).
+		Update("calendar_feed_token", token).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
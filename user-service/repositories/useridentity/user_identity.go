@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	errWrap "user-service/common/error"
+	errConstant "user-service/constants/error"
+	"user-service/domain/models"
+
+	"gorm.io/gorm"
+)
+
+type UserIdentityRepository struct {
+	db *gorm.DB
+}
+
+type IUserIdentityRepository interface {
+	FindByProviderSubject(context.Context, string, string) (*models.UserIdentity, error)
+	Create(context.Context, *models.UserIdentity) error
+	CreateWithUser(context.Context, *models.User, *models.UserIdentity) error
+}
+
+func NewUserIdentityRepository(db *gorm.DB) IUserIdentityRepository {
+	return &UserIdentityRepository{db: db}
+}
+
+func (r *UserIdentityRepository) FindByProviderSubject(ctx context.Context, provider, subject string) (*models.UserIdentity, error) {
+	var identity models.UserIdentity
+	err := r.db.WithContext(ctx).Preload("User.Role").
+		Where("provider = ? AND subject = ?", provider, subject).
+		First(&identity).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapError(errConstant.ErrUserNotFound)
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &identity, nil
+}
+
+func (r *UserIdentityRepository) Create(ctx context.Context, identity *models.UserIdentity) error {
+	err := r.db.WithContext(ctx).Create(identity).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+// CreateWithUser registers a new user together with its first identity.
+func (r *UserIdentityRepository) CreateWithUser(ctx context.Context, user *models.User, identity *models.UserIdentity) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Create(user).Error
+		if err != nil {
+			return err
+		}
+
+		identity.UserID = user.ID
+		return tx.Create(identity).Error
+	})
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package routes
+
+import (
+	"user-service/controllers"
+	"user-service/middlewares"
+	"user-service/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type InternalRoute struct {
+	controller controllers.IUserControllerRegistry
+	service    services.IServiceRegistry
+	group      *gin.RouterGroup
+}
+
+type IInternalRoute interface {
+	Run()
+}
+
+// NewInternalRoute registers endpoints that are only called by other
+// services. The group is expected to require middlewares.AuthenticateInternal.
+func NewInternalRoute(controller controllers.IUserControllerRegistry, service services.IServiceRegistry, group *gin.RouterGroup) IInternalRoute {
+	return &InternalRoute{controller: controller, service: service, group: group}
+}
+
+func (i *InternalRoute) Run() {
+	group := i.group.Group("/auth")
+	group.GET("/user", middlewares.AuthenticateToken(i.service), i.controller.GetUserController().GetUserLogin)
+	group.GET("/:uuid", i.controller.GetUserController().GetUserByUUID)
+	group.POST("/calendar-token/verify", i.controller.GetUserController().GetUserByCalendarFeedToken)
+}
@@ -2,28 +2,37 @@ package routes
 
 import (
 	"user-service/controllers"
+	routesI "user-service/routes/internal"
 	routes "user-service/routes/user"
+	"user-service/services"
 
 	"github.com/gin-gonic/gin"
 )
 
 type Registry struct {
-	controller controllers.IUserControllerRegistry
-	group      *gin.RouterGroup
+	controller    controllers.IUserControllerRegistry
+	service       services.IServiceRegistry
+	group         *gin.RouterGroup
+	internalGroup *gin.RouterGroup
 }
 
 type IRouteRegistry interface {
 	Serve()
 }
 
-func NewRouteRegistry(controller controllers.IUserControllerRegistry, group *gin.RouterGroup) IRouteRegistry {
-	return &Registry{controller: controller, group: group}
+func NewRouteRegistry(controller controllers.IUserControllerRegistry, service services.IServiceRegistry, group, internalGroup *gin.RouterGroup) IRouteRegistry {
+	return &Registry{controller: controller, service: service, group: group, internalGroup: internalGroup}
 }
 
 func (r *Registry) Serve() {
 	r.userRoute().Run()
+	r.internalRoute().Run()
 }
 
 func (r *Registry) userRoute() routes.IUserRoute {
-	return routes.NewUserROute(r.controller, r.group)
+	return routes.NewUserROute(r.controller, r.service, r.group)
+}
+
+func (r *Registry) internalRoute() routesI.IInternalRoute {
+	return routesI.NewInternalRoute(r.controller, r.service, r.internalGroup)
 }
@@ -1,14 +1,17 @@
 package routes
 
 import (
+	"user-service/constants"
 	"user-service/controllers"
 	"user-service/middlewares"
+	"user-service/services"
 
 	"github.com/gin-gonic/gin"
 )
 
 type UserRoute struct {
 	controller controllers.IUserControllerRegistry
+	service    services.IServiceRegistry
 	group      *gin.RouterGroup
 }
 
@@ -16,15 +19,40 @@ type IUserRoute interface {
 	Run()
 }
 
-func NewUserROute(controller controllers.IUserControllerRegistry, group *gin.RouterGroup) IUserRoute {
-	return &UserRoute{controller: controller, group: group}
+func NewUserROute(controller controllers.IUserControllerRegistry, service services.IServiceRegistry, group *gin.RouterGroup) IUserRoute {
+	return &UserRoute{controller: controller, service: service, group: group}
 }
 
 func (u *UserRoute) Run() {
 	group := u.group.Group("/auth")
-	group.GET("/user", middlewares.Authenticate(), u.controller.GetUserController().GetUserLogin)
-	group.GET("/:uuid", middlewares.Authenticate(), u.controller.GetUserController().GetUserByUUID)
+	group.GET("/user", middlewares.Authenticate(u.service), u.controller.GetUserController().GetUserLogin)
+	group.GET("/:uuid", middlewares.Authenticate(u.service), u.controller.GetUserController().GetUserByUUID)
 	group.POST("/login", u.controller.GetUserController().Login)
+	group.POST("/login/2fa", u.controller.GetUserController().VerifyMFALogin)
+	group.POST("/login/2fa/setup", u.controller.GetUserController().SetupMFAWithToken)
+	group.POST("/login/reset-password", u.controller.GetUserController().CompletePasswordReset)
+	group.POST("/login/otp", u.controller.GetUserController().RequestLoginOTP)
+	group.POST("/login/otp/verify", u.controller.GetUserController().VerifyLoginOTP)
+	group.GET("/oidc/login", u.controller.GetUserController().OIDCLogin)
+	group.POST("/oidc/callback", u.controller.GetUserController().OIDCCallback)
+	group.POST("/2fa/setup", middlewares.Authenticate(u.service), u.controller.GetUserController().SetupMFA)
+	group.POST("/2fa/confirm", middlewares.Authenticate(u.service), u.controller.GetUserController().ConfirmMFA)
+	group.POST("/2fa/recovery-codes", middlewares.Authenticate(u.service), u.controller.GetUserController().RegenerateRecoveryCodes)
+	group.DELETE("/2fa", middlewares.Authenticate(u.service), u.controller.GetUserController().DisableMFA)
+	group.POST("/phone/verify", middlewares.Authenticate(u.service), u.controller.GetUserController().RequestPhoneVerification)
+	group.POST("/phone/verify/confirm", middlewares.Authenticate(u.service), u.controller.GetUserController().ConfirmPhoneVerification)
+	group.POST("/phone/change/confirm", middlewares.Authenticate(u.service), u.controller.GetUserController().ConfirmPhoneChange)
+	group.POST("/calendar-token", middlewares.Authenticate(u.service), u.controller.GetUserController().RotateCalendarFeedToken)
+	group.DELETE("/calendar-token", middlewares.Authenticate(u.service), u.controller.GetUserController().RevokeCalendarFeedToken)
 	group.POST("/register", u.controller.GetUserController().Register)
-	group.PUT("/:uuid", middlewares.Authenticate(), u.controller.GetUserController().Update)
+	group.PUT("/:uuid", middlewares.Authenticate(u.service), u.controller.GetUserController().Update)
+
+	admin := group.Group("/users")
+	admin.Use(middlewares.Authenticate(u.service), middlewares.CheckRole([]string{constants.AdminCode}))
+	admin.GET("", u.controller.GetUserController().GetAllUsers)
+	admin.GET("/:uuid", u.controller.GetUserController().GetUserDetail)
+	admin.PATCH("/:uuid/activate", u.controller.GetUserController().ActivateUser)
+	admin.PATCH("/:uuid/deactivate", u.controller.GetUserController().DeactivateUser)
+	admin.PATCH("/:uuid/role", u.controller.GetUserController().UpdateUserRole)
+	admin.POST("/:uuid/reset-password", u.controller.GetUserController().ResetUserPassword)
 }
@@ -1,22 +1,26 @@
 package services
 
 import (
+	"user-service/common/oidc"
+	"user-service/common/sms"
 	"user-service/repositories"
 	services "user-service/services/user"
 )
 
 type Registry struct {
 	repository repositories.IRegistryRepository
+	oidc       oidc.IOIDCClient
+	sms        sms.ISender
 }
 
 type IServiceRegistry interface {
 	GetUser() services.IUserService
 }
 
-func NewServiceRegistry(repository repositories.IRegistryRepository) IServiceRegistry {
-	return &Registry{repository: repository}
+func NewServiceRegistry(repository repositories.IRegistryRepository, oidc oidc.IOIDCClient, sms sms.ISender) IServiceRegistry {
+	return &Registry{repository: repository, oidc: oidc, sms: sms}
 }
 
 func (r *Registry) GetUser() services.IUserService {
-	return services.NewUserService(r.repository)
+	return services.NewUserService(r.repository, r.oidc, r.sms)
 }
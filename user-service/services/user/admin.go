@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+	"user-service/common/util"
+	"user-service/constants"
+	"user-service/domain/dto"
+	"user-service/domain/models"
+
+	errWrap "user-service/common/error"
+	errConstant "user-service/constants/error"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const temporaryPasswordSize = 12
+
+func toUserDetailResponse(user *models.User) *dto.UserDetailResponse {
+	return &dto.UserDetailResponse{
+		UUID:                  user.UUID,
+		Name:                  user.Name,
+		Username:              user.Username,
+		Email:                 user.Email,
+		PhoneNumber:           user.PhoneNumber,
+		Role:                  strings.ToLower(user.Role.Code),
+		IsActive:              user.IsActive,
+		MFAEnabled:            user.TOTPEnabled,
+		PasswordResetRequired: user.PasswordResetRequired,
+		CreatedAt:             user.CreatedAt,
+		UpdatedAt:             user.UpdatdeAt,
+	}
+}
+
+// findManagedUser loads the target of an admin action. Admins cannot act on
+// their own account, so a single admin cannot lock everyone out.
+func (s *UserService) findManagedUser(ctx context.Context, uuid string) (*models.User, error) {
+	userLogin := ctx.Value(constants.UserLogin).(*dto.UserResponse)
+	if userLogin.UUID.String() == uuid {
+		return nil, errWrap.WrapError(errConstant.ErrCannotModifySelf)
+	}
+
+	return s.repository.GetUser().FindByUUID(ctx, uuid)
+}
+
+func (s *UserService) GetAllUsers(ctx context.Context, param *dto.UserRequestParam) (*util.PaginationResult, error) {
+	users, total, err := s.repository.GetUser().FindAllWithPagination(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+
+	userResults := make([]dto.UserDetailResponse, 0, len(users))
+	for _, user := range users {
+		userResults = append(userResults, *toUserDetailResponse(&user))
+	}
+
+	pagination := util.PaginationParam{
+		Count: total,
+		Page:  param.Page,
+		Limit: param.Limit,
+		Data:  userResults,
+	}
+
+	response := util.GeneratePagination(pagination)
+	return &response, nil
+}
+
+func (s *UserService) GetUserDetail(ctx context.Context, uuid string) (*dto.UserDetailResponse, error) {
+	user, err := s.repository.GetUser().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	return toUserDetailResponse(user), nil
+}
+
+func (s *UserService) UpdateUserStatus(ctx context.Context, uuid string, isActive bool) (*dto.UserDetailResponse, error) {
+	user, err := s.findManagedUser(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.repository.GetUser().UpdateStatus(ctx, user.ID, isActive)
+	if err != nil {
+		return nil, err
+	}
+
+	user.IsActive = isActive
+	return toUserDetailResponse(user), nil
+}
+
+func (s *UserService) UpdateUserRole(ctx context.Context, uuid string, req *dto.UpdateUserRoleRequest) (*dto.UserDetailResponse, error) {
+	user, err := s.findManagedUser(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := s.repository.GetRole().FindByCode(ctx, req.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.repository.GetUser().UpdateRole(ctx, user.ID, role.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	user.RoleID = role.ID
+	user.Role = *role
+	return toUserDetailResponse(user), nil
+}
+
+// ResetUserPassword replaces the password with a random temporary one that is
+// returned only once. The user has to choose a new password on next login.
+func (s *UserService) ResetUserPassword(ctx context.Context, uuid string) (*dto.ResetPasswordResponse, error) {
+	user, err := s.findManagedUser(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := make([]byte, temporaryPasswordSize)
+	_, err = rand.Read(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	temporaryPassword := base64.RawURLEncoding.EncodeToString(raw)
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(temporaryPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.repository.GetUser().UpdatePassword(ctx, user.ID, string(hashedPassword), true)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.ResetPasswordResponse{TemporaryPassword: temporaryPassword}, nil
+}
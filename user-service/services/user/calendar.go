@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"user-service/common/util"
+	"user-service/domain/dto"
+
+	errWrap "user-service/common/error"
+	errConstant "user-service/constants/error"
+)
+
+const calendarFeedTokenSize = 32
+
+// RotateCalendarFeedToken issues a new feed token for the current user. The
+// previous token, if any, stops working. Only the hash is stored, so the
+// token is shown once.
+func (s *UserService) RotateCalendarFeedToken(ctx context.Context) (*dto.CalendarFeedTokenResponse, error) {
+	user, err := s.userFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := randomToken(calendarFeedTokenSize)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := util.GenerateSHA256(token)
+	err = s.repository.GetUser().UpdateCalendarFeedToken(ctx, user.ID, &hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.CalendarFeedTokenResponse{Token: token}, nil
+}
+
+func (s *UserService) RevokeCalendarFeedToken(ctx context.Context) error {
+	user, err := s.userFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	return s.repository.GetUser().UpdateCalendarFeedToken(ctx, user.ID, nil)
+}
+
+// GetUserByCalendarFeedToken resolves a feed token for the services that
+// serve calendar feeds.
+func (s *UserService) GetUserByCalendarFeedToken(ctx context.Context, req *dto.CalendarFeedTokenRequest) (*dto.UserResponse, error) {
+	user, err := s.repository.GetUser().FindByCalendarFeedToken(ctx, util.GenerateSHA256(req.Token))
+	if err != nil {
+		if errors.Is(err, errConstant.ErrUserNotFound) {
+			return nil, errWrap.WrapError(errConstant.ErrInvalidFeedToken)
+		}
+		return nil, err
+	}
+
+	if !user.IsActive {
+		return nil, errWrap.WrapError(errConstant.ErrUserInactive)
+	}
+
+	return &dto.UserResponse{
+		UUID:        user.UUID,
+		Name:        user.Name,
+		Username:    user.Username,
+		Email:       user.Email,
+		PhoneNumber: user.PhoneNumber,
+		Role:        strings.ToLower(user.Role.Code),
+	}, nil
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+	"time"
+	"user-service/config"
+	"user-service/domain/models"
+
+	errWrap "user-service/common/error"
+	errConstant "user-service/constants/error"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+)
+
+const (
+	mfaAudience           = "mfa"
+	passwordResetAudience = "password-reset"
+)
+
+type ChallengeClaims struct {
+	UserUUID string `json:"userUUID"`
+	jwt.RegisteredClaims
+}
+
+// generateChallengeToken issues the short-lived token that links the password
+// step of the login to a follow-up step (second factor, forced password
+// reset). It carries no user data, so it is rejected by ValidateBearerToken
+// and cannot be used as an access token.
+func (s *UserService) generateChallengeToken(user *models.User, audience string) (string, error) {
+	expiresAt := time.Now().Add(time.Duration(config.Cfg.MFATokenExpiration) * time.Minute)
+	claims := &ChallengeClaims{
+		UserUUID: user.UUID.String(),
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Audience:  jwt.ClaimStrings{audience},
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(config.Cfg.JWTSecretKey))
+}
+
+func (s *UserService) parseChallengeToken(ctx context.Context, tokenString, audience string, errInvalid error) (*models.User, *ChallengeClaims, error) {
+	claims := &ChallengeClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
+		_, ok := t.Method.(*jwt.SigningMethodHMAC)
+		if !ok {
+			return nil, errInvalid
+		}
+		return []byte(config.Cfg.JWTSecretKey), nil
+	})
+	if err != nil || !token.Valid || !claims.VerifyAudience(audience, true) || claims.UserUUID == "" || claims.ID == "" {
+		return nil, nil, errWrap.WrapError(errInvalid)
+	}
+
+	user, err := s.repository.GetUser().FindByUUID(ctx, claims.UserUUID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !user.IsActive {
+		return nil, nil, errWrap.WrapError(errConstant.ErrUserInactive)
+	}
+
+	return user, claims, nil
+}
@@ -0,0 +1,263 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"strings"
+	"time"
+	"user-service/common/totp"
+	"user-service/common/util"
+	"user-service/config"
+	"user-service/constants"
+	"user-service/domain/dto"
+	"user-service/domain/models"
+
+	errWrap "user-service/common/error"
+	errConstant "user-service/constants/error"
+)
+
+const (
+	totpSkew          = 1
+	recoveryCodeCount = 10
+	// mfaMaxAttempts bounds the codes tried with one MFA token. With the skew
+	// three codes are valid at a time, so the login must restart well before
+	// a guess becomes likely.
+	mfaMaxAttempts = 5
+	// mfaUserMaxAttempts bounds the codes tried for one user across MFA
+	// tokens, since a fresh token only costs a password login.
+	mfaUserMaxAttempts = 10
+	mfaLockout         = 15 * time.Minute
+)
+
+func (s *UserService) userFromContext(ctx context.Context) (*models.User, error) {
+	userLogin := ctx.Value(constants.UserLogin).(*dto.UserResponse)
+	return s.repository.GetUser().FindByUUID(ctx, userLogin.UUID.String())
+}
+
+func (s *UserService) setupTOTP(ctx context.Context, user *models.User) (*dto.MFASetupResponse, error) {
+	if user.TOTPEnabled {
+		return nil, errWrap.WrapError(errConstant.ErrMFAAlreadyEnabled)
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.repository.GetUser().UpdateTOTP(ctx, user.ID, &secret, false)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.MFASetupResponse{
+		Secret:     secret,
+		OtpauthURI: totp.URI(config.Cfg.TOTPIssuer, user.Username, secret),
+	}
+
+	return response, nil
+}
+
+func (s *UserService) confirmTOTP(ctx context.Context, user *models.User, code string) ([]string, error) {
+	if user.TOTPEnabled {
+		return nil, errWrap.WrapError(errConstant.ErrMFAAlreadyEnabled)
+	}
+
+	if user.TOTPSecret == nil {
+		return nil, errWrap.WrapError(errConstant.ErrMFASetupNotStarted)
+	}
+
+	step, ok := totp.Validate(*user.TOTPSecret, code, time.Now(), totpSkew)
+	if !ok {
+		return nil, errWrap.WrapError(errConstant.ErrInvalidOTPCode)
+	}
+
+	err := s.repository.GetUser().UpdateTOTP(ctx, user.ID, user.TOTPSecret, true)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.repository.GetUser().UpdateTOTPLastStep(ctx, user.ID, step)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.generateRecoveryCodes(ctx, user.ID)
+}
+
+// verifySecondFactor accepts either a TOTP code or an unused recovery code.
+// A TOTP step can only be used once, so a code observed in transit cannot be
+// replayed within its validity window.
+func (s *UserService) verifySecondFactor(ctx context.Context, user *models.User, code string) error {
+	if !user.TOTPEnabled || user.TOTPSecret == nil {
+		return errWrap.WrapError(errConstant.ErrMFANotEnrolled)
+	}
+
+	step, ok := totp.Validate(*user.TOTPSecret, code, time.Now(), totpSkew)
+	if ok {
+		return s.repository.GetUser().UpdateTOTPLastStep(ctx, user.ID, step)
+	}
+
+	return s.repository.GetRecoveryCode().Use(ctx, user.ID, hashRecoveryCode(code))
+}
+
+func (s *UserService) generateRecoveryCodes(ctx context.Context, userID uint) ([]string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		raw := make([]byte, 5)
+		_, err := rand.Read(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		encoded := strings.ToLower(base32.StdEncoding.EncodeToString(raw))
+		code := encoded[:4] + "-" + encoded[4:]
+		codes = append(codes, code)
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+
+	err := s.repository.GetRecoveryCode().Replace(ctx, userID, hashes)
+	if err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	return util.GenerateSHA256(normalized)
+}
+
+func (s *UserService) SetupMFAWithToken(ctx context.Context, req *dto.MFATokenRequest) (*dto.MFASetupResponse, error) {
+	user, _, err := s.parseChallengeToken(ctx, req.MFAToken, mfaAudience, errConstant.ErrInvalidMFAToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.setupTOTP(ctx, user)
+}
+
+// VerifyMFALogin completes a login started by Login. When the user is still
+// enrolling (admins without TOTP), the code confirms the pending secret and
+// the response also carries the freshly generated recovery codes.
+func (s *UserService) VerifyMFALogin(ctx context.Context, req *dto.MFAVerifyRequest) (*dto.MFALoginResponse, error) {
+	var recoveryCodes []string
+
+	user, claims, err := s.parseChallengeToken(ctx, req.MFAToken, mfaAudience, errConstant.ErrInvalidMFAToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every code tried counts against the token, the right one included, so
+	// the token is dead after mfaMaxAttempts.
+	err = s.repository.GetChallengeAttempt().Take(ctx, claims.ID, claims.ExpiresAt.Time, mfaMaxAttempts)
+	if err != nil {
+		return nil, err
+	}
+
+	// They also count against the user until a code is right, which locks
+	// the user out after mfaUserMaxAttempts whatever the token.
+	err = s.repository.GetUser().TakeMFAAttempt(ctx, user.ID, mfaUserMaxAttempts, mfaLockout)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TOTPEnabled {
+		err = s.verifySecondFactor(ctx, user, req.Code)
+	} else {
+		recoveryCodes, err = s.confirmTOTP(ctx, user, req.Code)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.repository.GetChallengeAttempt().Exhaust(ctx, claims.ID, mfaMaxAttempts)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.repository.GetUser().ResetMFAAttempts(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	login, err := s.generateToken(user)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.MFALoginResponse{
+		User:          login.User,
+		RecoveryCodes: recoveryCodes,
+		Token:         login.Token,
+	}
+
+	return response, nil
+}
+
+func (s *UserService) SetupMFA(ctx context.Context) (*dto.MFASetupResponse, error) {
+	user, err := s.userFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.setupTOTP(ctx, user)
+}
+
+func (s *UserService) ConfirmMFA(ctx context.Context, req *dto.MFACodeRequest) (*dto.MFARecoveryCodesResponse, error) {
+	user, err := s.userFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	codes, err := s.confirmTOTP(ctx, user, req.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.MFARecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+func (s *UserService) RegenerateRecoveryCodes(ctx context.Context, req *dto.MFACodeRequest) (*dto.MFARecoveryCodesResponse, error) {
+	user, err := s.userFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.verifySecondFactor(ctx, user, req.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	codes, err := s.generateRecoveryCodes(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.MFARecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+func (s *UserService) DisableMFA(ctx context.Context, req *dto.MFACodeRequest) error {
+	user, err := s.userFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if user.RoleID == constants.Admin {
+		return errWrap.WrapError(errConstant.ErrMFARequiredForAdmin)
+	}
+
+	err = s.verifySecondFactor(ctx, user, req.Code)
+	if err != nil {
+		return err
+	}
+
+	err = s.repository.GetUser().UpdateTOTP(ctx, user.ID, nil, false)
+	if err != nil {
+		return err
+	}
+
+	return s.repository.GetRecoveryCode().DeleteByUserID(ctx, user.ID)
+}
@@ -0,0 +1,219 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"time"
+	"user-service/common/oidc"
+	"user-service/config"
+	"user-service/constants"
+	"user-service/domain/dto"
+	"user-service/domain/models"
+
+	errWrap "user-service/common/error"
+	errConstant "user-service/constants/error"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/oauth2"
+)
+
+const (
+	oidcUsernamePrefixSize = 10
+	oidcUsernameAttempts   = 5
+)
+
+var usernameSanitizer = regexp.MustCompile(`[^a-z0-9_]`)
+
+func randomToken(size int) (string, error) {
+	raw := make([]byte, size)
+	_, err := rand.Read(raw)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// OIDCLogin starts the authorization-code flow. The state, nonce and PKCE
+// verifier are kept server side and checked when the provider redirects back.
+// The state is also returned for the controller to set in a cookie.
+func (s *UserService) OIDCLogin(ctx context.Context) (*dto.OIDCLoginResponse, error) {
+	if s.oidc == nil {
+		return nil, errWrap.WrapError(errConstant.ErrOIDCNotConfigured)
+	}
+
+	state, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	codeVerifier := oauth2.GenerateVerifier()
+	err = s.repository.GetOIDCState().Create(ctx, &models.OIDCState{
+		State:        state,
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+		ExpiresAt:    time.Now().Add(time.Duration(config.Cfg.OIDC.StateExpiration) * time.Minute),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.OIDCLoginResponse{
+		AuthorizationURL: s.oidc.AuthCodeURL(state, nonce, codeVerifier),
+		State:            state,
+	}
+
+	return response, nil
+}
+
+// OIDCCallback finishes the flow. The state must match the cookie of the
+// browser that started it, which stops a victim from being signed in to the
+// account of an attacker with a forged callback.
+func (s *UserService) OIDCCallback(ctx context.Context, req *dto.OIDCCallbackRequest) (*dto.LoginResponse, error) {
+	if s.oidc == nil {
+		return nil, errWrap.WrapError(errConstant.ErrOIDCNotConfigured)
+	}
+
+	if req.BrowserState == "" || subtle.ConstantTimeCompare([]byte(req.State), []byte(req.BrowserState)) != 1 {
+		return nil, errWrap.WrapError(errConstant.ErrInvalidOIDCState)
+	}
+
+	state, err := s.repository.GetOIDCState().Consume(ctx, req.State)
+	if err != nil {
+		return nil, err
+	}
+
+	identity, err := s.oidc.Exchange(ctx, req.Code, state.CodeVerifier, state.Nonce)
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrOIDCLoginFailed)
+	}
+
+	user, err := s.findOrCreateOIDCUser(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsActive {
+		return nil, errWrap.WrapError(errConstant.ErrUserInactive)
+	}
+
+	return s.continueLogin(user)
+}
+
+// findOrCreateOIDCUser resolves the identity to a user. An existing account is
+// only linked by email when the provider has verified that email, otherwise
+// anyone could take over an account by registering its address upstream.
+func (s *UserService) findOrCreateOIDCUser(ctx context.Context, identity *oidc.Identity) (*models.User, error) {
+	linked, err := s.repository.GetUserIdentity().FindByProviderSubject(ctx, identity.Provider, identity.Subject)
+	if err != nil && !errors.Is(err, errConstant.ErrUserNotFound) {
+		return nil, err
+	}
+	if linked != nil {
+		return &linked.User, nil
+	}
+
+	if identity.Email == "" || !identity.EmailVerified {
+		return nil, errWrap.WrapError(errConstant.ErrOIDCEmailUnverified)
+	}
+
+	newIdentity := &models.UserIdentity{
+		Provider: identity.Provider,
+		Subject:  identity.Subject,
+		Email:    identity.Email,
+	}
+
+	user, err := s.repository.GetUser().FindByEmail(ctx, identity.Email)
+	if err != nil && !errors.Is(err, errConstant.ErrUserNotFound) {
+		return nil, err
+	}
+	if user != nil {
+		newIdentity.UserID = user.ID
+		err = s.repository.GetUserIdentity().Create(ctx, newIdentity)
+		if err != nil {
+			return nil, err
+		}
+		return user, nil
+	}
+
+	return s.createOIDCUser(ctx, identity, newIdentity)
+}
+
+func (s *UserService) createOIDCUser(ctx context.Context, identity *oidc.Identity, newIdentity *models.UserIdentity) (*models.User, error) {
+	username, err := s.generateUsername(ctx, identity.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	// Social accounts get a random password nobody knows; the owner can set a
+	// real one later through the regular update endpoint.
+	password, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	name := identity.Name
+	if name == "" {
+		name = username
+	}
+
+	user := &models.User{
+		UUID:     uuid.New(),
+		Name:     name,
+		Username: username,
+		Password: string(hashedPassword),
+		Email:    identity.Email,
+		RoleID:   constants.Customer,
+		IsActive: true,
+	}
+
+	err = s.repository.GetUserIdentity().CreateWithUser(ctx, user, newIdentity)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repository.GetUser().FindByUUID(ctx, user.UUID.String())
+}
+
+func (s *UserService) generateUsername(ctx context.Context, email string) (string, error) {
+	prefix := strings.ToLower(strings.Split(email, "@")[0])
+	prefix = usernameSanitizer.ReplaceAllString(prefix, "")
+	if len(prefix) > oidcUsernamePrefixSize {
+		prefix = prefix[:oidcUsernamePrefixSize]
+	}
+
+	for i := 0; i < oidcUsernameAttempts; i++ {
+		suffix, err := rand.Int(rand.Reader, big.NewInt(10000))
+		if err != nil {
+			return "", err
+		}
+
+		username := fmt.Sprintf("%s%04d", prefix, suffix.Int64())
+		_, err = s.repository.GetUser().FindByUsername(ctx, username)
+		if errors.Is(err, errConstant.ErrUserNotFound) {
+			return username, nil
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return "", errWrap.WrapError(errConstant.ErrUsernameExists)
+}
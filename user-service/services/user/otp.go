@@ -0,0 +1,238 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+	"user-service/common/util"
+	"user-service/config"
+	"user-service/constants"
+	"user-service/domain/dto"
+	"user-service/domain/models"
+
+	errWrap "user-service/common/error"
+	errConstant "user-service/constants/error"
+)
+
+const (
+	otpDigits                = 6
+	defaultOTPExpiration     = 5 * time.Minute
+	defaultOTPMaxAttempts    = 5
+	defaultOTPResendInterval = time.Minute
+)
+
+func otpExpiration() time.Duration {
+	if config.Cfg.OTP.Expiration > 0 {
+		return time.Duration(config.Cfg.OTP.Expiration) * time.Minute
+	}
+	return defaultOTPExpiration
+}
+
+func otpMaxAttempts() int {
+	if config.Cfg.OTP.MaxAttempts > 0 {
+		return config.Cfg.OTP.MaxAttempts
+	}
+	return defaultOTPMaxAttempts
+}
+
+func otpResendInterval() time.Duration {
+	if config.Cfg.OTP.ResendInterval > 0 {
+		return time.Duration(config.Cfg.OTP.ResendInterval) * time.Second
+	}
+	return defaultOTPResendInterval
+}
+
+// hashOTP binds the code to the phone number it was sent to and peppers it
+// with the server secret, so the short numeric code cannot be brute forced
+// from a leaked table.
+func hashOTP(phoneNumber, code string) string {
+	return util.GenerateSHA256(fmt.Sprintf("%s:%s:%s", config.Cfg.JWTSecretKey, phoneNumber, code))
+}
+
+func generateOTPCode() (string, error) {
+	max := big.NewInt(1)
+	for i := 0; i < otpDigits; i++ {
+		max.Mul(max, big.NewInt(10))
+	}
+
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%0*d", otpDigits, n.Int64()), nil
+}
+
+func (s *UserService) sendOTP(ctx context.Context, user *models.User, purpose, phoneNumber string) (*dto.OTPSentResponse, error) {
+	active, err := s.repository.GetPhoneOTP().FindActive(ctx, user.ID, purpose)
+	if err != nil && !errors.Is(err, errConstant.ErrOTPNotFound) {
+		return nil, err
+	}
+	if active != nil && active.CreatedAt != nil && time.Since(*active.CreatedAt) < otpResendInterval() {
+		return nil, errWrap.WrapError(errConstant.ErrOTPResendTooSoon)
+	}
+
+	code, err := generateOTPCode()
+	if err != nil {
+		return nil, err
+	}
+
+	otp := &models.PhoneOTP{
+		UserID:      user.ID,
+		Purpose:     purpose,
+		PhoneNumber: phoneNumber,
+		CodeHash:    hashOTP(phoneNumber, code),
+		ExpiresAt:   time.Now().Add(otpExpiration()),
+	}
+	err = s.repository.GetPhoneOTP().Create(ctx, otp)
+	if err != nil {
+		return nil, err
+	}
+
+	message := fmt.Sprintf("Your verification code is %s. It expires in %d minutes.", code, int(otpExpiration().Minutes()))
+	err = s.sms.Send(ctx, phoneNumber, message)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.OTPSentResponse{
+		PhoneNumber: phoneNumber,
+		ExpiresAt:   otp.ExpiresAt,
+	}
+
+	return response, nil
+}
+
+// verifyOTP checks the latest pending code for the purpose. Every guess is
+// counted against the code before it is compared, and once the limit is
+// reached the code is dead even if the right value is sent afterwards.
+func (s *UserService) verifyOTP(ctx context.Context, user *models.User, purpose, code string) (*models.PhoneOTP, error) {
+	otp, err := s.repository.GetPhoneOTP().FindActive(ctx, user.ID, purpose)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.repository.GetPhoneOTP().TakeAttempt(ctx, otp.ID, otpMaxAttempts())
+	if err != nil {
+		return nil, err
+	}
+
+	if !hmac.Equal([]byte(otp.CodeHash), []byte(hashOTP(otp.PhoneNumber, code))) {
+		return nil, errWrap.WrapError(errConstant.ErrInvalidOTPCode)
+	}
+
+	err = s.repository.GetPhoneOTP().Consume(ctx, otp.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return otp, nil
+}
+
+func (s *UserService) ensurePhoneAvailable(ctx context.Context, userID uint, phoneNumber string) error {
+	owner, err := s.repository.GetUser().FindByVerifiedPhoneNumber(ctx, phoneNumber)
+	if err != nil && !errors.Is(err, errConstant.ErrUserNotFound) {
+		return err
+	}
+
+	if owner != nil && owner.ID != userID {
+		return errWrap.WrapError(errConstant.ErrPhoneExists)
+	}
+
+	return nil
+}
+
+func (s *UserService) RequestLoginOTP(ctx context.Context, req *dto.OTPLoginRequest) (*dto.OTPSentResponse, error) {
+	user, err := s.repository.GetUser().FindByVerifiedPhoneNumber(ctx, req.PhoneNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsActive {
+		return nil, errWrap.WrapError(errConstant.ErrUserInactive)
+	}
+
+	return s.sendOTP(ctx, user, constants.OTPPurposeLogin, user.PhoneNumber)
+}
+
+func (s *UserService) VerifyLoginOTP(ctx context.Context, req *dto.OTPLoginVerifyRequest) (*dto.LoginResponse, error) {
+	user, err := s.repository.GetUser().FindByVerifiedPhoneNumber(ctx, req.PhoneNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsActive {
+		return nil, errWrap.WrapError(errConstant.ErrUserInactive)
+	}
+
+	_, err = s.verifyOTP(ctx, user, constants.OTPPurposeLogin, req.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.continueLogin(user)
+}
+
+func (s *UserService) RequestPhoneVerification(ctx context.Context) (*dto.OTPSentResponse, error) {
+	user, err := s.userFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.PhoneVerifiedAt != nil {
+		return nil, errWrap.WrapError(errConstant.ErrPhoneAlreadyVerified)
+	}
+
+	err = s.ensurePhoneAvailable(ctx, user.ID, user.PhoneNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.sendOTP(ctx, user, constants.OTPPurposeVerifyPhone, user.PhoneNumber)
+}
+
+func (s *UserService) ConfirmPhoneVerification(ctx context.Context, req *dto.PhoneCodeRequest) (*dto.UserResponse, error) {
+	return s.confirmPhone(ctx, constants.OTPPurposeVerifyPhone, req.Code)
+}
+
+func (s *UserService) ConfirmPhoneChange(ctx context.Context, req *dto.PhoneCodeRequest) (*dto.UserResponse, error) {
+	return s.confirmPhone(ctx, constants.OTPPurposeChangePhone, req.Code)
+}
+
+// confirmPhone applies the phone number the code was sent to, which for a
+// change is the new number requested through Update.
+func (s *UserService) confirmPhone(ctx context.Context, purpose, code string) (*dto.UserResponse, error) {
+	user, err := s.userFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	otp, err := s.verifyOTP(ctx, user, purpose, code)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.ensurePhoneAvailable(ctx, user.ID, otp.PhoneNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.repository.GetUser().UpdatePhoneNumber(ctx, user.ID, otp.PhoneNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.UserResponse{
+		UUID:        user.UUID,
+		Name:        user.Name,
+		Username:    user.Username,
+		Email:       user.Email,
+		PhoneNumber: otp.PhoneNumber,
+	}
+
+	return response, nil
+}
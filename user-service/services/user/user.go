@@ -5,9 +5,13 @@ import (
 	"errors"
 	"strings"
 	"time"
+	"user-service/common/oidc"
+	"user-service/common/sms"
+	"user-service/common/util"
 	"user-service/config"
 	"user-service/constants"
 	"user-service/domain/dto"
+	"user-service/domain/models"
 	"user-service/repositories"
 
 	errWrap "user-service/common/error"
@@ -19,6 +23,8 @@ import (
 
 type UserService struct {
 	repository repositories.IRegistryRepository
+	oidc       oidc.IOIDCClient
+	sms        sms.ISender
 }
 
 type IUserService interface {
@@ -27,6 +33,29 @@ type IUserService interface {
 	Update(context.Context, *dto.UpdateRequest, string) (*dto.UserResponse, error)
 	GetUserLogin(context.Context) (*dto.UserResponse, error)
 	GetUserByUUID(context.Context, string) (*dto.UserResponse, error)
+	SetupMFAWithToken(context.Context, *dto.MFATokenRequest) (*dto.MFASetupResponse, error)
+	VerifyMFALogin(context.Context, *dto.MFAVerifyRequest) (*dto.MFALoginResponse, error)
+	SetupMFA(context.Context) (*dto.MFASetupResponse, error)
+	ConfirmMFA(context.Context, *dto.MFACodeRequest) (*dto.MFARecoveryCodesResponse, error)
+	RegenerateRecoveryCodes(context.Context, *dto.MFACodeRequest) (*dto.MFARecoveryCodesResponse, error)
+	DisableMFA(context.Context, *dto.MFACodeRequest) error
+	CompletePasswordReset(context.Context, *dto.CompletePasswordResetRequest) (*dto.LoginResponse, error)
+	ValidateSession(context.Context, *Claims) (*dto.UserResponse, error)
+	GetAllUsers(context.Context, *dto.UserRequestParam) (*util.PaginationResult, error)
+	GetUserDetail(context.Context, string) (*dto.UserDetailResponse, error)
+	UpdateUserStatus(context.Context, string, bool) (*dto.UserDetailResponse, error)
+	UpdateUserRole(context.Context, string, *dto.UpdateUserRoleRequest) (*dto.UserDetailResponse, error)
+	ResetUserPassword(context.Context, string) (*dto.ResetPasswordResponse, error)
+	OIDCLogin(context.Context) (*dto.OIDCLoginResponse, error)
+	OIDCCallback(context.Context, *dto.OIDCCallbackRequest) (*dto.LoginResponse, error)
+	RequestLoginOTP(context.Context, *dto.OTPLoginRequest) (*dto.OTPSentResponse, error)
+	VerifyLoginOTP(context.Context, *dto.OTPLoginVerifyRequest) (*dto.LoginResponse, error)
+	RequestPhoneVerification(context.Context) (*dto.OTPSentResponse, error)
+	ConfirmPhoneVerification(context.Context, *dto.PhoneCodeRequest) (*dto.UserResponse, error)
+	ConfirmPhoneChange(context.Context, *dto.PhoneCodeRequest) (*dto.UserResponse, error)
+	RotateCalendarFeedToken(context.Context) (*dto.CalendarFeedTokenResponse, error)
+	RevokeCalendarFeedToken(context.Context) error
+	GetUserByCalendarFeedToken(context.Context, *dto.CalendarFeedTokenRequest) (*dto.UserResponse, error)
 }
 
 type Claims struct {
@@ -34,8 +63,8 @@ type Claims struct {
 	jwt.RegisteredClaims
 }
 
-func NewUserService(repository repositories.IRegistryRepository) IUserService {
-	return &UserService{repository: repository}
+func NewUserService(repository repositories.IRegistryRepository, oidc oidc.IOIDCClient, sms sms.ISender) IUserService {
+	return &UserService{repository: repository, oidc: oidc, sms: sms}
 }
 
 func (s *UserService) Login(ctx context.Context, req *dto.LoginRequest) (*dto.LoginResponse, error) {
@@ -49,6 +78,50 @@ func (s *UserService) Login(ctx context.Context, req *dto.LoginRequest) (*dto.Lo
 		return nil, err
 	}
 
+	if !user.IsActive {
+		return nil, errWrap.WrapError(errConstant.ErrUserInactive)
+	}
+
+	return s.continueLogin(user)
+}
+
+// continueLogin runs the steps that follow a successful password check: a
+// pending forced password reset first, then the second factor, and only then
+// the access token.
+func (s *UserService) continueLogin(user *models.User) (*dto.LoginResponse, error) {
+	if user.PasswordResetRequired {
+		resetToken, err := s.generateChallengeToken(user, passwordResetAudience)
+		if err != nil {
+			return nil, err
+		}
+
+		response := &dto.LoginResponse{
+			PasswordReset: &dto.PasswordResetChallengeResponse{
+				ResetToken: resetToken,
+			},
+		}
+		return response, nil
+	}
+
+	if user.TOTPEnabled || user.RoleID == constants.Admin {
+		mfaToken, err := s.generateChallengeToken(user, mfaAudience)
+		if err != nil {
+			return nil, err
+		}
+
+		response := &dto.LoginResponse{
+			MFA: &dto.MFAChallengeResponse{
+				MFAToken:           mfaToken,
+				EnrollmentRequired: !user.TOTPEnabled,
+			},
+		}
+		return response, nil
+	}
+
+	return s.generateToken(user)
+}
+
+func (s *UserService) generateToken(user *models.User) (*dto.LoginResponse, error) {
 	TokenExpireTime := time.Now().Add(time.Duration(config.Cfg.JWTExpirationTime) * time.Minute).Unix()
 	data := &dto.UserResponse{
 		UUID:        user.UUID,
@@ -62,6 +135,7 @@ func (s *UserService) Login(ctx context.Context, req *dto.LoginRequest) (*dto.Lo
 	claims := &Claims{
 		User: data,
 		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			ExpiresAt: jwt.NewNumericDate(time.Unix(TokenExpireTime, 0)),
 		},
 	}
@@ -163,18 +237,45 @@ func (s *UserService) Update(ctx context.Context, req *dto.UpdateRequest, uuid s
 		req.Password = string(hashedPassword)
 	}
 
-	req.RoleID = constants.Customer
+	// A new phone number is only stored once it has been confirmed with the
+	// code sent to it, see ConfirmPhoneChange. The code is sent before the
+	// other changes are saved, so a code that cannot be sent yet fails the
+	// whole update instead of half of it.
+	var pendingPhoneNumber *string
+	if req.PhoneNumber != getUser.PhoneNumber {
+		err = s.ensurePhoneAvailable(ctx, getUser.ID, req.PhoneNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = s.sendOTP(ctx, getUser, constants.OTPPurposeChangePhone, req.PhoneNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		pendingPhoneNumber = &req.PhoneNumber
+		req = &dto.UpdateRequest{
+			Name:        req.Name,
+			Username:    req.Username,
+			Password:    req.Password,
+			ConfirmPass: req.ConfirmPass,
+			Email:       req.Email,
+			PhoneNumber: getUser.PhoneNumber,
+		}
+	}
+
 	Update, err := s.repository.GetUser().Update(ctx, req, uuid)
 	if err != nil {
 		return nil, err
 	}
 
 	response := &dto.UserResponse{
-		UUID:        Update.UUID,
-		Name:        Update.Name,
-		Username:    Update.Username,
-		Email:       Update.Email,
-		PhoneNumber: Update.PhoneNumber,
+		UUID:               Update.UUID,
+		Name:               Update.Name,
+		Username:           Update.Username,
+		Email:              Update.Email,
+		PhoneNumber:        Update.PhoneNumber,
+		PendingPhoneNumber: pendingPhoneNumber,
 	}
 
 	return response, nil
@@ -214,3 +315,61 @@ func (s *UserService) GetUserByUUID(ctx context.Context, uuid string) (*dto.User
 
 	return &data, nil
 }
+
+func (s *UserService) CompletePasswordReset(ctx context.Context, req *dto.CompletePasswordResetRequest) (*dto.LoginResponse, error) {
+	user, _, err := s.parseChallengeToken(ctx, req.ResetToken, passwordResetAudience, errConstant.ErrInvalidResetToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.PasswordResetRequired {
+		return nil, errWrap.WrapError(errConstant.ErrInvalidResetToken)
+	}
+
+	if req.Password != req.ConfirmPass {
+		return nil, errWrap.WrapError(errConstant.ErrPasswordDoesNotMatch)
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.repository.GetUser().UpdatePassword(ctx, user.ID, string(hashedPassword), false)
+	if err != nil {
+		return nil, err
+	}
+
+	user.PasswordResetRequired = false
+	return s.continueLogin(user)
+}
+
+// ValidateSession reloads the token owner so that deactivation, role changes
+// and password resets take effect before the token expires.
+func (s *UserService) ValidateSession(ctx context.Context, claims *Claims) (*dto.UserResponse, error) {
+	user, err := s.repository.GetUser().FindByUUID(ctx, claims.User.UUID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsActive {
+		return nil, errWrap.WrapError(errConstant.ErrUserInactive)
+	}
+
+	if user.PasswordChangedAt != nil {
+		if claims.IssuedAt == nil || claims.IssuedAt.Time.Before(user.PasswordChangedAt.Truncate(time.Second)) {
+			return nil, errWrap.WrapError(errConstant.ErrUnauthorized)
+		}
+	}
+
+	data := &dto.UserResponse{
+		UUID:        user.UUID,
+		Name:        user.Name,
+		Username:    user.Username,
+		PhoneNumber: user.PhoneNumber,
+		Email:       user.Email,
+		Role:        strings.ToLower(user.Role.Code),
+	}
+
+	return data, nil
+}